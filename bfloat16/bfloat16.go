@@ -0,0 +1,57 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bfloat16 implements bfloat16 ("brain") floating-point numbers,
+// the upper half of IEEE 754 single-precision numbers, as used by machine
+// learning frameworks such as JAX and TensorFlow.
+//
+// NumPy has no bfloat16 data type: arrays of the bfloat16 type of the
+// ml_dtypes package are stored with the 2-byte void data type ('<V2').
+package bfloat16 // import "github.com/sbinet/npyio/bfloat16"
+
+import (
+	"math"
+	"strconv"
+)
+
+// Num is a bfloat16 floating-point number, stored as its bit pattern.
+type Num uint16
+
+// MaxValue is the largest finite Num value.
+const MaxValue = 0x1.fep127
+
+// New returns the Num value nearest to f, rounding ties to even.
+// Values too large for a Num become infinities, and NaNs stay NaNs.
+func New(f float32) Num {
+	bits := math.Float32bits(f)
+	if f != f {
+		// keep the most significant bits of the payload, and make sure
+		// the result is still a NaN.
+		return Num(bits>>16 | 0x40)
+	}
+	// rounding may carry into the exponent, up to infinity.
+	bits += 0x7fff + (bits>>16)&1
+	return Num(bits >> 16)
+}
+
+// Float32 returns the value of h as a float32.
+// The conversion is exact.
+func (h Num) Float32() float32 {
+	return math.Float32frombits(uint32(h) << 16)
+}
+
+// Float64 returns the value of h as a float64.
+// The conversion is exact.
+func (h Num) Float64() float64 {
+	return float64(h.Float32())
+}
+
+// IsNaN reports whether h is a NaN.
+func (h Num) IsNaN() bool {
+	return h&0x7f80 == 0x7f80 && h&0x7f != 0
+}
+
+func (h Num) String() string {
+	return strconv.FormatFloat(float64(h.Float32()), 'g', -1, 32)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bfloat16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		f    float32
+		want Num
+	}{
+		{"zero", 0, 0x0000},
+		{"neg-zero", float32(math.Copysign(0, -1)), 0x8000},
+		{"one", 1, 0x3f80},
+		{"neg-two", -2, 0xc000},
+		{"third", 1.0 / 3, 0x3eab},
+		{"max", MaxValue, 0x7f7f},
+		{"overflow", math.MaxFloat32, 0x7f80},
+		{"inf", float32(math.Inf(+1)), 0x7f80},
+		{"neg-inf", float32(math.Inf(-1)), 0xff80},
+		{"min-normal", 0x1p-126, 0x0080},
+		{"min-subnormal", 0x1p-133, 0x0001},
+		{"tie-to-even-down", 1 + 0x1p-8, 0x3f80},
+		{"tie-to-even-up", 1 + 0x3p-8, 0x3f82},
+		{"above-tie", 1 + 0x1p-8 + 0x1p-20, 0x3f81},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := New(tc.f); got != tc.want {
+				t.Fatalf("invalid value: got=0x%04x, want=0x%04x", uint16(got), uint16(tc.want))
+			}
+		})
+	}
+}
+
+func TestNewNaN(t *testing.T) {
+	for _, f := range []float32{
+		float32(math.NaN()),
+		-float32(math.NaN()),
+		math.Float32frombits(0x7f800001), // payload lost by truncation
+		math.Float32frombits(0x7fffffff), // largest payload
+	} {
+		if h := New(f); !h.IsNaN() {
+			t.Fatalf("New(%v) = 0x%04x is not a NaN", f, uint16(h))
+		}
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	for _, tc := range []struct {
+		h    Num
+		want float32
+	}{
+		{0x0000, 0},
+		{0x3f80, 1},
+		{0xc000, -2},
+		{0x7f7f, MaxValue},
+		{0x0080, 0x1p-126},
+		{0x0001, 0x1p-133},
+		{0x8001, -0x1p-133},
+		{0x7f80, float32(math.Inf(+1))},
+		{0xff80, float32(math.Inf(-1))},
+	} {
+		if got := tc.h.Float32(); got != tc.want {
+			t.Fatalf("invalid value for 0x%04x: got=%v, want=%v", uint16(tc.h), got, tc.want)
+		}
+	}
+
+	if got := Num(0x8000).Float32(); got != 0 || !math.Signbit(float64(got)) {
+		t.Fatalf("invalid negative zero: got=%v", got)
+	}
+	if got := Num(0x7fc0).Float32(); !math.IsNaN(float64(got)) {
+		t.Fatalf("invalid NaN: got=%v", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for i := 0; i <= math.MaxUint16; i++ {
+		h := Num(i)
+		got := New(h.Float32())
+		switch {
+		case h.IsNaN():
+			if !got.IsNaN() {
+				t.Fatalf("NaN 0x%04x round-tripped to 0x%04x", i, uint16(got))
+			}
+		case got != h:
+			t.Fatalf("0x%04x round-tripped to 0x%04x", i, uint16(got))
+		}
+	}
+}
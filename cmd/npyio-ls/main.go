@@ -17,15 +17,27 @@ func main() {
 	log.SetPrefix("npyio-ls: ")
 	log.SetFlags(0)
 
+	var (
+		prec = flag.Int("prec", 0, "number of digits displayed after the decimal point (0: shortest representation)")
+		nmax = flag.Int("max", 0, "maximum number of elements displayed per array (0: no limit)")
+		hex  = flag.Bool("hex", false, "display integers in hexadecimal")
+	)
+
 	flag.Parse()
 
-	if len(os.Args) <= 1 {
+	if flag.NArg() == 0 {
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	opts := npyio.DumpOpts{
+		Precision: *prec,
+		MaxElems:  *nmax,
+		Hex:       *hex,
+	}
+
 	allgood := true
-	for i, fname := range os.Args[1:] {
+	for i, fname := range flag.Args() {
 		if i > 0 {
 			fmt.Printf("\n")
 		}
@@ -38,7 +50,7 @@ func main() {
 		}
 		defer f.Close()
 
-		err = npyio.Dump(os.Stdout, f)
+		err = opts.Dump(os.Stdout, f)
 		if err != nil {
 			log.Printf("could not dump %q: %+v\n", fname, err)
 			allgood = false
@@ -5,24 +5,58 @@
 package npyio
 
 import (
-	"bytes"
+	"archive/zip"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 
+	"github.com/sbinet/npyio/float16"
 	"github.com/sbinet/npyio/npy"
-	"github.com/sbinet/npyio/npz"
 )
 
 // Dump dumps the content of the provided reader to the writer,
 // in a human readable format
+//
+// The elements of arrays are displayed in the order they are stored,
+// see DumpOpts to display them in logical order.
 func Dump(o io.Writer, r io.ReaderAt) error {
+	return DumpOpts{}.Dump(o, r)
+}
+
+// DumpOpts configures how the content of NumPy files is dumped.
+type DumpOpts struct {
+	// LogicalOrder displays the elements of Fortran-ordered arrays in
+	// logical order, i.e. in C-order (row-major), as NumPy's
+	// arr.ravel() would, instead of in the column-major order they are
+	// stored in.
+	// The elements of C-ordered arrays are always displayed in logical
+	// order.
+	LogicalOrder bool
+
+	// Precision, if positive, is the number of digits displayed after the
+	// decimal point for floating-point and complex elements.
+	Precision int
+
+	// MaxElems, if positive, is the maximum number of elements displayed
+	// for an array.
+	// Larger arrays are summarized, as NumPy does, by their first and last
+	// elements, separated by an ellipsis.
+	MaxElems int
+
+	// Hex displays integer elements in hexadecimal.
+	Hex bool
+}
+
+// Dump dumps the content of the provided reader to the writer,
+// in a human readable format
+func (opts DumpOpts) Dump(o io.Writer, r io.ReaderAt) error {
 	var (
-		err      error
-		zipMagic = [4]byte{'P', 'K', 3, 4}
-		fname    = "input.npy"
+		err   error
+		fname = "input.npy"
 	)
 
 	if r, ok := r.(interface{ Name() string }); ok {
@@ -32,9 +66,7 @@ func Dump(o io.Writer, r io.ReaderAt) error {
 	fmt.Fprintf(o, strings.Repeat("=", 80)+"\n")
 	fmt.Fprintf(o, "file: %v\n", fname)
 
-	// detect .npz files (check if we find a ZIP file magic header)
-	var hdr [6]byte
-	_, err = r.ReadAt(hdr[:], 0)
+	format, err := Peek(r)
 	if err != nil {
 		return fmt.Errorf("npyio: could not infer format: %w", err)
 	}
@@ -71,56 +103,91 @@ func Dump(o io.Writer, r io.ReaderAt) error {
 		return fmt.Errorf("npyio: could not infer file size: %w", err)
 	}
 
-	switch {
-	case bytes.Equal(npy.Magic[:], hdr[:]):
-		err = display(o, io.NewSectionReader(r, 0, sz), fname)
+	switch format {
+	case FormatNPY:
+		err = opts.display(o, io.NewSectionReader(r, 0, sz), fname)
 		if err != nil {
 			return fmt.Errorf("npyio: could not display ile: %w", err)
 		}
 
-	case bytes.Equal(zipMagic[:], hdr[:len(zipMagic)]):
-		zr, err := npz.NewReader(r, sz)
+	case FormatNPZ:
+		err = opts.dumpZip(o, r, sz, fname)
 		if err != nil {
-			return fmt.Errorf("npyio: could not create npz reader: %w", err)
+			return err
 		}
-		defer zr.Close()
 
-		for i, name := range zr.Keys() {
-			r, err := zr.Open(name)
-			if err != nil {
-				return fmt.Errorf(
-					"npyio: could not open npz entry %s: %w",
-					name, err,
-				)
-			}
-			defer r.Close()
-			if i > 0 {
-				fmt.Fprintf(o, "\n")
-			}
-			fmt.Fprintf(o, "entry: %s\n", name)
-			err = display(o, r, fname+"@"+name)
-			if err != nil {
-				return fmt.Errorf(
-					"npyio: could not display npz entry %s: %w",
-					name, err,
-				)
-			}
-			err = r.Close()
-			if err != nil {
-				return fmt.Errorf(
-					"npyio: could not close npz entry %s: %w",
-					name, err,
-				)
-			}
-		}
 	default:
-		return fmt.Errorf("npyio: unknown magic header %q", string(hdr[:]))
+		return fmt.Errorf("npyio: unknown file format")
+	}
+
+	return nil
+}
+
+// DumpZip dumps the content of the provided compressed NumPy data file,
+// of the given size in bytes, to the writer, in a human readable format.
+//
+// Members of the archive are decoded and displayed one at a time, in the
+// order of the archive directory, so that the memory used does not depend
+// on the number of members.
+func DumpZip(o io.Writer, r io.ReaderAt, size int64) error {
+	return DumpOpts{}.DumpZip(o, r, size)
+}
+
+// DumpZip dumps the content of the provided compressed NumPy data file,
+// of the given size in bytes, to the writer, in a human readable format.
+func (opts DumpOpts) DumpZip(o io.Writer, r io.ReaderAt, size int64) error {
+	fname := "input.npz"
+	if r, ok := r.(interface{ Name() string }); ok {
+		fname = r.Name()
+	}
+
+	fmt.Fprintf(o, strings.Repeat("=", 80)+"\n")
+	fmt.Fprintf(o, "file: %v\n", fname)
+	return opts.dumpZip(o, r, size, fname)
+}
+
+func (opts DumpOpts) dumpZip(o io.Writer, r io.ReaderAt, size int64, fname string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("npyio: could not create npz reader: %w", err)
+	}
+
+	for i, f := range zr.File {
+		if i > 0 {
+			fmt.Fprintf(o, "\n")
+		}
+		fmt.Fprintf(o, "entry: %s\n", f.Name)
+		err := opts.displayMember(o, f, fname+"@"+f.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// displayMember displays the npz member f, releasing its resources before
+// returning.
+func (opts DumpOpts) displayMember(o io.Writer, f *zip.File, fname string) error {
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("npyio: could not open npz entry %s: %w", f.Name, err)
+	}
+	defer r.Close()
+
+	err = opts.display(o, r, fname)
+	if err != nil {
+		return fmt.Errorf("npyio: could not display npz entry %s: %w", f.Name, err)
 	}
 
+	err = r.Close()
+	if err != nil {
+		return fmt.Errorf("npyio: could not close npz entry %s: %w", f.Name, err)
+	}
 	return nil
 }
 
-func display(o io.Writer, f io.Reader, fname string) error {
+func (opts DumpOpts) display(o io.Writer, f io.Reader, fname string) error {
 	r, err := npy.NewReader(f)
 	if err != nil {
 		return fmt.Errorf("npyio: could not create npy reader %s: %w", fname, err)
@@ -137,6 +204,152 @@ func display(o io.Writer, f io.Reader, fname string) error {
 	if err != nil && err != io.EOF {
 		return fmt.Errorf("npyio: read error: %w", err)
 	}
-	fmt.Fprintf(o, "data = %v\n", rv.Elem().Interface())
+	data := rv.Elem()
+	if opts.LogicalOrder && r.Header.Descr.Fortran {
+		data = cOrder(data, r.Header.Descr.Shape)
+	}
+	fmt.Fprintf(o, "data = %s\n", opts.format(data))
 	return nil
 }
+
+// format returns the representation of the slice of elements data.
+func (opts DumpOpts) format(data reflect.Value) string {
+	if opts.Precision <= 0 && opts.MaxElems <= 0 && !opts.Hex && !isFloat(data.Type().Elem()) {
+		return fmt.Sprintf("%v", data.Interface())
+	}
+
+	var (
+		n    = data.Len()
+		head = n
+		tail = 0
+		o    = new(strings.Builder)
+	)
+	if opts.MaxElems > 0 && n > opts.MaxElems {
+		tail = opts.MaxElems / 2
+		head = opts.MaxElems - tail
+	}
+
+	o.WriteString("[")
+	for i := 0; i < head; i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		o.WriteString(opts.formatElem(data.Index(i)))
+	}
+	if head+tail < n {
+		o.WriteString(" ...")
+	}
+	for i := n - tail; i < n; i++ {
+		o.WriteString(" ")
+		o.WriteString(opts.formatElem(data.Index(i)))
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+// formatElem returns the representation of the array element v.
+//
+// Floating-point NaNs and infinities are displayed as NumPy does, as nan,
+// inf and -inf.
+func (opts DumpOpts) formatElem(v reflect.Value) string {
+	if h, ok := v.Interface().(float16.Num); ok {
+		if s, ok := formatNonFinite(h.Float64()); ok {
+			return s
+		}
+		if opts.Precision > 0 {
+			return strconv.FormatFloat(h.Float64(), 'f', opts.Precision, 32)
+		}
+	}
+	if v.Type().PkgPath() != "" {
+		// named types (float16.Num, time.Duration, ...) have their own
+		// representation.
+		return fmt.Sprintf("%v", v.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if opts.Hex {
+			x := v.Int()
+			if x < 0 {
+				return "-0x" + strconv.FormatUint(-uint64(x), 16)
+			}
+			return "0x" + strconv.FormatInt(x, 16)
+		}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if opts.Hex {
+			return "0x" + strconv.FormatUint(v.Uint(), 16)
+		}
+	case reflect.Float32, reflect.Float64:
+		if s, ok := formatNonFinite(v.Float()); ok {
+			return s
+		}
+		if opts.Precision > 0 {
+			return strconv.FormatFloat(v.Float(), 'f', opts.Precision, v.Type().Bits())
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if opts.Precision > 0 {
+			c := v.Complex()
+			return fmt.Sprintf("(%.*f%+.*fi)", opts.Precision, real(c), opts.Precision, imag(c))
+		}
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// isFloat returns whether rt is a floating-point type, whose NaNs and
+// infinities are displayed as NumPy does.
+func isFloat(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	}
+	return rt == reflect.TypeOf(float16.Num(0))
+}
+
+// formatNonFinite returns the NumPy representation of f, and whether f is
+// a NaN or an infinity.
+func formatNonFinite(f float64) (string, bool) {
+	switch {
+	case math.IsNaN(f):
+		return "nan", true
+	case math.IsInf(f, +1):
+		return "inf", true
+	case math.IsInf(f, -1):
+		return "-inf", true
+	}
+	return "", false
+}
+
+// cOrder returns a copy of the slice of Fortran-ordered elements src,
+// re-ordered in C-order.
+func cOrder(src reflect.Value, shape []int) reflect.Value {
+	n := 1
+	for _, v := range shape {
+		n *= v
+	}
+	if src.Len() != n {
+		return src
+	}
+
+	var (
+		dst = reflect.MakeSlice(src.Type(), n, n)
+		idx = make([]int, len(shape))
+	)
+	for i := 0; i < n; i++ {
+		// index of the element in Fortran-order.
+		j := 0
+		for k := len(shape) - 1; k >= 0; k-- {
+			j = j*shape[k] + idx[k]
+		}
+		dst.Index(i).Set(src.Index(j))
+
+		// next index in C-order.
+		for k := len(shape) - 1; k >= 0; k-- {
+			idx[k]++
+			if idx[k] < shape[k] {
+				break
+			}
+			idx[k] = 0
+		}
+	}
+	return dst
+}
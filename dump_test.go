@@ -5,10 +5,14 @@
 package npyio
 
 import (
+	"bytes"
 	"io"
+	"math"
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/sbinet/npyio/float16"
 )
 
 func TestDump(t *testing.T) {
@@ -28,6 +32,10 @@ func TestDump(t *testing.T) {
 			name: "testdata/data_float64_2x3x4_corder.npy",
 			want: "testdata/data_float64_2x3x4_corder.npy.txt",
 		},
+		{
+			name: "testdata/data_float32_nan_inf.npy",
+			want: "testdata/data_float32_nan_inf.npy.txt",
+		},
 		{
 			name: "testdata/data_float64_corder.npz",
 			want: "testdata/data_float64_corder.npz.txt",
@@ -82,6 +90,10 @@ func TestDumpSeeker(t *testing.T) {
 			name: "testdata/data_float64_2x3x4_corder.npy",
 			want: "testdata/data_float64_2x3x4_corder.npy.txt",
 		},
+		{
+			name: "testdata/data_float32_nan_inf.npy",
+			want: "testdata/data_float32_nan_inf.npy.txt",
+		},
 		{
 			name: "testdata/data_float64_corder.npz",
 			want: "testdata/data_float64_corder.npz.txt",
@@ -129,3 +141,199 @@ func TestDumpSeeker(t *testing.T) {
 		})
 	}
 }
+
+func TestDumpLogicalOrder(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{
+			name: "testdata/data_float32_2x3_corder.npy",
+			want: "testdata/data_float32_2x3_corder.npy.txt",
+		},
+		{
+			name: "testdata/data_float32_2x3_forder.npy",
+			want: "testdata/data_float32_2x3_forder.npy.logical.txt",
+		},
+		{
+			name: "testdata/data_float64_forder.npz",
+			want: "testdata/data_float64_forder.npz.logical.txt",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.Open(tc.name)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.name, err)
+			}
+			defer f.Close()
+
+			o := new(strings.Builder)
+			err = DumpOpts{LogicalOrder: true}.Dump(o, f)
+			if err != nil {
+				t.Fatalf("could not dump %q: %+v", tc.name, err)
+			}
+
+			want, err := os.ReadFile(tc.want)
+			if err != nil {
+				t.Fatalf("could not read reference file %q: %+v", tc.want, err)
+			}
+
+			if got, want := o.String(), string(want); got != want {
+				t.Fatalf(
+					"invalid dump:\ngot:\n%s\nwant:\n%s\n",
+					got, want,
+				)
+			}
+		})
+	}
+}
+
+func TestDumpFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		opts DumpOpts
+		want string
+	}{
+		{
+			name: "default",
+			val:  []float64{0.5, 1, 2.25},
+			want: "[0.5 1 2.25]",
+		},
+		{
+			name: "precision",
+			val:  []float64{0.5, 1, 2.25},
+			opts: DumpOpts{Precision: 3},
+			want: "[0.500 1.000 2.250]",
+		},
+		{
+			name: "precision-non-finite",
+			val:  []float32{float32(math.NaN()), float32(math.Inf(-1)), 0.5},
+			opts: DumpOpts{Precision: 2},
+			want: "[nan -inf 0.50]",
+		},
+		{
+			name: "non-finite-float16",
+			val:  []float16.Num{float16.New(math.Inf(+1)), float16.New(math.NaN()), float16.New(-1.5)},
+			want: "[inf nan -1.5]",
+		},
+		{
+			name: "non-finite-complex",
+			val:  []complex128{complex(math.Inf(+1), math.NaN())},
+			want: "[(+Inf+NaNi)]",
+		},
+		{
+			name: "precision-complex",
+			val:  []complex64{1 - 0.5i},
+			opts: DumpOpts{Precision: 2},
+			want: "[(1.00-0.50i)]",
+		},
+		{
+			name: "precision-ints",
+			val:  []int32{1, 2},
+			opts: DumpOpts{Precision: 2},
+			want: "[1 2]",
+		},
+		{
+			name: "hex",
+			val:  []int16{-255, 0, 255},
+			opts: DumpOpts{Hex: true},
+			want: "[-0xff 0x0 0xff]",
+		},
+		{
+			name: "hex-unsigned",
+			val:  []uint64{1 << 63},
+			opts: DumpOpts{Hex: true},
+			want: "[0x8000000000000000]",
+		},
+		{
+			name: "max-elems",
+			val:  []int8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			opts: DumpOpts{MaxElems: 5},
+			want: "[0 1 2 ... 8 9]",
+		},
+		{
+			name: "max-elems-one",
+			val:  []int8{0, 1, 2},
+			opts: DumpOpts{MaxElems: 1},
+			want: "[0 ...]",
+		},
+		{
+			name: "max-elems-fit",
+			val:  []int8{0, 1, 2},
+			opts: DumpOpts{MaxElems: 3, Hex: true},
+			want: "[0x0 0x1 0x2]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			o := new(strings.Builder)
+			err = tc.opts.Dump(o, bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("could not dump data: %+v", err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(o.String()), "\n")
+			if got, want := lines[len(lines)-1], "data = "+tc.want; got != want {
+				t.Fatalf("invalid dump: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestDumpZip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{
+			name: "testdata/data_float64_corder.npz",
+			want: "testdata/data_float64_corder.npz.txt",
+		},
+		{
+			name: "testdata/data_float64_forder.npz",
+			want: "testdata/data_float64_forder.npz.txt",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.Open(tc.name)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.name, err)
+			}
+			defer f.Close()
+
+			fi, err := f.Stat()
+			if err != nil {
+				t.Fatalf("could not stat %q: %+v", tc.name, err)
+			}
+
+			o := new(strings.Builder)
+			err = DumpZip(o, f, fi.Size())
+			if err != nil {
+				t.Fatalf("could not dump %q: %+v", tc.name, err)
+			}
+
+			want, err := os.ReadFile(tc.want)
+			if err != nil {
+				t.Fatalf("could not read reference file %q: %+v", tc.want, err)
+			}
+
+			if got, want := o.String(), string(want); got != want {
+				t.Fatalf(
+					"invalid dump:\ngot:\n%s\nwant:\n%s\n",
+					got, want,
+				)
+			}
+		})
+	}
+
+	err := DumpZip(io.Discard, strings.NewReader("not a zip file"), 14)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package float16 implements IEEE 754 half-precision floating-point
+// numbers, as stored in NumPy arrays with the 'f2' data type.
+package float16 // import "github.com/sbinet/npyio/float16"
+
+import (
+	"math"
+	"strconv"
+)
+
+// Num is an IEEE 754 half-precision floating-point number, stored as its
+// bit pattern.
+type Num uint16
+
+// MaxValue is the largest finite Num value.
+const MaxValue = 65504
+
+// New returns the Num value nearest to f, rounding ties to even.
+// Values too large for a Num become infinities, values too small become
+// zeros, and NaNs stay NaNs.
+func New(f float64) Num {
+	var (
+		bits = math.Float64bits(f)
+		sign = uint16(bits>>48) & 0x8000
+		exp  = int(bits>>52) & 0x7ff
+		mant = bits & (1<<52 - 1)
+	)
+
+	if exp == 0x7ff {
+		if mant == 0 {
+			return Num(sign | 0x7c00)
+		}
+		// keep the most significant bits of the payload, and make sure
+		// the result is still a NaN.
+		m := uint16(mant >> 42)
+		if m == 0 {
+			m = 0x200
+		}
+		return Num(sign | 0x7c00 | m)
+	}
+
+	e := exp - 1023 + 15
+	switch {
+	case e >= 0x1f:
+		return Num(sign | 0x7c00)
+
+	case e <= 0:
+		// subnormal half-precision value, or zero.
+		shift := uint(43 - e)
+		if shift > 54 {
+			return Num(sign)
+		}
+		v := mant | 1<<52
+		return Num(sign | uint16(roundShift(v, shift)))
+	}
+
+	// rounding may carry into the exponent, up to infinity.
+	v := uint64(e)<<52 | mant
+	return Num(sign | uint16(roundShift(v, 42)))
+}
+
+// roundShift returns v shifted right by n bits, rounded to the nearest
+// value, ties to even.
+func roundShift(v uint64, n uint) uint64 {
+	var (
+		q    = v >> n
+		rem  = v & (1<<n - 1)
+		half = uint64(1) << (n - 1)
+	)
+	if rem > half || (rem == half && q&1 == 1) {
+		q++
+	}
+	return q
+}
+
+// Float32 returns the value of h as a float32.
+// The conversion is exact.
+func (h Num) Float32() float32 {
+	var (
+		sign = uint32(h>>15) << 31
+		exp  = uint32(h>>10) & 0x1f
+		mant = uint32(h) & 0x3ff
+	)
+	switch exp {
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// subnormal: normalize the mantissa.
+		e := uint32(127 - 15 + 1)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		mant &= 0x3ff
+		return math.Float32frombits(sign | e<<23 | mant<<13)
+	case 0x1f:
+		return math.Float32frombits(sign | 0xff<<23 | mant<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// Float64 returns the value of h as a float64.
+// The conversion is exact.
+func (h Num) Float64() float64 {
+	return float64(h.Float32())
+}
+
+// IsNaN reports whether h is a NaN.
+func (h Num) IsNaN() bool {
+	return h&0x7c00 == 0x7c00 && h&0x3ff != 0
+}
+
+func (h Num) String() string {
+	return strconv.FormatFloat(float64(h.Float32()), 'g', -1, 32)
+}
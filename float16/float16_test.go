@@ -0,0 +1,102 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package float16
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		f    float64
+		want Num
+	}{
+		{"zero", 0, 0x0000},
+		{"neg-zero", math.Copysign(0, -1), 0x8000},
+		{"one", 1, 0x3c00},
+		{"neg-two", -2, 0xc000},
+		{"third", 1.0 / 3, 0x3555},
+		{"max", 65504, 0x7bff},
+		{"round-to-max", 65519, 0x7bff},
+		{"overflow", 65520, 0x7c00},
+		{"large", 1e10, 0x7c00},
+		{"inf", math.Inf(+1), 0x7c00},
+		{"neg-inf", math.Inf(-1), 0xfc00},
+		{"min-normal", 0x1p-14, 0x0400},
+		{"max-subnormal", 0x3ffp-24, 0x03ff},
+		{"min-subnormal", 0x1p-24, 0x0001},
+		{"round-to-normal", 0x3ff8p-28, 0x0400},
+		{"half-min-subnormal", 0x1p-25, 0x0000},
+		{"above-half-min-subnormal", 0x3p-26, 0x0001},
+		{"neg-underflow", -1e-10, 0x8000},
+		{"tie-to-even-down", 1 + 0x1p-11, 0x3c00},
+		{"tie-to-even-up", 1 + 0x3p-11, 0x3c02},
+		{"subnormal-tie-to-even", 0x3p-25, 0x0002},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := New(tc.f); got != tc.want {
+				t.Fatalf("invalid value: got=0x%04x, want=0x%04x", uint16(got), uint16(tc.want))
+			}
+		})
+	}
+}
+
+func TestNewNaN(t *testing.T) {
+	for _, f := range []float64{
+		math.NaN(),
+		-math.NaN(),
+		math.Float64frombits(0x7ff0000000000001), // payload lost by truncation
+	} {
+		if h := New(f); !h.IsNaN() {
+			t.Fatalf("New(%v) = 0x%04x is not a NaN", f, uint16(h))
+		}
+	}
+}
+
+func TestFloat32(t *testing.T) {
+	for _, tc := range []struct {
+		h    Num
+		want float32
+	}{
+		{0x0000, 0},
+		{0x3c00, 1},
+		{0xc000, -2},
+		{0x7bff, 65504},
+		{0x0400, 0x1p-14},
+		{0x03ff, 0x3ffp-24},
+		{0x0001, 0x1p-24},
+		{0x8001, -0x1p-24},
+		{0x7c00, float32(math.Inf(+1))},
+		{0xfc00, float32(math.Inf(-1))},
+	} {
+		if got := tc.h.Float32(); got != tc.want {
+			t.Fatalf("invalid value for 0x%04x: got=%v, want=%v", uint16(tc.h), got, tc.want)
+		}
+	}
+
+	if got := Num(0x8000).Float32(); got != 0 || !math.Signbit(float64(got)) {
+		t.Fatalf("invalid negative zero: got=%v", got)
+	}
+	if got := Num(0x7e00).Float32(); !math.IsNaN(float64(got)) {
+		t.Fatalf("invalid NaN: got=%v", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for i := 0; i <= math.MaxUint16; i++ {
+		h := Num(i)
+		got := New(h.Float64())
+		switch {
+		case h.IsNaN():
+			if !got.IsNaN() {
+				t.Fatalf("NaN 0x%04x round-tripped to 0x%04x", i, uint16(got))
+			}
+		case got != h:
+			t.Fatalf("0x%04x round-tripped to 0x%04x", i, uint16(got))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npyio
+
+import (
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/sbinet/npyio/npy"
+)
+
+// Format is the file format of NumPy data.
+type Format int
+
+const (
+	FormatUnknown Format = iota // not NumPy data
+	FormatNPY                   // NumPy data file (.npy)
+	FormatNPZ                   // compressed NumPy data file (.npz)
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatUnknown:
+		return "unknown"
+	case FormatNPY:
+		return "npy"
+	case FormatNPZ:
+		return "npz"
+	}
+	return "Format(" + strconv.Itoa(int(f)) + ")"
+}
+
+var (
+	// zipMagic is the signature of the local file headers of ZIP
+	// archives, starting non-empty archives.
+	zipMagic = [4]byte{'P', 'K', 3, 4}
+	// zipEmpty is the signature of the end of central directory record
+	// of ZIP archives, starting empty archives.
+	zipEmpty = [4]byte{'P', 'K', 5, 6}
+
+	peekBufs = sync.Pool{
+		New: func() interface{} { return new([len(npy.Magic)]byte) },
+	}
+)
+
+// Peek returns the format of the data held in r, sniffed from its first
+// bytes: the "\x93NUMPY" magic string of NumPy data files, or the signature
+// of ZIP archives for compressed NumPy data files.
+// Peek reads at most 6 bytes from r, and neither checks the rest of the
+// header nor reads the directory of ZIP archives.
+//
+// Data shorter than the signatures is reported as FormatUnknown.
+func Peek(r io.ReaderAt) (Format, error) {
+	buf := peekBufs.Get().(*[len(npy.Magic)]byte)
+	defer peekBufs.Put(buf)
+
+	n, err := r.ReadAt(buf[:], 0)
+	if err != nil && err != io.EOF {
+		return FormatUnknown, err
+	}
+
+	switch {
+	case n == len(buf) && *buf == npy.Magic:
+		return FormatNPY, nil
+	case n >= len(zipMagic) && [4]byte(buf[:4]) == zipMagic,
+		n >= len(zipEmpty) && [4]byte(buf[:4]) == zipEmpty:
+		return FormatNPZ, nil
+	}
+	return FormatUnknown, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npyio
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPeek(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want Format
+	}{
+		{"testdata/data_float64_2x3_corder.npy", FormatNPY},
+		{"testdata/data_float64_corder.npz", FormatNPZ},
+		{"testdata/data_float32_2x3_corder.npy.txt", FormatUnknown},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.Open(tc.name)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.name, err)
+			}
+			defer f.Close()
+
+			got, err := Peek(f)
+			if err != nil {
+				t.Fatalf("could not peek: %+v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("invalid format: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		data string
+		want Format
+	}{
+		{"", FormatUnknown},
+		{"\x93NUM", FormatUnknown},
+		{"\x93NUMPY", FormatNPY},
+		{"PK\x03\x04", FormatNPZ},
+		{"PK\x05\x06" + strings.Repeat("\x00", 18), FormatNPZ},
+		{"PK\x01\x02", FormatUnknown},
+	} {
+		t.Run(tc.data, func(t *testing.T) {
+			got, err := Peek(strings.NewReader(tc.data))
+			if err != nil {
+				t.Fatalf("could not peek: %+v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("invalid format: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+
+	_, err := Peek(errReaderAt{})
+	if !errors.Is(err, errPeek) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, errPeek)
+	}
+
+	f, err := os.Open("testdata/data_float64_corder.npz")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+	if n := testing.AllocsPerRun(100, func() { _, _ = Peek(f) }); n != 0 {
+		t.Fatalf("invalid number of allocations: got=%v, want=0", n)
+	}
+}
+
+var errPeek = errors.New("peek error")
+
+type errReaderAt struct{}
+
+func (errReaderAt) ReadAt(p []byte, off int64) (int, error) { return 0, errPeek }
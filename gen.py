@@ -57,3 +57,170 @@ for order in ["f", "c"]:
         np.savez(f, arr0=arr0, arr1=arr1)
         pass
     pass
+
+for dt in ["complex64", "complex128"]:
+    with open("testdata/data_%s_bigendian.npy" % (dt,), "w") as f:
+        print(">>> %s" % f.name)
+        arr = np.array([0, 1+2j, -3.5-4.25j, 1024+0.5j], dtype=np.dtype(dt).newbyteorder(">"))
+        np.save(f, arr)
+        pass
+
+with open("testdata/data_records.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array(
+        [(1.5, 2, b"abc", u"αβ"), (-3.25, -4, b"hello!!!", u"xyz"), (0, 42, b"", u"")],
+        dtype=[("x", "<f8"), ("y", "<i4"), ("name", "S8"), ("label", "<U3")],
+    )
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_unicode_U8.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([u"hello", u"wörld", u"日本語テキスト", u"", u"exactly8"], dtype="<U8")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_float64_narrowing.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([0.1, 1/3., -2.5, 16777217.0, 1e-46, 3.4028235677973366e38, 1e39, -1e39], dtype="<f8")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_float16.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([0, -0., 1, -2, 65504, np.inf, -np.inf, np.nan, 2**-24, 2**-14, 1/3.], dtype="<f2")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_float64_2x3_bigendian.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.arange(6, dtype=">f8").reshape(2, 3)
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_int32_2x3_bigendian.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([[0, 1, -2], [3, -4, 1<<30]], dtype=">i4")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_datetime64_ns.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array(["1970-01-01T00:00:00", "2021-03-04T05:06:07.123456789", "NaT", "1900-01-01"], dtype="<M8[ns]")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_datetime64_D.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array(["1970-01-01", "2000-02-29", "1969-12-31", "NaT"], dtype="<M8[D]")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_timedelta64_ms.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([0, 1500, -250, "NaT"], dtype="<m8[ms]")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_bytes_S5.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([b"abc", b"", b"a\x00b", b"hello", b"\x00\x00x"], dtype="|S5")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_float64_2x3x4_forder.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.asfortranarray(np.arange(24, dtype="<f8").reshape(2, 3, 4))
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_complex128_split.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([1+2j, -3.5, -1j], dtype="<c16")
+    np.save(f, arr.view([("real", "<f8"), ("imag", "<f8")]).reshape(3))
+    pass
+
+with open("testdata/data_complex64_split_bigendian.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([1+2j, -3.5, -1j], dtype=">c8")
+    np.save(f, arr.view([("real", ">f4"), ("imag", ">f4")]).reshape(3))
+    pass
+
+for dt in ["c8", "c16"]:
+    with open("testdata/data_complex%d_scalar_corder.npy" % (int(dt[1:]) * 8), "w") as f:
+        print(">>> %s" % f.name)
+        arr = np.array(3.5-1.25j, dtype="<" + dt)
+        np.save(f, arr)
+        pass
+
+with open("testdata/data_records_aligned.npy", "w") as f:
+    print(">>> %s" % f.name)
+    dt = np.dtype([("a", "u1"), ("b", "<f8"), ("c", "<i2"), ("d", "<c8")], align=True)
+    # fill the padding bytes, so that readers must skip them.
+    arr = np.frombuffer(b"\xaa" * (3 * dt.itemsize), dtype=dt).copy()
+    arr[:] = [(1, 1.5, -2, 3+4j), (255, -0.25, 1024, -1-0.5j), (0, 0, 0, 0)]
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_float32_nan_inf.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([[np.nan, np.inf, -np.inf], [1.5, 0, -2]], dtype="<f4")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_float64_2x3_truncated.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.arange(6, dtype="<f8").reshape(2, 3)
+    np.save(f, arr)
+    # drop the last 2 elements, as an interrupted write would.
+    f.truncate(f.tell() - 2 * arr.itemsize)
+    pass
+
+# reference files, byte-for-byte identical to the output of np.save.
+for name, arr in [
+    ("float64_2x3", np.arange(6, dtype="<f8").reshape(2, 3)),
+    ("int16_5", np.arange(5, dtype="<i2")),
+    ("float32_2x3_forder", np.asfortranarray(np.arange(6, dtype="<f4").reshape(2, 3))),
+    ("bool_scalar", np.array(True)),
+    ("uint8_empty", np.zeros(0, dtype="|u1")),
+    ("int64_1000", np.arange(1000, dtype="<i8")),
+    ("records", np.array([(1.5, -1), (2.5, 7)], dtype=[("x", "<f8"), ("y", "<i4")])),
+    ("unicode_U3", np.array([u"a", u"bcd"], dtype="<U3")),
+]:
+    with open("testdata/data_golden_%s.npy" % (name,), "w") as f:
+        print(">>> %s" % f.name)
+        np.save(f, arr)
+        pass
+
+with open("testdata/data_unicode_2x2_U3.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array([[u"a", u"bcé"], [u"😀x", u""]], dtype="<U3")
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_records_times.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array(
+        [(1.5, -2, b"alpha", "2021-03-04T05:06:07", 1500), (-0.25, 7, b"gamma123", "NaT", -250)],
+        dtype=[("x", "<f8"), ("y", "<i4"), ("name", "|S8"), ("t", "<M8[s]"), ("dt", "<m8[ms]")],
+    )
+    np.save(f, arr)
+    pass
+
+with open("testdata/data_records_nested.npy", "w") as f:
+    print(">>> %s" % f.name)
+    arr = np.array(
+        [
+            (1, (1.5, -2, 0.25), ((1, 2), (3, 4)), (True, 3.5), ((1, 2), (3, 4))),
+            (-7, (0, 0, 1), ((-1, 0), (0, -1)), (False, -0.5), ((-1, -2), (0.5, 0.25))),
+        ],
+        dtype=[
+            ("id", "<i4"),
+            ("pos", "<f4", (3,)),
+            ("grid", "<i2", (2, 2)),
+            ("meta", [("ok", "|b1"), ("t", "<f8")]),
+            ("pts", [("x", "<f4"), ("y", "<f4")], (2,)),
+        ],
+    )
+    np.save(f, arr)
+    pass
@@ -0,0 +1,194 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// Appender appends elements to the array of a NumPy data file, along its
+// first axis, without rewriting the file.
+type Appender struct {
+	Header Header
+
+	f    *os.File
+	dt   dType
+	hoff int64 // offset of the header dictionary in f
+	hlen int   // length of the padded header dictionary
+	data int64 // offset of the array data in f
+	size int64 // size of the array data, in bytes
+	row  int   // number of elements along the other axes
+}
+
+// OpenAppend reads the header of the NumPy data file f and returns an
+// Appender for its array.
+//
+// Only arrays with at least one dimension can be appended to; arrays with
+// more than one dimension must be in C-order. The file must not hold
+// anything past the array data.
+func OpenAppend(f *os.File) (*Appender, error) {
+	a, err := openAppend(f)
+	if err != nil {
+		return nil, wrapErr("header", err)
+	}
+	return a, nil
+}
+
+func openAppend(f *os.File) (*Appender, error) {
+	hdr, data, err := ReadHeaderAt(f, 0)
+	if err != nil {
+		return nil, err
+	}
+	shape := hdr.Descr.Shape
+	switch {
+	case len(shape) == 0:
+		return nil, fmt.Errorf("npy: can not append to a scalar array: %w", errDims)
+	case len(shape) > 1 && hdr.Descr.Fortran:
+		return nil, fmt.Errorf("npy: can not append to a Fortran-ordered array of shape %v: %w", shape, errDims)
+	}
+
+	dt, err := newDtype(hdr.Descr.Type)
+	if err != nil {
+		return nil, err
+	}
+	if dt.rt == nil {
+		return nil, fmt.Errorf("npy: can not append to array of dtype %q: %w", hdr.Descr.Type, ErrInvalidType)
+	}
+
+	size, err := dataSize(hdr)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if got, want := fi.Size()-data, size; got != want {
+		return nil, fmt.Errorf(
+			"npy: file holds %d bytes of array data, want %d: %w",
+			got, want, ErrInvalidNumPyFormat,
+		)
+	}
+
+	hoff := int64(len(Magic) + 2 + 2)
+	if hdr.Major > 1 {
+		hoff += 2
+	}
+	return &Appender{
+		Header: hdr,
+		f:      f,
+		dt:     dt,
+		hoff:   hoff,
+		hlen:   int(data - hoff),
+		data:   data,
+		size:   size,
+		row:    numElems(shape[1:]),
+	}, nil
+}
+
+// Append writes the elements of v after the array data and updates the
+// shape of the array in the header.
+//
+// v must be a scalar, a slice or an array of elements of the data type of
+// the array, and hold a whole number of entries along the first axis.
+// Nested slices and arrays must match the shape of the array along its
+// other axes.
+// The data is written before the header, so that the file stays valid if
+// Append is interrupted.
+//
+// The header is updated in place. Append fails if the padding of the
+// header is too short to hold the new shape: the file must then be
+// rewritten with Write.
+func (a *Appender) Append(v interface{}) error {
+	return wrapErr("write", a.append(v))
+}
+
+func (a *Appender) append(v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	descr, err := dtypeFrom(rv, rv.Type())
+	if err != nil {
+		return err
+	}
+	vdt, err := newDtype(descr)
+	if err != nil {
+		return err
+	}
+	if !a.accepts(vdt) {
+		return fmt.Errorf(
+			"npy: can not append %v to array of dtype %q: %w",
+			rv.Type(), a.Header.Descr.Type, ErrTypeMismatch,
+		)
+	}
+
+	vshape, err := shapeFrom(rv)
+	if err != nil {
+		return err
+	}
+	n := numElems(vshape)
+	if a.row == 0 || n%a.row != 0 {
+		return fmt.Errorf(
+			"npy: can not append %d elements to array of shape %v: %w",
+			n, a.Header.Descr.Shape, errDims,
+		)
+	}
+	if len(vshape) > 1 && !equalShapes(vshape[1:], a.Header.Descr.Shape[1:]) {
+		return fmt.Errorf(
+			"npy: can not append value of shape %v to array of shape %v: %w",
+			vshape, a.Header.Descr.Shape, errDims,
+		)
+	}
+
+	hdr := a.Header
+	hdr.Descr.Shape = append([]int(nil), hdr.Descr.Shape...)
+	hdr.Descr.Shape[0] += n / a.row
+	dict := headerDict(hdr)
+	if len(dict)+1 > a.hlen {
+		return fmt.Errorf(
+			"npy: header of %d bytes can not hold shape %v, the file must be rewritten",
+			a.hlen, hdr.Descr.Shape,
+		)
+	}
+
+	buf := new(bytes.Buffer)
+	err = writeData(buf, rv, a.dt)
+	if err != nil {
+		return err
+	}
+	_, err = a.f.WriteAt(buf.Bytes(), a.data+a.size)
+	if err != nil {
+		return err
+	}
+
+	raw := make([]byte, a.hlen)
+	copy(raw, dict)
+	for i := len(dict); i < len(raw)-1; i++ {
+		raw[i] = '\x20'
+	}
+	raw[len(raw)-1] = '\n'
+	_, err = a.f.WriteAt(raw, a.hoff)
+	if err != nil {
+		return err
+	}
+
+	a.Header = hdr
+	a.size += int64(buf.Len())
+	return nil
+}
+
+// accepts returns whether values of the data type vdt can be appended to
+// the array.
+func (a *Appender) accepts(vdt dType) bool {
+	switch {
+	case vdt.rt != a.dt.rt, vdt.unit != a.dt.unit:
+		return false
+	case vdt.rt == stringType:
+		// strings are padded to the item size of the array.
+		return vdt.utf == a.dt.utf && vdt.size <= a.dt.size
+	}
+	return vdt.size == a.dt.size
+}
@@ -0,0 +1,193 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAppender(t *testing.T) {
+	create := func(t *testing.T, v interface{}) *os.File {
+		t.Helper()
+		f, err := os.Create(filepath.Join(t.TempDir(), "data.npy"))
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		t.Cleanup(func() { f.Close() })
+		err = Write(f, v)
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+		return f
+	}
+
+	for _, tc := range []struct {
+		name  string
+		init  interface{}
+		vals  []interface{}
+		want  interface{}
+		shape []int
+	}{
+		{
+			name:  "float64",
+			init:  []float64{0, 1, 2},
+			vals:  []interface{}{[]float64{3, 4}, 5.0, [2]float64{6, 7}},
+			want:  []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			shape: []int{8},
+		},
+		{
+			name:  "empty",
+			init:  []int16{},
+			vals:  []interface{}{[]int16{1, 2}},
+			want:  []int16{1, 2},
+			shape: []int{2},
+		},
+		{
+			name:  "rows",
+			init:  [][]int32{{0, 1, 2}, {3, 4, 5}},
+			vals:  []interface{}{[]int32{6, 7, 8}, [][]int32{{9, 10, 11}, {12, 13, 14}}},
+			want:  []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14},
+			shape: []int{5, 3},
+		},
+		{
+			name:  "strings",
+			init:  []string{"hello", "world"},
+			vals:  []interface{}{"go"},
+			want:  []string{"hello", "world", "go"},
+			shape: []int{3},
+		},
+		{
+			name:  "bools",
+			init:  []bool{true},
+			vals:  []interface{}{[]bool{false, true}},
+			want:  []bool{true, false, true},
+			shape: []int{3},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := create(t, tc.init)
+			a, err := OpenAppend(f)
+			if err != nil {
+				t.Fatalf("could not open appender: %+v", err)
+			}
+			for _, v := range tc.vals {
+				err = a.Append(v)
+				if err != nil {
+					t.Fatalf("could not append %v: %+v", v, err)
+				}
+			}
+			if got, want := a.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid appender shape: got=%v, want=%v", got, want)
+			}
+
+			_, err = f.Seek(0, 0)
+			if err != nil {
+				t.Fatalf("could not rewind file: %+v", err)
+			}
+			got := reflect.New(reflect.TypeOf(tc.want))
+			hdr, err := ReadWithHeader(f, got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got.Elem().Interface(), tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got.Elem().Interface(), tc.want)
+			}
+			if got, want := hdr.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestAppenderInvalid(t *testing.T) {
+	open := func(t *testing.T, raw []byte) (*Appender, error) {
+		t.Helper()
+		fname := filepath.Join(t.TempDir(), "data.npy")
+		err := os.WriteFile(fname, raw, 0644)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		f, err := os.OpenFile(fname, os.O_RDWR, 0)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return OpenAppend(f)
+	}
+	encode := func(v interface{}, opts ...WriteOption) []byte {
+		buf := new(bytes.Buffer)
+		err := WriteWith(buf, v, opts...)
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+		return buf.Bytes()
+	}
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{"scalar", encode(42.0), errDims},
+		{"fortran", encode([][]float64{{1, 2}, {3, 4}}, WithFortranOrder(true)), errDims},
+		{"trailing", append(encode([]float64{1, 2}), 0), ErrInvalidNumPyFormat},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := open(t, tc.raw)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+
+	a, err := open(t, encode([][]float32{{1, 2}, {3, 4}}))
+	if err != nil {
+		t.Fatalf("could not open appender: %+v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		err  error
+	}{
+		{"type", []float64{5, 6}, ErrTypeMismatch},
+		{"row", []float32{5, 6, 7}, errDims},
+		{"shape", [][4]float32{{5, 6, 7, 8}}, errDims},
+		{"transposed", [][]float32{{5}, {6}}, errDims},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := a.Append(tc.val)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+
+	// a header without room to spare for a longer shape.
+	dict := "{'descr': '<f8', 'fortran_order': False, 'shape': (9,), }\n"
+	raw := new(bytes.Buffer)
+	raw.Write(Magic[:])
+	raw.Write([]byte{1, 0})
+	binary.Write(raw, binary.LittleEndian, uint16(len(dict)))
+	raw.WriteString(dict)
+	raw.Write(make([]byte, 9*8))
+
+	a, err = open(t, raw.Bytes())
+	if err != nil {
+		t.Fatalf("could not open appender: %+v", err)
+	}
+	err = a.Append(1.0)
+	if err == nil {
+		t.Fatalf("expected an error for a full header")
+	}
+	if got, want := a.Header.Descr.Shape, []int{9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"encoding/binary"
+	"io"
+	"reflect"
+
+	"github.com/sbinet/npyio/bfloat16"
+)
+
+var bfloat16Type = reflect.TypeOf(bfloat16.Num(0))
+
+// bfloat16Descr is the data type descriptor of the arrays of bfloat16
+// values written by NumPy for the ml_dtypes package.
+const bfloat16Descr = "<V2"
+
+// WithBFloat16 configures a Reader to decode arrays of the 2-byte void data
+// type ('<V2', '>V2' or '|V2'), as written by np.save for the bfloat16 type
+// of the ml_dtypes package used by JAX and TensorFlow, as bfloat16 values.
+// '|V2' arrays are assumed to be little-endian.
+//
+// Such arrays can then be read into bfloat16.Num values, or converted
+// exactly into float32 and float64 values, as scalars or slices.
+// The default is to reject them, as NumPy itself only loads them as opaque
+// bytes.
+//
+// Values of type bfloat16.Num are always written as '<V2' arrays.
+func WithBFloat16() ReadOption {
+	return func(r *Reader) {
+		r.bf16 = true
+	}
+}
+
+// bfloat16Order returns the byte order of the bfloat16 values of the data
+// type descr, and whether descr describes bfloat16 values.
+func bfloat16Order(descr string) (binary.ByteOrder, bool) {
+	switch descr {
+	case "<V2", "|V2":
+		return binary.LittleEndian, true
+	case ">V2":
+		return binary.BigEndian, true
+	}
+	return nil, false
+}
+
+// readBFloat16 reads the array data of bfloat16 values with the byte order
+// order into ptr.
+func (r *Reader) readBFloat16(ptr interface{}, order binary.ByteOrder) error {
+	var (
+		nelems = numElems(r.Header.Descr.Shape)
+		dec    = func(b []byte) bfloat16.Num { return bfloat16.Num(order.Uint16(b)) }
+	)
+	scalar := func() (bfloat16.Num, error) {
+		var buf [2]byte
+		_, err := r.read(buf[:])
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		return dec(buf[:]), r.err
+	}
+
+	if rv := reflect.ValueOf(ptr).Elem(); rv.Kind() == reflect.Slice && rv.Len() == 0 {
+		err := r.checkMem(int(rv.Type().Elem().Size()))
+		if err != nil {
+			return err
+		}
+	}
+
+	switch vptr := ptr.(type) {
+	case *bfloat16.Num:
+		v, err := scalar()
+		*vptr = v
+		return err
+
+	case *float32:
+		v, err := scalar()
+		*vptr = v.Float32()
+		return err
+
+	case *float64:
+		v, err := scalar()
+		*vptr = v.Float64()
+		return err
+
+	case *[]bfloat16.Num:
+		*vptr = resizeSlice(*vptr, nelems)
+		return readBatch(r, *vptr, 2, dec)
+
+	case *[]float32:
+		*vptr = resizeSlice(*vptr, nelems)
+		return readBatch(r, *vptr, 2, func(b []byte) float32 {
+			return dec(b).Float32()
+		})
+
+	case *[]float64:
+		*vptr = resizeSlice(*vptr, nelems)
+		return readBatch(r, *vptr, 2, func(b []byte) float64 {
+			return dec(b).Float64()
+		})
+
+	case *interface{}:
+		if len(r.Header.Descr.Shape) == 0 {
+			v, err := scalar()
+			*vptr = v
+			return err
+		}
+		var vs []bfloat16.Num
+		err := r.readBFloat16(&vs, order)
+		*vptr = vs
+		return err
+	}
+
+	return ErrTypeMismatch
+}
+
+// writeBFloat16 writes rv, a bfloat16.Num value, or a slice or an array of
+// bfloat16.Num values, as a '<V2' array.
+func writeBFloat16(w io.Writer, rv reflect.Value) error {
+	hdr := newHeader()
+	hdr.Descr.Type = bfloat16Descr
+	vs := rv
+	if rv.Type() == bfloat16Type {
+		vs = reflect.Append(reflect.MakeSlice(reflect.SliceOf(bfloat16Type), 0, 1), rv)
+	} else {
+		hdr.Descr.Shape = []int{rv.Len()}
+	}
+
+	err := writeHeader(w, hdr, dType{})
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 2*vs.Len())
+	for i := 0; i < vs.Len(); i++ {
+		binary.LittleEndian.PutUint16(buf[2*i:], uint16(vs.Index(i).Uint()))
+	}
+	_, err = w.Write(buf)
+	return err
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/sbinet/npyio/bfloat16"
+)
+
+func TestBFloat16(t *testing.T) {
+	var (
+		vs   = []bfloat16.Num{0x0000, 0x3f80, 0xc000, 0x3eab, 0x7f80}
+		f32s = []float32{0, 1, -2, 0.333984375, float32(math.Inf(+1))}
+	)
+
+	buf := new(bytes.Buffer)
+	err := Write(buf, vs)
+	if err != nil {
+		t.Fatalf("could not write bfloat16 values: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	hdr, err := ReadHeader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not read header: %+v", err)
+	}
+	if got, want := hdr.Descr.Type, "<V2"; got != want {
+		t.Fatalf("invalid descr: got=%q, want=%q", got, want)
+	}
+
+	t.Run("bfloat16", func(t *testing.T) {
+		var got []bfloat16.Num
+		err := Read(bytes.NewReader(raw), &got, WithBFloat16())
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if !reflect.DeepEqual(got, vs) {
+			t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, vs)
+		}
+	})
+
+	t.Run("float32", func(t *testing.T) {
+		var got []float32
+		err := Read(bytes.NewReader(raw), &got, WithBFloat16())
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if !reflect.DeepEqual(got, f32s) {
+			t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, f32s)
+		}
+	})
+
+	t.Run("float64", func(t *testing.T) {
+		var got []float64
+		err := Read(bytes.NewReader(raw), &got, WithBFloat16())
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		for i := range got {
+			if got[i] != float64(f32s[i]) {
+				t.Fatalf("invalid element #%d: got=%v, want=%v", i, got[i], f32s[i])
+			}
+		}
+	})
+
+	t.Run("no-option", func(t *testing.T) {
+		var got []float32
+		err := Read(bytes.NewReader(raw), &got)
+		if err == nil {
+			t.Fatalf("expected an error reading bfloat16 data without WithBFloat16")
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		var got []int32
+		err := Read(bytes.NewReader(raw), &got, WithBFloat16())
+		if err == nil {
+			t.Fatalf("expected an error reading bfloat16 data into []int32")
+		}
+	})
+}
+
+func TestReadBFloat16Scalar(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		descr string
+		data  []byte
+	}{
+		{"little-endian", "<V2", []byte{0xc0, 0x3f}},
+		{"big-endian", ">V2", []byte{0x3f, 0xc0}},
+		{"no-order", "|V2", []byte{0xc0, 0x3f}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hdr := newHeader()
+			hdr.Descr.Type = tc.descr
+			buf := new(bytes.Buffer)
+			err := writeHeader(buf, hdr, dType{})
+			if err != nil {
+				t.Fatalf("could not write header: %+v", err)
+			}
+			buf.Write(tc.data)
+
+			var got float32
+			err = Read(bytes.NewReader(buf.Bytes()), &got, WithBFloat16())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if want := float32(1.5); got != want {
+				t.Fatalf("invalid value: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// WithKeepNULs configures a Reader to keep the trailing NUL bytes of the
+// elements of '|S' arrays read into string and [][]byte values, so that
+// each element holds exactly the item size of the array.
+// The default is to remove them, as NumPy does: embedded NUL bytes are
+// kept in both cases.
+func WithKeepNULs() ReadOption {
+	return func(r *Reader) {
+		r.keepNULs = true
+	}
+}
+
+// trimNULs returns the element of a '|S' array raw, without its trailing
+// NUL bytes unless the Reader is configured to keep them.
+func (r *Reader) trimNULs(raw []byte) []byte {
+	if r.keepNULs {
+		return raw
+	}
+	return bytes.TrimRight(raw, "\x00")
+}
+
+// readByteStrings reads the elements of a '|S' array into vptr, one byte
+// slice per element.
+func (r *Reader) readByteStrings(vptr *[][]byte, dt dType, nelems int) error {
+	*vptr = resizeSlice(*vptr, nelems)
+	buf := make([]byte, dt.size*len(*vptr))
+	_, err := r.read(buf)
+	if err != nil {
+		return r.err
+	}
+
+	for i := range *vptr {
+		// elements share buf: cap them so appending to one does not
+		// overwrite the next.
+		raw := buf[i*dt.size : (i+1)*dt.size : (i+1)*dt.size]
+		(*vptr)[i] = r.trimNULs(raw)
+	}
+	return r.err
+}
+
+// WithByteStrings configures WriteWith to write strings, and slices and
+// arrays of strings or of []byte values, as '|S' arrays of fixed-width
+// byte strings, e.g. for tools that do not support the '<U' Unicode data
+// type NumPy uses for Python strings.
+//
+// The elements are written as is, e.g. UTF-8 encoded for strings, and
+// padded with NUL bytes to the item size width. If width is 0, the item
+// size is the length of the longest element, and at least 1 as with
+// NumPy. WriteWith fails with ErrOutOfRange if an element is longer than
+// width bytes.
+func WithByteStrings(width int) WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.bytes = true
+		cfg.width = width
+	}
+}
+
+// isByteStrings returns whether rt is a string type, or a slice or an
+// array of strings or of byte slices, as written by WithByteStrings.
+func isByteStrings(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.String:
+		return true
+	case reflect.Slice, reflect.Array:
+		return rt.Elem().Kind() == reflect.String || rt.Elem() == bytesType
+	}
+	return false
+}
+
+// byteStrings returns the values of rv, for which isByteStrings holds, as
+// a string or a slice of strings, and the '|S' data type descriptor of
+// width bytes able to hold them.
+func byteStrings(rv reflect.Value, width int) (reflect.Value, string, error) {
+	if width < 0 {
+		return rv, "", fmt.Errorf("npy: invalid byte string width %d", width)
+	}
+
+	var vs []string
+	switch {
+	case rv.Kind() == reflect.String:
+		vs = []string{rv.String()}
+	case rv.Type().Elem() == bytesType:
+		vs = make([]string, rv.Len())
+		for i := range vs {
+			vs[i] = string(rv.Index(i).Bytes())
+		}
+	default:
+		vs = make([]string, rv.Len())
+		for i := range vs {
+			vs[i] = rv.Index(i).String()
+		}
+	}
+
+	n := max(width, 1)
+	for i, v := range vs {
+		switch {
+		case width == 0:
+			n = max(n, len(v))
+		case len(v) > width:
+			return rv, "", fmt.Errorf(
+				"npy: element #%d of %d bytes does not fit in byte strings of %d bytes: %w",
+				i, len(v), width, ErrOutOfRange,
+			)
+		}
+	}
+
+	descr := fmt.Sprintf("|S%d", n)
+	if rv.Kind() == reflect.String {
+		return reflect.ValueOf(vs[0]), descr, nil
+	}
+	return reflect.ValueOf(vs), descr, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadByteStrings(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/data_bytes_S5.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []ReadOption
+		want []string
+	}{
+		{
+			name: "trim",
+			want: []string{"abc", "", "a\x00b", "hello", "\x00\x00x"},
+		},
+		{
+			name: "keep-nuls",
+			opts: []ReadOption{WithKeepNULs()},
+			want: []string{"abc\x00\x00", "\x00\x00\x00\x00\x00", "a\x00b\x00\x00", "hello", "\x00\x00x\x00\x00"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var strs []string
+			err := Read(bytes.NewReader(raw), &strs, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not read strings: %+v", err)
+			}
+			if !reflect.DeepEqual(strs, tc.want) {
+				t.Fatalf("invalid strings:\ngot= %q\nwant=%q", strs, tc.want)
+			}
+
+			var bs [][]byte
+			err = Read(bytes.NewReader(raw), &bs, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not read byte slices: %+v", err)
+			}
+			if len(bs) != len(tc.want) {
+				t.Fatalf("invalid number of byte slices: got=%d, want=%d", len(bs), len(tc.want))
+			}
+			for i := range bs {
+				if got, want := string(bs[i]), tc.want[i]; got != want {
+					t.Fatalf("invalid byte slice #%d: got=%q, want=%q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadByteStringsChunks(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/data_bytes_S5.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	r, err := NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+
+	want := []string{"abc", "", "a\x00b", "hello", "\x00\x00x"}
+	chunk := make([][]byte, 2)
+	for i := 0; i < 2; i++ {
+		err := r.Read(&chunk)
+		if err != nil {
+			t.Fatalf("could not read chunk #%d: %+v", i, err)
+		}
+		if len(chunk) != 2 {
+			t.Fatalf("invalid chunk #%d length: got=%d, want=%d", i, len(chunk), 2)
+		}
+		for j := range chunk {
+			if got, want := string(chunk[j]), want[2*i+j]; got != want {
+				t.Fatalf("invalid element #%d of chunk #%d: got=%q, want=%q", j, i, got, want)
+			}
+		}
+	}
+
+	last := make([][]byte, 1)
+	err = r.Read(&last)
+	if err != nil {
+		t.Fatalf("could not read last chunk: %+v", err)
+	}
+	if got, want := string(last[0]), want[4]; got != want {
+		t.Fatalf("invalid last element: got=%q, want=%q", got, want)
+	}
+}
+
+func TestWriteByteStrings(t *testing.T) {
+	want, err := os.ReadFile("../testdata/data_bytes_S5.npy")
+	if err != nil {
+		t.Fatalf("could not read reference file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+	}{
+		{"strings", []string{"abc", "", "a\x00b", "hello", "\x00\x00x"}},
+		{"bytes", [][]byte{[]byte("abc"), nil, []byte("a\x00b"), []byte("hello"), []byte("\x00\x00x")}},
+		{"array", [5]string{"abc", "", "a\x00b", "hello", "\x00\x00x"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, tc.val, WithByteStrings(0))
+			if err != nil {
+				t.Fatalf("could not write value: %+v", err)
+			}
+			if got := buf.Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("invalid file content:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		width int
+		descr string
+		want  []string
+		err   error
+	}{
+		{"scalar", "héllo", 0, "|S6", []string{"héllo"}, nil},
+		{"empty", []string{""}, 0, "|S1", []string{""}, nil},
+		{"width", []string{"a", "bc"}, 8, "|S8", []string{"a", "bc"}, nil},
+		{"too-long", []string{"a", "bcd"}, 2, "", nil, ErrOutOfRange},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, tc.val, WithByteStrings(tc.width))
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not write value: %+v", err)
+			}
+
+			var got []string
+			hdr, err := ReadWithHeader(bytes.NewReader(buf.Bytes()), &got)
+			if err != nil {
+				t.Fatalf("could not read value: %+v", err)
+			}
+			if got, want := hdr.Descr.Type, tc.descr; got != want {
+				t.Fatalf("invalid descr: got=%q, want=%q", got, want)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid value:\ngot= %q\nwant=%q", got, tc.want)
+			}
+		})
+	}
+}
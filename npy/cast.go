@@ -0,0 +1,472 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+
+	"github.com/sbinet/npyio/float16"
+	"gonum.org/v1/gonum/mat"
+)
+
+// CastMode describes how WriteAs handles values that can not be
+// represented in the requested data type.
+type CastMode int
+
+const (
+	// CastStrict makes WriteAs fail with ErrOutOfRange on the first
+	// value outside of the range of the requested data type, or on the
+	// first floating-point value with a fractional part converted to an
+	// integer data type.
+	// NaNs can not be written as integers.
+	CastStrict CastMode = iota
+
+	// CastClamp saturates values outside of the range of the requested
+	// data type to its minimum or maximum value, like NumPy's
+	// clip followed by astype.
+	// Floating-point values are truncated toward zero when converted to
+	// integers.
+	// NaNs are written as 0 when converted to integers.
+	CastClamp
+
+	// CastWrap silently wraps integer values around, keeping their
+	// lowest bits, like Go conversions do.
+	// Floating-point values are truncated toward zero and wrapped modulo
+	// 2^N when converted to N-bits integers; NaNs and infinities are
+	// written as 0.
+	// Floating-point values too large for float32 become infinities.
+	CastWrap
+)
+
+func (m CastMode) String() string {
+	switch m {
+	case CastStrict:
+		return "strict"
+	case CastClamp:
+		return "clamp"
+	case CastWrap:
+		return "wrap"
+	}
+	return fmt.Sprintf("CastMode(%d)", int(m))
+}
+
+// WithCast configures how WriteAs handles values that can not be
+// represented in the requested data type.
+// The default is CastStrict.
+func WithCast(mode CastMode) WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.cast = mode
+	}
+}
+
+// WriteAs writes 'val' into 'w' in the NumPy data format, converting its
+// elements to the provided NumPy data type (e.g. '<i4', '|u1', '<f4').
+//
+// val must be a scalar, a possibly nested slice or array of booleans or
+// numbers, or a mat.Dense. Nested slices must not be ragged.
+// Integers can be converted to any integer or floating-point data type,
+// and floating-point values to any floating-point data type. Both can be
+// converted to complex data types.
+// Floating-point values can be converted to integer data types only if
+// they hold integral values, unless the CastClamp or CastWrap modes are
+// used, in which case they are truncated toward zero.
+// Complex values can only be converted to complex data types, and booleans
+// to booleans.
+//
+// Out-of-range values are handled according to the WithCast option.
+func WriteAs(w io.Writer, val interface{}, dtype string, opts ...WriteOption) error {
+	return wrapErr("write", writeAs(w, val, dtype, opts...))
+}
+
+func writeAs(w io.Writer, val interface{}, dtype string, opts ...WriteOption) error {
+	cfg := newWriteConfig(opts)
+
+	dt, err := newDtype(dtype)
+	if err != nil {
+		return err
+	}
+	if dt.rt == nil || dt.rt == stringType {
+		return fmt.Errorf("npy: can not write values as dtype %q: %w", dtype, ErrInvalidType)
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(denseFrom(val)))
+	src, shape, err := castSource(rv)
+	if err != nil {
+		return err
+	}
+
+	dst := reflect.MakeSlice(reflect.SliceOf(dt.rt), src.Len(), src.Len())
+	for i := 0; i < src.Len(); i++ {
+		err = castValue(dst.Index(i), src.Index(i), cfg.cast)
+		if err != nil {
+			return fmt.Errorf("npy: could not convert element #%d to dtype %q: %w", i, dtype, err)
+		}
+	}
+
+	hdr := newHeader()
+	hdr.Descr.Type = dtype
+	hdr.Descr.Shape = shape
+
+	err = writeHeader(w, hdr, dt)
+	if err != nil {
+		return err
+	}
+
+	return writeData(w, dst, dt)
+}
+
+// castSource returns the elements of rv as a flat C-ordered list, together
+// with the shape of rv.
+func castSource(rv reflect.Value) (reflect.Value, []int, error) {
+	if rv.Type() == rtDense {
+		m := rv.Interface().(mat.Dense)
+		var (
+			nrows, ncols = m.Dims()
+			data         = make([]float64, 0, nrows*ncols)
+		)
+		for i := 0; i < nrows; i++ {
+			data = append(data, mat.Row(nil, i, &m)...)
+		}
+		return reflect.ValueOf(data), []int{nrows, ncols}, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		et := rv.Type().Elem()
+		for et.Kind() == reflect.Slice || et.Kind() == reflect.Array {
+			et = et.Elem()
+		}
+		if !isCastable(et.Kind()) {
+			return reflect.Value{}, nil, fmt.Errorf("npy: type %v not supported: %w", rv.Type(), ErrInvalidType)
+		}
+		if et == rv.Type().Elem() {
+			return rv, []int{rv.Len()}, nil
+		}
+
+		// nested slices and arrays are flattened in C-order.
+		shape, err := shapeFrom(rv)
+		if err != nil {
+			return reflect.Value{}, nil, err
+		}
+		src := reflect.MakeSlice(reflect.SliceOf(et), 0, numElems(shape))
+		return flatten(src, rv), shape, nil
+	}
+
+	if !isCastable(rv.Kind()) {
+		return reflect.Value{}, nil, fmt.Errorf("npy: type %v not supported: %w", rv.Type(), ErrInvalidType)
+	}
+	src := reflect.MakeSlice(reflect.SliceOf(rv.Type()), 1, 1)
+	src.Index(0).Set(rv)
+	return src, nil, nil
+}
+
+// flatten appends the elements of rv, a possibly nested slice or array, to
+// dst, in C-order.
+func flatten(dst, rv reflect.Value) reflect.Value {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem() == dst.Type().Elem() {
+			if rv.Kind() == reflect.Array {
+				for i := 0; i < rv.Len(); i++ {
+					dst = reflect.Append(dst, rv.Index(i))
+				}
+				return dst
+			}
+			return reflect.AppendSlice(dst, rv)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			dst = flatten(dst, rv.Index(i))
+		}
+	}
+	return dst
+}
+
+func isCastable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// castValue converts src to the type of dst and stores it into dst.
+func castValue(dst, src reflect.Value, mode CastMode) error {
+	if dst.Type() == float16Type {
+		return castFloat16(dst, src, mode)
+	}
+
+	switch dst.Kind() {
+	case reflect.Bool:
+		if src.Kind() != reflect.Bool {
+			return fmt.Errorf("npy: can not convert %v to bool: %w", src.Type(), ErrTypeMismatch)
+		}
+		dst.SetBool(src.Bool())
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var (
+			bits = dst.Type().Bits()
+			hi   = int64(1)<<(bits-1) - 1
+			lo   = -hi - 1
+		)
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v := src.Int()
+			if v < lo || v > hi {
+				switch mode {
+				case CastStrict:
+					return errOutOfRange(src, dst)
+				case CastClamp:
+					v = clamp(v, lo, hi)
+				}
+			}
+			dst.SetInt(v)
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u := src.Uint()
+			if u > uint64(hi) {
+				switch mode {
+				case CastStrict:
+					return errOutOfRange(src, dst)
+				case CastClamp:
+					u = uint64(hi)
+				}
+			}
+			dst.SetInt(int64(u))
+
+		case reflect.Float32, reflect.Float64:
+			if mode == CastStrict && isFraction(src.Float()) {
+				return errInexact(src, dst)
+			}
+			var (
+				f    = math.Trunc(src.Float())
+				lim  = math.Ldexp(1, bits-1)
+				v, s = castFloat(f, -lim, lim, bits)
+			)
+			if !s {
+				switch mode {
+				case CastStrict:
+					return errOutOfRange(src, dst)
+				case CastClamp:
+					switch {
+					case math.IsNaN(f):
+						v = 0
+					case f < 0:
+						v = uint64(lo)
+					default:
+						v = uint64(hi)
+					}
+				}
+			}
+			dst.SetInt(int64(v))
+
+		default:
+			return fmt.Errorf("npy: can not convert %v to %v: %w", src.Type(), dst.Type(), ErrTypeMismatch)
+		}
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var (
+			bits = dst.Type().Bits()
+			hi   = uint64(1)<<bits - 1
+		)
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v := src.Int()
+			u := uint64(v)
+			if v < 0 || u > hi {
+				switch mode {
+				case CastStrict:
+					return errOutOfRange(src, dst)
+				case CastClamp:
+					if v < 0 {
+						u = 0
+					} else {
+						u = hi
+					}
+				}
+			}
+			dst.SetUint(u)
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u := src.Uint()
+			if u > hi {
+				switch mode {
+				case CastStrict:
+					return errOutOfRange(src, dst)
+				case CastClamp:
+					u = hi
+				}
+			}
+			dst.SetUint(u)
+
+		case reflect.Float32, reflect.Float64:
+			if mode == CastStrict && isFraction(src.Float()) {
+				return errInexact(src, dst)
+			}
+			var (
+				f    = math.Trunc(src.Float())
+				u, s = castFloat(f, 0, math.Ldexp(1, bits), bits)
+			)
+			if !s {
+				switch mode {
+				case CastStrict:
+					return errOutOfRange(src, dst)
+				case CastClamp:
+					switch {
+					case math.IsNaN(f), f < 0:
+						u = 0
+					default:
+						u = hi
+					}
+				}
+			}
+			dst.SetUint(u)
+
+		default:
+			return fmt.Errorf("npy: can not convert %v to %v: %w", src.Type(), dst.Type(), ErrTypeMismatch)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			dst.SetFloat(float64(src.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			dst.SetFloat(float64(src.Uint()))
+		case reflect.Float32, reflect.Float64:
+			f, ok := castFloat32(src.Float(), dst.Kind(), mode)
+			if !ok {
+				return errOutOfRange(src, dst)
+			}
+			dst.SetFloat(f)
+		default:
+			return fmt.Errorf("npy: can not convert %v to %v: %w", src.Type(), dst.Type(), ErrTypeMismatch)
+		}
+
+	case reflect.Complex64, reflect.Complex128:
+		var c complex128
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			c = complex(float64(src.Int()), 0)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			c = complex(float64(src.Uint()), 0)
+		case reflect.Float32, reflect.Float64:
+			c = complex(src.Float(), 0)
+		case reflect.Complex64, reflect.Complex128:
+			c = src.Complex()
+		default:
+			return fmt.Errorf("npy: can not convert %v to %v: %w", src.Type(), dst.Type(), ErrTypeMismatch)
+		}
+		kind := reflect.Float64
+		if dst.Kind() == reflect.Complex64 {
+			kind = reflect.Float32
+		}
+		re, ok := castFloat32(real(c), kind, mode)
+		if !ok {
+			return errOutOfRange(src, dst)
+		}
+		im, ok := castFloat32(imag(c), kind, mode)
+		if !ok {
+			return errOutOfRange(src, dst)
+		}
+		dst.SetComplex(complex(re, im))
+
+	default:
+		return fmt.Errorf("npy: can not convert %v to %v: %w", src.Type(), dst.Type(), ErrInvalidType)
+	}
+
+	return nil
+}
+
+// castFloat converts the integral value f to an N-bits integer, returning
+// its bit pattern and whether f was in the [lo, hi) range.
+// Out-of-range values are wrapped modulo 2^N; NaNs and infinities are
+// converted to 0.
+func castFloat(f, lo, hi float64, bits int) (uint64, bool) {
+	if lo <= f && f < hi {
+		if f < 0 {
+			return uint64(int64(f)), true
+		}
+		return uint64(f), true
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	mod := math.Ldexp(1, bits)
+	f = math.Mod(f, mod)
+	if f < 0 {
+		f += mod
+	}
+	return uint64(f), false
+}
+
+// castFloat32 converts f to a float of the provided kind, handling values
+// too large for a float32 according to mode.
+func castFloat32(f float64, kind reflect.Kind, mode CastMode) (float64, bool) {
+	if kind != reflect.Float32 || math.IsInf(f, 0) || math.Abs(f) <= math.MaxFloat32 {
+		return f, true
+	}
+	switch mode {
+	case CastStrict:
+		return 0, false
+	case CastClamp:
+		return math.Copysign(math.MaxFloat32, f), true
+	}
+	return f, true
+}
+
+// castFloat16 rounds src to the nearest half-precision value and stores it
+// into dst.
+func castFloat16(dst, src reflect.Value, mode CastMode) error {
+	var f float64
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(src.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f = float64(src.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = src.Float()
+	default:
+		return fmt.Errorf("npy: can not convert %v to %v: %w", src.Type(), dst.Type(), ErrTypeMismatch)
+	}
+	if !math.IsInf(f, 0) && math.Abs(f) > float16.MaxValue {
+		switch mode {
+		case CastStrict:
+			return errOutOfRange(src, dst)
+		case CastClamp:
+			f = math.Copysign(float16.MaxValue, f)
+		}
+	}
+	dst.SetUint(uint64(float16.New(f)))
+	return nil
+}
+
+func clamp(v, lo, hi int64) int64 {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	}
+	return v
+}
+
+func errOutOfRange(src, dst reflect.Value) error {
+	return fmt.Errorf("npy: value %v out of range of %v: %w", src, dst.Type(), ErrOutOfRange)
+}
+
+func errInexact(src, dst reflect.Value) error {
+	return fmt.Errorf("npy: value %v can not be exactly represented as %v: %w", src, dst.Type(), ErrOutOfRange)
+}
+
+// isFraction returns whether f is a finite value with a fractional part.
+func isFraction(f float64) bool {
+	return !math.IsInf(f, 0) && f != math.Trunc(f) && !math.IsNaN(f)
+}
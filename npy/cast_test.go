@@ -0,0 +1,246 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWriteAs(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		src   interface{}
+		dtype string
+		mode  CastMode
+		want  interface{}
+		shape []int
+		err   error
+		msg   string // expected part of the error message
+	}{
+		{
+			name:  "f64-u1-strict",
+			src:   []float64{0, 1, 255},
+			dtype: "|u1",
+			want:  []uint8{0, 1, 255},
+			shape: []int{3},
+		},
+		{
+			name:  "f64-u1-strict-overflow",
+			src:   []float64{0, 1, 256},
+			dtype: "|u1",
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "f64-u1-strict-fraction",
+			src:   []float64{0, 1.5, 2},
+			dtype: "|u1",
+			err:   ErrOutOfRange,
+			msg:   "element #1",
+		},
+		{
+			name:  "f64-i4-strict-fraction",
+			src:   []float64{-1, 2, -0.25},
+			dtype: "<i4",
+			err:   ErrOutOfRange,
+			msg:   "element #2",
+		},
+		{
+			name:  "f64-i4-clamp-fraction",
+			src:   []float64{-1.5, 2.75},
+			dtype: "<i4",
+			mode:  CastClamp,
+			want:  []int32{-1, 2},
+			shape: []int{2},
+		},
+		{
+			name:  "f64-u1-strict-nan",
+			src:   []float64{math.NaN()},
+			dtype: "|u1",
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "f64-u1-clamp",
+			src:   []float64{-1, 0.5, 255.9, 300, math.Inf(+1), math.NaN()},
+			dtype: "|u1",
+			mode:  CastClamp,
+			want:  []uint8{0, 0, 255, 255, 255, 0},
+			shape: []int{6},
+		},
+		{
+			name:  "f64-u1-wrap",
+			src:   []float64{-1, 0.5, 255.9, 300, math.Inf(+1)},
+			dtype: "|u1",
+			mode:  CastWrap,
+			want:  []uint8{255, 0, 255, 44, 0},
+			shape: []int{5},
+		},
+		{
+			name:  "i64-i1-clamp",
+			src:   [4]int64{-200, -128, 127, 200},
+			dtype: "<i1",
+			mode:  CastClamp,
+			want:  []int8{-128, -128, 127, 127},
+			shape: []int{4},
+		},
+		{
+			name:  "i64-i1-wrap",
+			src:   []int64{-200, 200},
+			dtype: "<i1",
+			mode:  CastWrap,
+			want:  []int8{56, -56},
+			shape: []int{2},
+		},
+		{
+			name:  "u64-i2-clamp",
+			src:   []uint64{1, math.MaxUint64},
+			dtype: "<i2",
+			mode:  CastClamp,
+			want:  []int16{1, math.MaxInt16},
+			shape: []int{2},
+		},
+		{
+			name:  "i32-u2-strict",
+			src:   []int32{1, -1},
+			dtype: "<u2",
+			err:   ErrOutOfRange,
+		},
+		{
+			name:  "f64-f4-clamp",
+			src:   []float64{1e300, -1e300, math.Inf(-1), 1.5},
+			dtype: "<f4",
+			mode:  CastClamp,
+			want:  []float32{math.MaxFloat32, -math.MaxFloat32, float32(math.Inf(-1)), 1.5},
+			shape: []int{4},
+		},
+		{
+			name:  "f64-f4-wrap",
+			src:   []float64{1e300},
+			dtype: "<f4",
+			mode:  CastWrap,
+			want:  []float32{float32(math.Inf(+1))},
+			shape: []int{1},
+		},
+		{
+			name:  "f64-i4-be-scalar",
+			src:   -42.0,
+			dtype: ">i4",
+			want:  int32(-42),
+		},
+		{
+			name:  "c128-c8",
+			src:   []complex128{1 + 2i},
+			dtype: "<c8",
+			want:  []complex64{1 + 2i},
+			shape: []int{1},
+		},
+		{
+			name:  "c128-f8",
+			src:   []complex128{1 + 2i},
+			dtype: "<f8",
+			err:   ErrTypeMismatch,
+		},
+		{
+			name:  "dense-u1",
+			src:   mat.NewDense(2, 2, []float64{-1, 2, 3, 1000}),
+			dtype: "|u1",
+			mode:  CastClamp,
+			want:  []uint8{0, 2, 3, 255},
+			shape: []int{2, 2},
+		},
+		{
+			name:  "i64-i4-strict",
+			src:   []int64{0, -1 << 31, 1<<31 - 1},
+			dtype: "<i4",
+			want:  []int32{0, -1 << 31, 1<<31 - 1},
+			shape: []int{3},
+		},
+		{
+			name:  "i64-i4-strict-overflow",
+			src:   []int64{0, 1, 1 << 31, 3},
+			dtype: "<i4",
+			err:   ErrOutOfRange,
+			msg:   "element #2",
+		},
+		{
+			name:  "nested-i64-i2",
+			src:   [][]int64{{0, 1, 2}, {-3, -4, -5}},
+			dtype: "<i2",
+			want:  []int16{0, 1, 2, -3, -4, -5},
+			shape: []int{2, 3},
+		},
+		{
+			name:  "nested-arrays-f64-f4",
+			src:   [][2][2]float64{{{0, 1}, {2, 3}}},
+			dtype: "<f4",
+			want:  []float32{0, 1, 2, 3},
+			shape: []int{1, 2, 2},
+		},
+		{
+			name:  "nested-overflow",
+			src:   [][]int64{{0, 1}, {2, 1 << 40}},
+			dtype: "<i4",
+			err:   ErrOutOfRange,
+			msg:   "element #3",
+		},
+		{
+			name:  "nested-ragged",
+			src:   [][]int64{{0, 1}, {2}},
+			dtype: "<i4",
+			err:   errDims,
+		},
+		{
+			name:  "string",
+			src:   []string{"hello"},
+			dtype: "<i4",
+			err:   ErrInvalidType,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteAs(buf, tc.src, tc.dtype, WithCast(tc.mode))
+			switch {
+			case tc.err != nil && err == nil:
+				t.Fatalf("expected an error")
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%+v, want=%+v", err, tc.err)
+				}
+				if !strings.Contains(err.Error(), tc.msg) {
+					t.Fatalf("invalid error message: got=%q, want=%q", err, tc.msg)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Type, tc.dtype; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+			if got, want := r.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got.Elem().Interface(), tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got.Elem().Interface(), tc.want)
+			}
+		})
+	}
+}
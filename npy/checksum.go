@@ -0,0 +1,98 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+const footerLen = 12 // size of the checksum footer
+
+var (
+	footerMagic = []byte("\x93NPYCRC\x00")
+	crcTable    = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// WriteChecksummed writes 'val' into 'w' in the NumPy data format, as Write
+// does, followed by a checksum footer that can be validated with
+// VerifyChecksum.
+//
+// The footer is 12 bytes long and laid out as:
+//
+//	offset  size  content
+//	0       8     the magic string "\x93NPYCRC\x00"
+//	8       4     the CRC-32C (Castagnoli) checksum of all the bytes
+//	              preceding the footer (header and array data), as a
+//	              little-endian uint32
+//
+// NumPy ignores the bytes following the array data, so that np.load still
+// reads files with a checksum footer.
+func WriteChecksummed(w io.Writer, val interface{}) error {
+	return wrapErr("write", writeChecksummed(w, val))
+}
+
+func writeChecksummed(w io.Writer, val interface{}) error {
+	crc := crc32.New(crcTable)
+	err := writeWith(io.MultiWriter(w, crc), val)
+	if err != nil {
+		return err
+	}
+
+	var footer [footerLen]byte
+	copy(footer[:], footerMagic)
+	binary.LittleEndian.PutUint32(footer[8:], crc.Sum32())
+	_, err = w.Write(footer[:])
+	return err
+}
+
+// VerifyChecksum validates the checksum footer of the NumPy data file of
+// the provided size held in r, as written by WriteChecksummed.
+// VerifyChecksum returns whether the checksum of the file matches the one
+// recorded in its footer.
+//
+// VerifyChecksum returns ErrInvalidNumPyFormat if r does not hold a NumPy
+// data file followed by a checksum footer.
+func VerifyChecksum(r io.ReaderAt, size int64) (bool, error) {
+	ok, err := verifyChecksum(r, size)
+	return ok, wrapErr("read", err)
+}
+
+func verifyChecksum(r io.ReaderAt, size int64) (bool, error) {
+	hdr, off, err := ReadHeaderAt(r, 0)
+	if err != nil {
+		return false, err
+	}
+	n, err := dataSize(hdr)
+	if err != nil {
+		return false, err
+	}
+	end := off + n
+	if size != end+footerLen {
+		return false, fmt.Errorf(
+			"npy: file size %d does not match array data end %d and checksum footer: %w",
+			size, end, ErrInvalidNumPyFormat,
+		)
+	}
+
+	var footer [footerLen]byte
+	_, err = r.ReadAt(footer[:], end)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	if !bytes.Equal(footer[:len(footerMagic)], footerMagic) {
+		return false, fmt.Errorf("npy: missing checksum footer: %w", ErrInvalidNumPyFormat)
+	}
+
+	crc := crc32.New(crcTable)
+	_, err = io.Copy(crc, io.NewSectionReader(r, 0, end))
+	if err != nil {
+		return false, err
+	}
+	return crc.Sum32() == binary.LittleEndian.Uint32(footer[8:]), nil
+}
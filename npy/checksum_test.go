@@ -0,0 +1,84 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	want := []float64{1, 2, 3, 4, 5, 6}
+	buf := new(bytes.Buffer)
+	err := WriteChecksummed(buf, want)
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	ok, err := VerifyChecksum(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatalf("could not verify checksum: %+v", err)
+	}
+	if !ok {
+		t.Fatalf("invalid checksum")
+	}
+
+	var got []float64
+	err = Read(bytes.NewReader(raw), &got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+
+	for _, pos := range []int{len(raw) - footerLen - 8*len(want), len(raw) - footerLen - 1, len(raw) - 1} {
+		corrupt := append([]byte(nil), raw...)
+		corrupt[pos] ^= 0x01
+		ok, err := VerifyChecksum(bytes.NewReader(corrupt), int64(len(corrupt)))
+		if err != nil {
+			t.Fatalf("could not verify checksum (byte %d): %+v", pos, err)
+		}
+		if ok {
+			t.Fatalf("corrupted byte %d not detected", pos)
+		}
+	}
+}
+
+func TestChecksumInvalid(t *testing.T) {
+	plain := new(bytes.Buffer)
+	err := Write(plain, []int32{1, 2, 3})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+
+	sum := new(bytes.Buffer)
+	err = WriteChecksummed(sum, []int32{1, 2, 3})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	magic := append([]byte(nil), sum.Bytes()...)
+	magic[len(magic)-footerLen] = 'X'
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+	}{
+		{"no-footer", plain.Bytes()},
+		{"truncated", sum.Bytes()[:sum.Len()-1]},
+		{"magic", magic},
+		{"not-npy", []byte("not a numpy file")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := VerifyChecksum(bytes.NewReader(tc.raw), int64(len(tc.raw)))
+			if !errors.Is(err, ErrInvalidNumPyFormat) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidNumPyFormat)
+			}
+		})
+	}
+}
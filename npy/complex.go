@@ -0,0 +1,141 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// ComplexParts holds the real and the imaginary parts of the elements of a
+// complex array, in two parallel slices.
+//
+// Read splits complex arrays ('c8' or 'c16') into the parts of a
+// *ComplexParts destination, in the order the elements are stored on
+// disk, as it does for slices. The slices are reused if their capacity is
+// large enough, and re-allocated otherwise.
+// Write interleaves the parts back into a 1-dimensional complex128 array;
+// both parts must then have the same length.
+type ComplexParts struct {
+	Re, Im []float64
+}
+
+var complexPartsType = reflect.TypeOf(ComplexParts{})
+
+// complexDescr returns the descriptor of the complex data type whose
+// memory layout is the one of the structured data type descr, if descr
+// describes exactly two packed fields named 'real' and 'imag', in that
+// order, of the same float32 or float64 data type.
+func complexDescr(descr string) (string, bool) {
+	rec, err := newRecType(descr)
+	if err != nil || len(rec.fields) != 2 {
+		return "", false
+	}
+	var (
+		re = rec.fields[0]
+		im = rec.fields[1]
+	)
+	if re.rec != nil || im.rec != nil || re.shape != nil || im.shape != nil {
+		return "", false
+	}
+	if re.name != "real" || im.name != "imag" || re.dt.str != im.dt.str ||
+		im.offset != re.dt.size || rec.size != 2*re.dt.size {
+		return "", false
+	}
+
+	order := re.dt.str[:len(re.dt.str)-len(typeCode(re.dt.str))]
+	switch re.dt.rt {
+	case float32Type:
+		return order + "c8", true
+	case float64Type:
+		return order + "c16", true
+	}
+	return "", false
+}
+
+// splitComplexDescr returns the descriptor of the structured data type
+// with a 'real' and an 'imag' field laid out as the complex data type dt.
+func splitComplexDescr(dt dType) string {
+	format := "f4"
+	if dt.rt == complex128Type {
+		format = "f8"
+	}
+	format = dt.str[:len(dt.str)-len(typeCode(dt.str))] + format
+	return repr([]interface{}{tuple{"real", format}, tuple{"imag", format}})
+}
+
+// isComplexElem returns whether the innermost element type of rt, looking
+// through pointers, slices and arrays, is a complex type.
+func isComplexElem(rt reflect.Type) bool {
+	for {
+		if rt == complexPartsType {
+			return true
+		}
+		switch rt.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			rt = rt.Elem()
+		case reflect.Complex64, reflect.Complex128:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// readParts reads the complex array data into the real and the imaginary
+// parts of dst.
+func (r *Reader) readParts(dst *ComplexParts, dt dType) error {
+	if dt.rt != complex64Type && dt.rt != complex128Type {
+		return ErrTypeMismatch
+	}
+	err := r.checkMem(dt.size)
+	if err != nil {
+		return err
+	}
+
+	n := numElems(r.Header.Descr.Shape)
+	dst.Re = resize(dst.Re, n)
+	dst.Im = resize(dst.Im, n)
+
+	const chunk = 4096 // number of elements decoded at once
+	buf := make([]byte, min(n, chunk)*dt.size)
+	for beg := 0; beg < n; beg += chunk {
+		end := min(beg+chunk, n)
+		raw := buf[:(end-beg)*dt.size]
+		_, err := r.read(raw)
+		if err != nil {
+			return err
+		}
+		for i := beg; i < end; i++ {
+			elem := raw[(i-beg)*dt.size:]
+			switch dt.rt {
+			case complex64Type:
+				dst.Re[i] = float64(math.Float32frombits(dt.order.Uint32(elem[0:])))
+				dst.Im[i] = float64(math.Float32frombits(dt.order.Uint32(elem[4:])))
+			default:
+				dst.Re[i] = math.Float64frombits(dt.order.Uint64(elem[0:]))
+				dst.Im[i] = math.Float64frombits(dt.order.Uint64(elem[8:]))
+			}
+		}
+	}
+	return nil
+}
+
+// interleave returns the complex values whose real and imaginary parts
+// are held by parts.
+func interleave(parts ComplexParts) ([]complex128, error) {
+	if len(parts.Re) != len(parts.Im) {
+		return nil, fmt.Errorf(
+			"npy: real and imaginary parts have different lengths (%d and %d): %w",
+			len(parts.Re), len(parts.Im), errDims,
+		)
+	}
+	vs := make([]complex128, len(parts.Re))
+	for i := range vs {
+		vs[i] = complex(parts.Re[i], parts.Im[i])
+	}
+	return vs, nil
+}
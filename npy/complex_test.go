@@ -0,0 +1,225 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadSplitComplex(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		ptr   interface{}
+		opts  []ReadOption
+		want  interface{}
+	}{
+		{
+			fname: "../testdata/data_complex128_split.npy",
+			ptr:   new([]complex128),
+			want:  []complex128{1 + 2i, -3.5, -1i},
+		},
+		{
+			fname: "../testdata/data_complex128_split.npy",
+			ptr:   new([3]complex128),
+			want:  [3]complex128{1 + 2i, -3.5, -1i},
+		},
+		{
+			fname: "../testdata/data_complex64_split_bigendian.npy",
+			ptr:   new([]complex64),
+			want:  []complex64{1 + 2i, -3.5, -1i},
+		},
+		{
+			fname: "../testdata/data_complex64_split_bigendian.npy",
+			ptr:   new([]complex128),
+			opts:  []ReadOption{WithConvert()},
+			want:  []complex128{1 + 2i, -3.5, -1i},
+		},
+		{
+			fname: "../testdata/data_complex128_split.npy",
+			ptr: new([]struct {
+				Real float64 `npy:"real"`
+				Imag float64 `npy:"imag"`
+			}),
+			want: []struct {
+				Real float64 `npy:"real"`
+				Imag float64 `npy:"imag"`
+			}{{1, 2}, {-3.5, 0}, {0, -1}},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			err = Read(f, tc.ptr, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := reflect.ValueOf(tc.ptr).Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadSplitComplexMismatch(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		descr string
+	}{
+		{"names", "[('re', '<f8'), ('im', '<f8')]"},
+		{"order", "[('imag', '<f8'), ('real', '<f8')]"},
+		{"types", "[('real', '<f8'), ('imag', '<f4')]"},
+		{"ints", "[('real', '<i8'), ('imag', '<i8')]"},
+		{"padding", "[('real', '<f4'), ('', '|V4'), ('imag', '<f4')]"},
+		{"fields", "[('real', '<f8'), ('imag', '<f8'), ('x', '<f8')]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if c, ok := complexDescr(tc.descr); ok {
+				t.Fatalf("invalid complex descriptor %q for %q", c, tc.descr)
+			}
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	err := WriteWith(buf, []complex128{1 + 2i}, WithSplitComplex(true))
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	var got []float64
+	err = Read(buf, &got)
+	var merr *MismatchError
+	if !errors.As(err, &merr) {
+		t.Fatalf("invalid error: got=%v, want=%T", err, merr)
+	}
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+	}
+}
+
+func TestWriteSplitComplex(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		descr string
+	}{
+		{"c16", []complex128{1 + 2i, -3.5, -1i}, "[('real', '<f8'), ('imag', '<f8')]"},
+		{"c8", []complex64{1 + 2i, -3.5, -1i}, "[('real', '<f4'), ('imag', '<f4')]"},
+		{"scalar", complex(1, -1), "[('real', '<f8'), ('imag', '<f8')]"},
+		{"floats", []float64{1, 2}, "<f8"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, tc.val, WithSplitComplex(true))
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Type, tc.descr; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.val))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := got.Elem().Interface(); !reflect.DeepEqual(got, tc.val) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.val)
+			}
+		})
+	}
+}
+
+func TestReadComplexParts(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		want  ComplexParts
+	}{
+		{
+			fname: "../testdata/data_complex128_split.npy",
+			want:  ComplexParts{Re: []float64{1, -3.5, 0}, Im: []float64{2, 0, -1}},
+		},
+		{
+			fname: "../testdata/data_complex64_split_bigendian.npy",
+			want:  ComplexParts{Re: []float64{1, -3.5, 0}, Im: []float64{2, 0, -1}},
+		},
+		{
+			fname: "../testdata/data_complex128_scalar_corder.npy",
+			want:  ComplexParts{Re: []float64{3.5}, Im: []float64{-1.25}},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			// storage large enough is reused.
+			got := ComplexParts{Re: make([]float64, 0, 8), Im: make([]float64, 0, 8)}
+			re := got.Re[:1]
+			err = Read(f, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+			if &got.Re[0] != &re[0] {
+				t.Fatalf("real part storage was not reused")
+			}
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	err := Write(buf, []float64{1, 2})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	var parts ComplexParts
+	err = Read(buf, &parts)
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+	}
+}
+
+func TestWriteComplexParts(t *testing.T) {
+	parts := ComplexParts{Re: []float64{1, -3.5, 0}, Im: []float64{2, 0, -1}}
+	for _, val := range []interface{}{parts, &parts} {
+		buf := new(bytes.Buffer)
+		err := Write(buf, val)
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+
+		var got []complex128
+		hdr, err := ReadWithHeader(buf, &got)
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if got, want := hdr.Descr.Type, "<c16"; got != want {
+			t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+		}
+		if want := []complex128{1 + 2i, -3.5, -1i}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+		}
+	}
+
+	err := Write(new(bytes.Buffer), ComplexParts{Re: []float64{1, 2}, Im: []float64{3}})
+	if !errors.Is(err, errDims) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, errDims)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+)
+
+// Concat concatenates the NumPy arrays read from the provided readers
+// along their first axis, and writes the resulting array to w.
+//
+// See ConcatAxis for documentation.
+func Concat(w io.Writer, readers ...io.Reader) error {
+	return ConcatAxis(w, 0, readers...)
+}
+
+// ConcatAxis concatenates the NumPy arrays read from the provided readers
+// along the provided axis, and writes the resulting array to w.
+// As with NumPy, a negative axis counts from the last dimension.
+//
+// All input arrays must share the same data type, the same memory order,
+// the same number of dimensions and the same dimensions but along the
+// concatenation axis.
+// Only arrays with at least one dimension can be concatenated.
+//
+// The data payloads are streamed from the inputs to the output: they are
+// never fully loaded in memory.
+// Concatenating C-ordered arrays along their first axis, or Fortran-ordered
+// arrays along their last axis, copies each payload in one go; other axes
+// interleave blocks of the inputs.
+func ConcatAxis(w io.Writer, axis int, readers ...io.Reader) error {
+	if len(readers) == 0 {
+		return fmt.Errorf("npy: no array to concatenate")
+	}
+
+	var (
+		rs    = make([]*Reader, len(readers))
+		dt    dType
+		shape []int
+	)
+	for i, r := range readers {
+		rr, err := NewReader(r)
+		if err != nil {
+			return fmt.Errorf("npy: could not read header of array #%d: %w", i, err)
+		}
+		rs[i] = rr
+
+		hdr := rr.Header
+		if len(hdr.Descr.Shape) == 0 {
+			return fmt.Errorf("npy: can not concatenate 0-d array #%d: %w", i, errDims)
+		}
+
+		if i == 0 {
+			dt, err = newDtype(hdr.Descr.Type)
+			if err != nil {
+				return err
+			}
+			shape = append([]int(nil), hdr.Descr.Shape...)
+			if axis < 0 {
+				axis += len(shape)
+			}
+			if axis < 0 || axis >= len(shape) {
+				return fmt.Errorf(
+					"npy: axis %d out of bounds for arrays of dimension %d: %w",
+					axis, len(shape), errDims,
+				)
+			}
+			continue
+		}
+
+		if hdr.Descr.Type != rs[0].Header.Descr.Type {
+			return fmt.Errorf(
+				"npy: array #%d has dtype %q, want %q: %w",
+				i, hdr.Descr.Type, rs[0].Header.Descr.Type, ErrTypeMismatch,
+			)
+		}
+		if hdr.Descr.Fortran != rs[0].Header.Descr.Fortran && len(shape) > 1 {
+			return fmt.Errorf("npy: array #%d does not share the memory order of array #0", i)
+		}
+		if len(hdr.Descr.Shape) != len(shape) ||
+			!equalDims(hdr.Descr.Shape[:axis], shape[:axis]) ||
+			!equalDims(hdr.Descr.Shape[axis+1:], shape[axis+1:]) {
+			return fmt.Errorf(
+				"npy: array #%d has shape %v, incompatible with %v along axis %d: %w",
+				i, hdr.Descr.Shape, rs[0].Header.Descr.Shape, axis, errDims,
+			)
+		}
+		shape[axis] += hdr.Descr.Shape[axis]
+	}
+
+	hdr := newHeader()
+	hdr.Descr.Type = rs[0].Header.Descr.Type
+	hdr.Descr.Fortran = rs[0].Header.Descr.Fortran
+	hdr.Descr.Shape = shape
+
+	err := writeHeader(w, hdr, dt)
+	if err != nil {
+		return err
+	}
+
+	// the data of each input is a sequence of nblocks blocks, laid out
+	// along the axes outer to the concatenation axis.
+	// the blocks of the inputs are interleaved in the output.
+	outer, inner := shape[:axis], shape[axis+1:]
+	if hdr.Descr.Fortran {
+		outer, inner = inner, outer
+	}
+	nblocks, _ := numElems64(outer)
+	ninner, _ := numElems64(inner)
+
+	sizes := make([]int64, len(rs))
+	for i, r := range rs {
+		sizes[i] = ninner * int64(r.Header.Descr.Shape[axis]) * int64(dt.size)
+	}
+
+	buf := make([]byte, 32*1024)
+	for b := int64(0); b < nblocks; b++ {
+		for i, r := range rs {
+			n, err := io.CopyBuffer(w, io.LimitReader(r.r, sizes[i]), buf)
+			if err == nil && n < sizes[i] {
+				err = io.ErrUnexpectedEOF
+			}
+			if err != nil {
+				return fmt.Errorf("npy: could not copy data of array #%d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func equalDims(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
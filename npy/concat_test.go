@@ -0,0 +1,322 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestConcat(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		srcs  []interface{}
+		want  interface{}
+		shape []int
+	}{
+		{
+			name:  "1d",
+			srcs:  []interface{}{[]float64{0, 1, 2}, []float64{3}, []float64{4, 5}},
+			want:  []float64{0, 1, 2, 3, 4, 5},
+			shape: []int{6},
+		},
+		{
+			name:  "2d",
+			srcs:  []interface{}{shaped{[]int32{0, 1, 2, 3}, []int{2, 2}}, shaped{[]int32{4, 5}, []int{1, 2}}},
+			want:  []int32{0, 1, 2, 3, 4, 5},
+			shape: []int{3, 2},
+		},
+		{
+			name:  "single",
+			srcs:  []interface{}{[]uint8{1, 2, 3}},
+			want:  []uint8{1, 2, 3},
+			shape: []int{3},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srcs := make([]io.Reader, len(tc.srcs))
+			for i, src := range tc.srcs {
+				buf := new(bytes.Buffer)
+				err := writeShaped(buf, src)
+				if err != nil {
+					t.Fatalf("could not create input #%d: %+v", i, err)
+				}
+				srcs[i] = buf
+			}
+
+			out := new(bytes.Buffer)
+			err := Concat(out, srcs...)
+			if err != nil {
+				t.Fatalf("could not concatenate: %+v", err)
+			}
+
+			r, err := NewReader(out)
+			if err != nil {
+				t.Fatalf("could not read header: %+v", err)
+			}
+			if got, want := r.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestConcatErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		srcs []interface{}
+		err  error
+	}{
+		{
+			name: "dtype",
+			srcs: []interface{}{[]float64{0, 1}, []float32{2, 3}},
+			err:  ErrTypeMismatch,
+		},
+		{
+			name: "dims",
+			srcs: []interface{}{shaped{[]float64{0, 1}, []int{1, 2}}, shaped{[]float64{2, 3, 4}, []int{1, 3}}},
+			err:  errDims,
+		},
+		{
+			name: "scalar",
+			srcs: []interface{}{float64(1), float64(2)},
+			err:  errDims,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srcs := make([]io.Reader, len(tc.srcs))
+			for i, src := range tc.srcs {
+				buf := new(bytes.Buffer)
+				err := writeShaped(buf, src)
+				if err != nil {
+					t.Fatalf("could not create input #%d: %+v", i, err)
+				}
+				srcs[i] = buf
+			}
+
+			err := Concat(io.Discard, srcs...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestConcatAxis(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		axis    int
+		fortran bool
+		srcs    []interface{}
+		want    interface{}
+		shape   []int
+	}{
+		{
+			name:  "2d-axis1",
+			axis:  1,
+			srcs:  []interface{}{shaped{[]int32{0, 1, 2, 3}, []int{2, 2}}, shaped{[]int32{4, 5}, []int{2, 1}}},
+			want:  []int32{0, 1, 4, 2, 3, 5},
+			shape: []int{2, 3},
+		},
+		{
+			name:  "2d-axis-1",
+			axis:  -1,
+			srcs:  []interface{}{shaped{[]int32{0, 1, 2, 3}, []int{2, 2}}, shaped{[]int32{4, 5}, []int{2, 1}}},
+			want:  []int32{0, 1, 4, 2, 3, 5},
+			shape: []int{2, 3},
+		},
+		{
+			name: "3d-axis1",
+			axis: 1,
+			srcs: []interface{}{
+				shaped{[]uint8{0, 1, 2, 3}, []int{2, 1, 2}},
+				shaped{[]uint8{4, 5, 6, 7, 8, 9, 10, 11}, []int{2, 2, 2}},
+			},
+			want:  []uint8{0, 1, 4, 5, 6, 7, 2, 3, 8, 9, 10, 11},
+			shape: []int{2, 3, 2},
+		},
+		{
+			name:    "fortran-axis0",
+			fortran: true,
+			srcs:    []interface{}{mat.NewDense(2, 2, []float64{0, 1, 2, 3}), mat.NewDense(1, 2, []float64{4, 5})},
+			want:    mat.NewDense(3, 2, []float64{0, 1, 2, 3, 4, 5}),
+			shape:   []int{3, 2},
+		},
+		{
+			name:    "fortran-axis1",
+			axis:    1,
+			fortran: true,
+			srcs:    []interface{}{mat.NewDense(2, 2, []float64{0, 1, 2, 3}), mat.NewDense(2, 1, []float64{4, 5})},
+			want:    mat.NewDense(2, 3, []float64{0, 1, 4, 2, 3, 5}),
+			shape:   []int{2, 3},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srcs := make([]io.Reader, len(tc.srcs))
+			for i, src := range tc.srcs {
+				buf := new(bytes.Buffer)
+				var err error
+				switch {
+				case tc.fortran:
+					err = WriteWith(buf, src, WithFortranOrder(true))
+				default:
+					err = writeShaped(buf, src)
+				}
+				if err != nil {
+					t.Fatalf("could not create input #%d: %+v", i, err)
+				}
+				srcs[i] = buf
+			}
+
+			out := new(bytes.Buffer)
+			err := ConcatAxis(out, tc.axis, srcs...)
+			if err != nil {
+				t.Fatalf("could not concatenate: %+v", err)
+			}
+
+			r, err := NewReader(out)
+			if err != nil {
+				t.Fatalf("could not read header: %+v", err)
+			}
+			if got, want := r.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+			if got, want := r.Header.Descr.Fortran, tc.fortran; got != want {
+				t.Fatalf("invalid memory order: got=%v, want=%v", got, want)
+			}
+
+			if want, ok := tc.want.(*mat.Dense); ok {
+				var got mat.Dense
+				err = r.Read(&got)
+				if err != nil {
+					t.Fatalf("could not read data: %+v", err)
+				}
+				if !mat.Equal(&got, want) {
+					t.Fatalf("invalid data:\ngot= %v\nwant=%v", mat.Formatted(&got), mat.Formatted(want))
+				}
+				return
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestConcatAxisErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		axis int
+		srcs []interface{}
+		err  error
+	}{
+		{
+			name: "axis",
+			axis: 2,
+			srcs: []interface{}{shaped{[]float64{0, 1}, []int{1, 2}}, shaped{[]float64{2, 3}, []int{1, 2}}},
+			err:  errDims,
+		},
+		{
+			name: "negative-axis",
+			axis: -3,
+			srcs: []interface{}{shaped{[]float64{0, 1}, []int{1, 2}}, shaped{[]float64{2, 3}, []int{1, 2}}},
+			err:  errDims,
+		},
+		{
+			name: "ndims",
+			axis: 1,
+			srcs: []interface{}{shaped{[]float64{0, 1}, []int{1, 2}}, shaped{[]float64{2, 3}, []int{1, 2, 1}}},
+			err:  errDims,
+		},
+		{
+			name: "dims",
+			axis: 1,
+			srcs: []interface{}{shaped{[]float64{0, 1}, []int{1, 2}}, shaped{[]float64{2, 3}, []int{2, 1}}},
+			err:  errDims,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srcs := make([]io.Reader, len(tc.srcs))
+			for i, src := range tc.srcs {
+				buf := new(bytes.Buffer)
+				err := writeShaped(buf, src)
+				if err != nil {
+					t.Fatalf("could not create input #%d: %+v", i, err)
+				}
+				srcs[i] = buf
+			}
+
+			err := ConcatAxis(io.Discard, tc.axis, srcs...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Write(buf, []float64{0, 1, 2})
+		if err != nil {
+			t.Fatalf("could not create input: %+v", err)
+		}
+		raw := buf.Bytes()
+		err = ConcatAxis(io.Discard, 0, bytes.NewReader(raw), bytes.NewReader(raw[:len(raw)-4]))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+// shaped is a flat array of data together with its logical shape.
+type shaped struct {
+	data  interface{}
+	shape []int
+}
+
+func writeShaped(w io.Writer, v interface{}) error {
+	src, ok := v.(shaped)
+	if !ok {
+		return Write(w, v)
+	}
+
+	rv := reflect.ValueOf(src.data)
+	descr, err := dtypeFrom(rv, rv.Type())
+	if err != nil {
+		return err
+	}
+	dt, err := newDtype(descr)
+	if err != nil {
+		return err
+	}
+
+	hdr := newHeader()
+	hdr.Descr.Type = descr
+	hdr.Descr.Shape = src.shape
+	err = writeHeader(w, hdr, dt)
+	if err != nil {
+		return err
+	}
+	return writeData(w, rv, dt)
+}
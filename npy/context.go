@@ -0,0 +1,90 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"context"
+	"io"
+)
+
+// ctxChunkSize is the number of bytes read or written between two checks
+// of the context of ReadCtx and WriteCtx.
+const ctxChunkSize = 1 << 20
+
+// ReadCtx reads the data from the r NumPy data file io.Reader into the
+// provided pointed at value ptr, as Read does.
+//
+// ReadCtx checks ctx before the first read from r and then after every
+// MiB of data. It stops and returns an error wrapping ctx.Err() once ctx
+// is done.
+func ReadCtx(ctx context.Context, r io.Reader, ptr interface{}, opts ...ReadOption) error {
+	return Read(&ctxReader{ctx: ctx, r: r}, ptr, opts...)
+}
+
+// WriteCtx writes the value val into the io.Writer w, as Write does.
+//
+// WriteCtx checks ctx before the first write to w and then after every
+// MiB of data. It stops and returns an error wrapping ctx.Err() once ctx
+// is done.
+func WriteCtx(ctx context.Context, w io.Writer, val interface{}, opts ...WriteOption) error {
+	return WriteWith(&ctxWriter{ctx: ctx, w: w}, val, opts...)
+}
+
+// ctxReader is an io.Reader that checks its context every ctxChunkSize
+// bytes.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+	n   int // number of bytes left before the next check of ctx
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		err := r.ctx.Err()
+		if err != nil {
+			return 0, err
+		}
+		r.n = ctxChunkSize
+	}
+	if len(p) > r.n {
+		p = p[:r.n]
+	}
+	n, err := r.r.Read(p)
+	r.n -= n
+	return n, err
+}
+
+// ctxWriter is an io.Writer that checks its context every ctxChunkSize
+// bytes.
+type ctxWriter struct {
+	ctx context.Context
+	w   io.Writer
+	n   int // number of bytes left before the next check of ctx
+}
+
+func (w *ctxWriter) Write(p []byte) (int, error) {
+	var nn int
+	for len(p) > 0 {
+		if w.n <= 0 {
+			err := w.ctx.Err()
+			if err != nil {
+				return nn, err
+			}
+			w.n = ctxChunkSize
+		}
+		chunk := p
+		if len(chunk) > w.n {
+			chunk = chunk[:w.n]
+		}
+		n, err := w.w.Write(chunk)
+		nn += n
+		w.n -= n
+		if err != nil {
+			return nn, err
+		}
+		p = p[n:]
+	}
+	return nn, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// cancelReader cancels its context on the first read.
+type cancelReader struct {
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	r.cancel()
+	return r.r.Read(p)
+}
+
+// cancelWriter cancels its context on the first write.
+type cancelWriter struct {
+	w      io.Writer
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	w.cancel()
+	return w.w.Write(p)
+}
+
+func TestReadWriteCtx(t *testing.T) {
+	want := make([]float64, 3*ctxChunkSize/8)
+	for i := range want {
+		want[i] = float64(i)
+	}
+
+	buf := new(bytes.Buffer)
+	err := WriteCtx(context.Background(), buf, want)
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	var got []float64
+	err = ReadCtx(context.Background(), bytes.NewReader(raw), &got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data")
+	}
+
+	t.Run("read-canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var got []float64
+		err := ReadCtx(ctx, bytes.NewReader(raw), &got)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+		}
+	})
+
+	t.Run("read-cancel-midway", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var (
+			got []float64
+			r   = &cancelReader{r: bytes.NewReader(raw), cancel: cancel}
+		)
+		err := ReadCtx(ctx, r, &got)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+		}
+	})
+
+	t.Run("write-cancel-midway", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var (
+			out = new(bytes.Buffer)
+			w   = &cancelWriter{w: out, cancel: cancel}
+		)
+		err := WriteCtx(ctx, w, want)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, context.Canceled)
+		}
+		if out.Len() >= len(raw) {
+			t.Fatalf("write was not interrupted: %d bytes written", out.Len())
+		}
+	})
+}
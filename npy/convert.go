@@ -0,0 +1,182 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/sbinet/npyio/float16"
+)
+
+// elemType returns the type of the elements of rt, if rt is a slice or an
+// array type, or rt itself otherwise.
+func elemType(rt reflect.Type) reflect.Type {
+	switch rt.Kind() {
+	case reflect.Slice, reflect.Array:
+		return rt.Elem()
+	}
+	return rt
+}
+
+// canWiden returns whether values of type src can be converted to values of
+// type dst without any loss of information.
+// canWiden returns false when both types have the same kind, or when one
+// of them is float16.Num or time.Duration, whose values can not be
+// converted by reflection.
+func canWiden(src, dst reflect.Type) bool {
+	if src.Kind() == dst.Kind() || src == float16Type || dst == float16Type ||
+		src == durationType || dst == durationType {
+		return false
+	}
+
+	switch src.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch dst.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return dst.Size() > src.Size()
+		case reflect.Float32:
+			return src.Size() <= 2
+		case reflect.Float64:
+			return src.Size() <= 4
+		}
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch dst.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return dst.Size() > src.Size()
+		case reflect.Float32:
+			return src.Size() <= 2
+		case reflect.Float64:
+			return src.Size() <= 4
+		}
+
+	case reflect.Float32:
+		return dst.Kind() == reflect.Float64
+
+	case reflect.Complex64:
+		return dst.Kind() == reflect.Complex128
+	}
+
+	return false
+}
+
+// canNarrow returns whether values of type src can be rounded to values of
+// the narrower floating-point type dst.
+func canNarrow(src, dst reflect.Type) bool {
+	switch src.Kind() {
+	case reflect.Float64:
+		return dst.Kind() == reflect.Float32
+	case reflect.Complex128:
+		return dst.Kind() == reflect.Complex64
+	}
+	return false
+}
+
+// canCheck returns whether values of type src can be converted to values of
+// type dst, one value at a time, checking that each value is exactly
+// representable in dst: at least one of src and dst must be an integer
+// type, the other one being an integer or a floating-point type.
+func canCheck(src, dst reflect.Type) bool {
+	if src.Kind() == dst.Kind() || src == float16Type || dst == float16Type ||
+		src == durationType || dst == durationType {
+		return false
+	}
+	switch {
+	case isInteger(src.Kind()):
+		return isInteger(dst.Kind()) || isFloat(dst.Kind())
+	case isFloat(src.Kind()):
+		return isInteger(dst.Kind())
+	}
+	return false
+}
+
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// canConvert returns whether values of type src can be converted to
+// values of type dst by convertValue.
+// Integers are not converted to strings, as reflect.Value.Convert would
+// interpret them as Unicode code points.
+func canConvert(src, dst reflect.Type) bool {
+	if dst.Kind() == reflect.String && src.Kind() != reflect.String {
+		return false
+	}
+	return src.ConvertibleTo(dst)
+}
+
+// convertValue converts v to a value of type rt.
+// Conversions between integer types, and between integer and floating-point
+// types, fail with ErrOutOfRange for values not exactly representable in rt.
+// Half-precision values are converted from their value, not from their
+// bits, and no other type can be converted to float16.Num.
+// Other conversions are performed as reflect.Value.Convert does, and
+// convertValue fails with errNoConv for values canConvert can not convert.
+func convertValue(v reflect.Value, rt reflect.Type) (reflect.Value, error) {
+	const two63 = 1 << 63
+
+	switch {
+	case v.Type() == float16Type && rt != float16Type:
+		v = reflect.ValueOf(v.Interface().(float16.Num).Float64())
+	case v.Type() != float16Type && rt == float16Type:
+		return reflect.Value{}, errNoConv
+	}
+	if !canConvert(v.Type(), rt) {
+		return reflect.Value{}, errNoConv
+	}
+
+	out := v.Convert(rt)
+	ok := true
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x := v.Int()
+		switch k := rt.Kind(); {
+		case isInteger(k) && k >= reflect.Uint:
+			ok = x >= 0 && !out.OverflowUint(uint64(x))
+		case isInteger(k):
+			ok = !out.OverflowInt(x)
+		case isFloat(k):
+			f := out.Float()
+			ok = f >= -two63 && f < two63 && int64(f) == x
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		x := v.Uint()
+		switch k := rt.Kind(); {
+		case isInteger(k) && k >= reflect.Uint:
+			ok = !out.OverflowUint(x)
+		case isInteger(k):
+			ok = x <= math.MaxInt64 && !out.OverflowInt(int64(x))
+		case isFloat(k):
+			f := out.Float()
+			ok = f < 2*two63 && uint64(f) == x
+		}
+
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch k := rt.Kind(); {
+		case isInteger(k) && k >= reflect.Uint:
+			ok = f == math.Trunc(f) && f >= 0 && f < 2*two63 && !out.OverflowUint(uint64(f))
+		case isInteger(k):
+			ok = f == math.Trunc(f) && f >= -two63 && f < two63 && !out.OverflowInt(int64(f))
+		}
+	}
+	if !ok {
+		return out, fmt.Errorf("npy: value %v out of range of %v: %w", v, rt, ErrOutOfRange)
+	}
+	return out, nil
+}
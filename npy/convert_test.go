@@ -0,0 +1,246 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestReadConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		want interface{}
+		err  error
+	}{
+		{name: "i1-i8", val: []int8{-1, 0, 1}, want: []int64{-1, 0, 1}},
+		{name: "i2-f4", val: []int16{-1, 0, 1}, want: []float32{-1, 0, 1}},
+		{name: "i4-f8", val: []int32{-1, 0, 1}, want: []float64{-1, 0, 1}},
+		{name: "u1-i2", val: []uint8{0, 1, 255}, want: []int16{0, 1, 255}},
+		{name: "u4-u8", val: []uint32{0, 1, 1 << 31}, want: []uint64{0, 1, 1 << 31}},
+		{name: "f4-f8", val: []float32{-1.5, 0, 1.5}, want: []float64{-1.5, 0, 1.5}},
+		{name: "c8-c16", val: []complex64{1 + 2i}, want: []complex128{1 + 2i}},
+		{name: "scalar", val: int32(42), want: int64(42)},
+		{name: "array", val: []uint16{1, 2}, want: [2]uint32{1, 2}},
+		{name: "same", val: []float64{1, 2}, want: []float64{1, 2}},
+		{name: "i8-f8", val: []int64{-1, 1 << 53}, want: []float64{-1, 1 << 53}},
+		{name: "i4-u8", val: []int32{0, 1}, want: []uint64{0, 1}},
+		{name: "u8-i2", val: []uint64{0, 32767}, want: []int16{0, 32767}},
+		{name: "i8-u1", val: []int64{0, 255}, want: [2]uint8{0, 255}},
+		{name: "f8-i4", val: []float64{-2, 0, 3}, want: []int32{-2, 0, 3}},
+		{name: "f4-u2", val: []float32{0, 65535}, want: []uint16{0, 65535}},
+		{name: "scalar-i8-i1", val: int64(-128), want: int8(-128)},
+		{name: "i8-f8-inexact", val: []int64{1, 1<<53 + 1}, want: []float64{}, err: ErrOutOfRange},
+		{name: "u8-f8-inexact", val: []uint64{math.MaxUint64}, want: []float64{}, err: ErrOutOfRange},
+		{name: "i4-u8-negative", val: []int32{1, -1}, want: []uint64{}, err: ErrOutOfRange},
+		{name: "u4-i4-overflow", val: []uint32{1 << 31}, want: []int32{}, err: ErrOutOfRange},
+		{name: "i8-i1-overflow", val: []int64{300}, want: [1]int8{}, err: ErrOutOfRange},
+		{name: "f8-i4-fraction", val: []float64{1.5}, want: []int32{}, err: ErrOutOfRange},
+		{name: "f8-i8-nan", val: []float64{math.NaN()}, want: []int64{}, err: ErrOutOfRange},
+		{name: "f8-i8-overflow", val: []float64{1 << 63}, want: []int64{}, err: ErrOutOfRange},
+		{name: "f4-u1-negative", val: []float32{-1}, want: []uint8{}, err: ErrOutOfRange},
+		{name: "scalar-overflow", val: uint16(256), want: uint8(0), err: ErrOutOfRange},
+		{name: "f8-f4", val: []float64{1}, want: []float32{}, err: ErrTypeMismatch},
+		{name: "c16-c8", val: []complex128{1}, want: []complex64{}, err: ErrTypeMismatch},
+		{name: "c16-f8", val: []complex128{1}, want: []float64{}, err: ErrTypeMismatch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = Read(buf, got.Interface(), WithConvert())
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadConvertBigEndian(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		want  interface{}
+	}{
+		{
+			fname: "../testdata/data_complex64_bigendian.npy",
+			want:  []complex128{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+		{
+			fname: "../testdata/data_complex64_bigendian.npy",
+			want:  [4]complex128{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+		{
+			fname: "../testdata/data_complex128_bigendian.npy",
+			want:  []complex128{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = Read(f, got.Interface(), WithConvert())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadNarrowing(t *testing.T) {
+	const fname = "../testdata/data_float64_narrowing.npy"
+
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open %q: %+v", fname, err)
+	}
+	defer f.Close()
+
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	var src []float64
+	err = r.Read(&src)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+
+	want := make([]float32, len(src))
+	for i, v := range src {
+		want[i] = float32(v)
+	}
+	for i, v := range map[int]float32{
+		3: 16777216,
+		4: 0,
+		6: float32(math.Inf(+1)),
+		7: float32(math.Inf(-1)),
+	} {
+		if want[i] != v {
+			t.Fatalf("invalid reference value #%d: got=%v, want=%v", i, want[i], v)
+		}
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		t.Fatalf("could not rewind file: %+v", err)
+	}
+
+	var got []float32
+	err = Read(f, &got, WithConvert())
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: %+v", err)
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		t.Fatalf("could not rewind file: %+v", err)
+	}
+
+	r, err = NewReader(f, WithConvert(), WithNarrowing())
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	err = r.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+	if !r.Narrowed() {
+		t.Fatalf("narrowing should have been reported")
+	}
+
+	buf := new(bytes.Buffer)
+	err = Write(buf, []float32{1, 2})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	r, err = NewReader(buf, WithConvert(), WithNarrowing())
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	var f64 []float64
+	err = r.Read(&f64)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if r.Narrowed() {
+		t.Fatalf("widening should not be reported as narrowing")
+	}
+}
+
+func TestReadNoConversion(t *testing.T) {
+	type P struct {
+		X float64
+	}
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		ptr  interface{}
+	}{
+		{"f8-string", []float64{1, 2}, &[]string{}},
+		{"i8-string", []int64{65, 66}, &[]string{}},
+		{"f8-string-array", []float64{1, 2}, &[2]string{}},
+		{"f8-struct", []float64{1, 2}, &[]P{}},
+		{"c16-struct", []complex128{1, 2}, &[]P{}},
+		{"S-struct", [][]byte{[]byte("a")}, &[]P{}},
+		{"M8-string", []time.Time{time.Unix(0, 0)}, &[]string{}},
+		{"f8-time", []float64{1, 2}, &[]time.Time{}},
+		{"f8-ptr", 1.0, new(*float64)},
+		{"f8-uintptr", 1.0, new(uintptr)},
+		{"f8-func", 1.0, new(func())},
+		{"f8-unsafe-ptr", 1.0, new(unsafe.Pointer)},
+		{"f8-map", 1.0, new(map[int]float64)},
+		{"f8-chan", 1.0, new(chan float64)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, tc.val, WithByteStrings(0))
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			err = Read(buf, tc.ptr)
+			var merr *MismatchError
+			if !errors.As(err, &merr) {
+				t.Fatalf("invalid error: got=%v, want=%T", err, merr)
+			}
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+			}
+		})
+	}
+}
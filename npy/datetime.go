@@ -0,0 +1,212 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	reDatetime = regexp.MustCompile(`^[<>|=]?([Mm])8\[(\w+)\]$`)
+)
+
+// nat is the value of the NumPy "not a time" datetime64 and timedelta64
+// sentinel.
+const nat = math.MinInt64
+
+// timeUnits holds the length of the fixed-length datetime64 and
+// timedelta64 units.
+// Years ('Y') and months ('M') have no fixed length.
+var timeUnits = map[string]time.Duration{
+	"W":  7 * 24 * time.Hour,
+	"D":  24 * time.Hour,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// isTimeUnit returns whether unit is a datetime64 unit supported by the
+// package.
+func isTimeUnit(unit string) bool {
+	_, ok := timeUnits[unit]
+	return ok || unit == "Y" || unit == "M"
+}
+
+// decodeTime returns the time v units after the Unix epoch, in UTC.
+// NaT is decoded as the zero time.Time.
+func decodeTime(v int64, unit string) (time.Time, error) {
+	if v == nat {
+		return time.Time{}, nil
+	}
+	switch unit {
+	case "Y":
+		return time.Date(1970+int(v), time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	case "M":
+		return time.Date(1970, time.January+time.Month(v), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	d, ok := timeUnits[unit]
+	if !ok {
+		return time.Time{}, fmt.Errorf("npy: datetime64 unit %q not supported: %w", unit, ErrInvalidType)
+	}
+	if d < time.Second {
+		per := int64(time.Second / d)
+		return time.Unix(v/per, (v%per)*int64(d)).UTC(), nil
+	}
+	secs := int64(d / time.Second)
+	if v > math.MaxInt64/secs || v < math.MinInt64/secs {
+		return time.Time{}, fmt.Errorf("npy: datetime64 value %d[%s] out of range: %w", v, unit, ErrOutOfRange)
+	}
+	return time.Unix(v*secs, 0).UTC(), nil
+}
+
+// decodeDuration returns the duration of v units.
+// NaT is decoded as the minimum time.Duration.
+func decodeDuration(v int64, unit string) (time.Duration, error) {
+	if v == nat {
+		return math.MinInt64, nil
+	}
+	d, ok := timeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("npy: timedelta64 unit %q can not be represented as a time.Duration: %w", unit, ErrTypeMismatch)
+	}
+	if v > int64(math.MaxInt64/d) || v < int64(math.MinInt64/d) {
+		return 0, fmt.Errorf("npy: timedelta64 value %d[%s] out of range: %w", v, unit, ErrOutOfRange)
+	}
+	return time.Duration(v) * d, nil
+}
+
+// encodeTime returns the number of units elapsed between the Unix epoch
+// and t, rounded down as NumPy does when converting to a coarser unit.
+// The zero time.Time is encoded as NaT.
+func encodeTime(t time.Time, unit string) (int64, error) {
+	if t.IsZero() {
+		return nat, nil
+	}
+	switch unit {
+	case "Y":
+		return int64(t.UTC().Year() - 1970), nil
+	case "M":
+		t = t.UTC()
+		return int64(t.Year()-1970)*12 + int64(t.Month()-time.January), nil
+	}
+
+	d, ok := timeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("npy: datetime64 unit %q not supported: %w", unit, ErrInvalidType)
+	}
+	sec := t.Unix()
+	if d >= time.Second {
+		secs := int64(d / time.Second)
+		v := sec / secs
+		if sec%secs < 0 {
+			v--
+		}
+		return v, nil
+	}
+
+	per := int64(time.Second / d)
+	if minSec, maxSec := math.MinInt64/per, math.MaxInt64/per-1; sec < minSec || sec > maxSec {
+		return 0, fmt.Errorf("npy: time %v out of datetime64[%s] range: %w", t, unit, ErrOutOfRange)
+	}
+	return sec*per + int64(t.Nanosecond())/int64(d), nil
+}
+
+// encodeDuration returns the number of units of d, rounded down as NumPy
+// does when converting to a coarser unit.
+// The minimum time.Duration is encoded as NaT.
+func encodeDuration(d time.Duration, unit string) (int64, error) {
+	if d == math.MinInt64 {
+		return nat, nil
+	}
+	u, ok := timeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("npy: timedelta64 unit %q can not be represented as a time.Duration: %w", unit, ErrInvalidType)
+	}
+	v := int64(d / u)
+	if d%u < 0 {
+		v--
+	}
+	return v, nil
+}
+
+func (r *Reader) readTimes(dst []time.Time, dt dType) error {
+	var buf [8]byte
+	for i := range dst {
+		_, err := r.read(buf[:])
+		if err != nil && err != io.EOF {
+			r.err = err
+			return r.err
+		}
+		dst[i], err = decodeTime(int64(dt.order.Uint64(buf[:])), dt.unit)
+		if err != nil {
+			return err
+		}
+	}
+	return r.err
+}
+
+func (r *Reader) readDurations(dst []time.Duration, dt dType) error {
+	var buf [8]byte
+	for i := range dst {
+		_, err := r.read(buf[:])
+		if err != nil && err != io.EOF {
+			r.err = err
+			return r.err
+		}
+		dst[i], err = decodeDuration(int64(dt.order.Uint64(buf[:])), dt.unit)
+		if err != nil {
+			return err
+		}
+	}
+	return r.err
+}
+
+// writeTimes writes rv, a time.Time, or a slice or an array of time.Time
+// values, as datetime64 data in the unit of dt.
+func writeTimes(w io.Writer, rv reflect.Value, dt dType) error {
+	if rv.Type() == timeType {
+		rv = reflect.ValueOf([]time.Time{rv.Interface().(time.Time)})
+	}
+	buf := make([]byte, 8*rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		v, err := encodeTime(rv.Index(i).Interface().(time.Time), dt.unit)
+		if err != nil {
+			return err
+		}
+		dt.order.PutUint64(buf[8*i:], uint64(v))
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// writeDurations writes rv, a time.Duration, or a slice or an array of
+// time.Duration values, as timedelta64 data in the unit of dt.
+func writeDurations(w io.Writer, rv reflect.Value, dt dType) error {
+	if rv.Type() == durationType {
+		rv = reflect.ValueOf([]time.Duration{time.Duration(rv.Int())})
+	}
+	buf := make([]byte, 8*rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		v, err := encodeDuration(time.Duration(rv.Index(i).Int()), dt.unit)
+		if err != nil {
+			return err
+		}
+		dt.order.PutUint64(buf[8*i:], uint64(v))
+	}
+	_, err := w.Write(buf)
+	return err
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadDatetime(t *testing.T) {
+	date := func(y int, m time.Month, d, hh, mm, ss, ns int) time.Time {
+		return time.Date(y, m, d, hh, mm, ss, ns, time.UTC)
+	}
+
+	for _, tc := range []struct {
+		fname string
+		want  interface{}
+	}{
+		{
+			fname: "../testdata/data_datetime64_ns.npy",
+			want: []time.Time{
+				date(1970, 1, 1, 0, 0, 0, 0),
+				date(2021, 3, 4, 5, 6, 7, 123456789),
+				{},
+				date(1900, 1, 1, 0, 0, 0, 0),
+			},
+		},
+		{
+			fname: "../testdata/data_datetime64_D.npy",
+			want: []time.Time{
+				date(1970, 1, 1, 0, 0, 0, 0),
+				date(2000, 2, 29, 0, 0, 0, 0),
+				date(1969, 12, 31, 0, 0, 0, 0),
+				{},
+			},
+		},
+		{
+			fname: "../testdata/data_timedelta64_ms.npy",
+			want: []time.Duration{
+				0, 1500 * time.Millisecond, -250 * time.Millisecond, math.MinInt64,
+			},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = Read(f, got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadDatetimeUnits(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		unit  string
+		want  []int64
+	}{
+		{"../testdata/data_datetime64_ns.npy", "ns", []int64{0, 1614834367123456789, nat, -2208988800000000000}},
+		{"../testdata/data_datetime64_D.npy", "D", []int64{0, 11016, -1, nat}},
+		{"../testdata/data_timedelta64_ms.npy", "ms", []int64{0, 1500, -250, nat}},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			var got []int64
+			hdr, err := ReadWithHeader(f, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got, want := hdr.TimeUnit(), tc.unit; got != want {
+				t.Fatalf("invalid time unit: got=%q, want=%q", got, want)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+
+	var hdr Header
+	hdr.Descr.Type = "<i8"
+	if got := hdr.TimeUnit(); got != "" {
+		t.Fatalf("invalid time unit for %q: got=%q, want=%q", hdr.Descr.Type, got, "")
+	}
+}
+
+func TestReadDatetimeInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		descr string
+		val   int64
+		ptr   interface{}
+		err   error
+	}{
+		{"time-as-duration", "<M8[ns]", 0, new(time.Duration), ErrTypeMismatch},
+		{"duration-as-time", "<m8[ns]", 0, new(time.Time), ErrTypeMismatch},
+		{"duration-months", "<m8[M]", 1, new(time.Duration), ErrTypeMismatch},
+		{"duration-overflow", "<m8[D]", 1 << 40, new(time.Duration), ErrOutOfRange},
+		{"time-unit", "<M8[ps]", 1, new(time.Time), ErrInvalidType},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWithDescr(buf, tc.val, tc.descr, nil)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			err = Read(buf, tc.ptr)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWriteDatetime(t *testing.T) {
+	times := []time.Time{
+		time.Date(2021, 3, 4, 5, 6, 7, 123456789, time.UTC),
+		{},
+		time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	durations := []time.Duration{0, -time.Hour, math.MinInt64}
+
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		descr string
+		shape []int
+		want  interface{} // value read back, if different from val
+	}{
+		{"time", times[0], "<M8[ns]", nil, nil},
+		{"times", times, "<M8[ns]", []int{3}, nil},
+		{"time-array", [3]time.Time{times[0], times[1], times[2]}, "<M8[ns]", []int{3}, times},
+		{"duration", durations[1], "<m8[ns]", nil, nil},
+		{"durations", durations, "<m8[ns]", []int{3}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Type, tc.descr; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+			if got, want := r.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+
+			want := tc.val
+			if tc.want != nil {
+				want = tc.want
+			}
+			got := reflect.New(reflect.TypeOf(want))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := got.Elem().Interface(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+
+	err := Write(new(bytes.Buffer), time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrOutOfRange)
+	}
+}
+
+func TestWriteDatetimeUnit(t *testing.T) {
+	times := []time.Time{
+		time.Date(2021, 3, 4, 5, 6, 7, 123456789, time.UTC),
+		{},
+		time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC),
+	}
+
+	for _, tc := range []struct {
+		unit string
+		want []int64
+		back time.Time // first time read back
+	}{
+		{"Y", []int64{51, nat, -1}, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"M", []int64{614, nat, -1}, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"W", []int64{2670, nat, -1}, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"D", []int64{18690, nat, -1}, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"s", []int64{1614834367, nat, -1}, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)},
+		{"ms", []int64{1614834367123, nat, -500}, time.Date(2021, 3, 4, 5, 6, 7, 123000000, time.UTC)},
+		{"ns", []int64{1614834367123456789, nat, -500000000}, times[0]},
+	} {
+		t.Run(tc.unit, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, times, WithTimeUnit(tc.unit))
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+			raw := buf.Bytes()
+
+			r, err := NewReader(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Type, "<M8["+tc.unit+"]"; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+
+			data := raw[len(raw)-8*len(tc.want):]
+			for i, want := range tc.want {
+				if got := int64(binary.LittleEndian.Uint64(data[8*i:])); got != want {
+					t.Fatalf("invalid element #%d: got=%d, want=%d", i, got, want)
+				}
+			}
+
+			var got []time.Time
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !got[0].Equal(tc.back) || !got[1].IsZero() {
+				t.Fatalf("invalid data: got=%v, want=[%v %v ...]", got, tc.back, time.Time{})
+			}
+		})
+	}
+
+	err := WriteWith(new(bytes.Buffer), times, WithTimeUnit("as"))
+	if !errors.Is(err, ErrInvalidType) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidType)
+	}
+}
+
+func TestWriteTimedeltaUnit(t *testing.T) {
+	durations := []time.Duration{0, 1500 * time.Millisecond, -250 * time.Millisecond, math.MinInt64}
+
+	for _, tc := range []struct {
+		unit string
+		want []int64
+	}{
+		{"D", []int64{0, 0, -1, nat}},
+		{"s", []int64{0, 1, -1, nat}},
+		{"ms", []int64{0, 1500, -250, nat}},
+		{"us", []int64{0, 1500000, -250000, nat}},
+	} {
+		t.Run(tc.unit, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, durations, WithTimeUnit(tc.unit))
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			var got []int64
+			hdr, err := ReadWithHeader(buf, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got, want := hdr.Descr.Type, "<m8["+tc.unit+"]"; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("golden", func(t *testing.T) {
+		want, err := os.ReadFile("../testdata/data_timedelta64_ms.npy")
+		if err != nil {
+			t.Fatalf("could not read file: %+v", err)
+		}
+		buf := new(bytes.Buffer)
+		err = WriteWith(buf, durations, WithTimeUnit("ms"))
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+		if got := buf.Bytes(); !bytes.Equal(got, want) {
+			t.Fatalf("invalid file content:\ngot= %q\nwant=%q", got, want)
+		}
+	})
+
+	err := WriteWith(new(bytes.Buffer), durations, WithTimeUnit("M"))
+	if !errors.Is(err, ErrInvalidType) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidType)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// ErrorKind classifies the errors returned by the npy package.
+type ErrorKind int
+
+const (
+	Other           ErrorKind = iota // unclassified error, e.g. an I/O error
+	InvalidFormat                    // malformed or unrecognized NumPy file
+	TypeMismatch                     // on-disk and Go types do not match
+	InvalidDims                      // invalid or incompatible array dimensions
+	Truncated                        // array data shorter than announced by the header
+	Unsupported                      // data type not supported by the package
+	TooLarge                         // array too large to be loaded in memory
+	OutOfRange                       // value not representable in the requested data type
+	NotContiguous                    // value not laid out contiguously in memory
+	InvalidArgument                  // invalid argument, e.g. a nil or non-pointer value
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case Other:
+		return "other"
+	case InvalidFormat:
+		return "invalid format"
+	case TypeMismatch:
+		return "type mismatch"
+	case InvalidDims:
+		return "invalid dimensions"
+	case Truncated:
+		return "truncated"
+	case Unsupported:
+		return "unsupported"
+	case TooLarge:
+		return "too large"
+	case OutOfRange:
+		return "out of range"
+	case NotContiguous:
+		return "not contiguous"
+	case InvalidArgument:
+		return "invalid argument"
+	}
+	return "ErrorKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Error is the type of the errors returned by NewReader, Reader.Read,
+// WriteWith and WriteAs.
+//
+// Error wraps the underlying error, so that errors.Is keeps reporting the
+// sentinel errors of the package (ErrTypeMismatch, ...).
+type Error struct {
+	Kind ErrorKind // class of the error
+	Op   string    // operation that failed: "header", "read" or "write"
+	Err  error     // underlying error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel error of the kind of e.
+func (e *Error) Is(target error) bool {
+	var sentinel error
+	switch e.Kind {
+	case InvalidFormat:
+		sentinel = ErrInvalidNumPyFormat
+	case TypeMismatch:
+		sentinel = ErrTypeMismatch
+	case Unsupported:
+		sentinel = ErrInvalidType
+	case TooLarge:
+		sentinel = ErrTooLargeForMemory
+	case OutOfRange:
+		sentinel = ErrOutOfRange
+	case NotContiguous:
+		sentinel = ErrNotContiguous
+	default:
+		return false
+	}
+	return target == sentinel
+}
+
+// HeaderError describes a malformed NumPy header.
+//
+// HeaderError values are reported by errors.Is as ErrInvalidNumPyFormat.
+type HeaderError struct {
+	Offset int64  // offset of the error from the start of the file, in bytes
+	Header string // fragment of the header around the error
+	Msg    string // description of the error, e.g. "unterminated dict"
+	Err    error  // underlying error, if any
+}
+
+func (e *HeaderError) Error() string {
+	msg := fmt.Sprintf("npy: invalid header at offset %d: %s", e.Offset, e.Msg)
+	if e.Header != "" {
+		msg += fmt.Sprintf(" (near %q)", e.Header)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *HeaderError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInvalidNumPyFormat.
+func (e *HeaderError) Is(target error) bool {
+	return target == ErrInvalidNumPyFormat
+}
+
+// MismatchError describes an array whose on-disk data type can not be
+// read into the Go destination.
+//
+// MismatchError values are reported by errors.Is as ErrTypeMismatch.
+// Reading with WithConvert may succeed where MismatchError is returned.
+type MismatchError struct {
+	Descr string       // on-disk array-protocol type string, e.g. "<f4"
+	Shape []int        // on-disk shape of the array
+	Count int          // number of elements of the array
+	Type  reflect.Type // type of the Go destination, e.g. []int64
+	Err   error        // details of the mismatch, if any, e.g. for struct fields
+}
+
+func (e *MismatchError) Error() string {
+	elems := "elements"
+	if e.Count == 1 {
+		elems = "element"
+	}
+	msg := fmt.Sprintf(
+		"npy: can not read %s shape %v (%d %s) into %v",
+		e.Descr, e.Shape, e.Count, elems, e.Type,
+	)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *MismatchError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTypeMismatch.
+func (e *MismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// fragmentLen is the number of bytes of context HeaderError values hold on
+// each side of the error.
+const fragmentLen = 16
+
+// fragment returns the part of buf around the offset pos.
+func fragment(buf []byte, pos int) string {
+	beg := max(0, pos-fragmentLen)
+	end := min(len(buf), pos+fragmentLen)
+	if beg > end {
+		return ""
+	}
+	return string(buf[beg:end])
+}
+
+// wrapErr wraps err into an *Error for the operation op.
+// nil errors, io.EOF and *Error values are returned unchanged.
+func wrapErr(op string, err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	return &Error{Kind: kindOf(err), Op: op, Err: err}
+}
+
+func kindOf(err error) ErrorKind {
+	switch {
+	case errors.Is(err, ErrInvalidNumPyFormat):
+		return InvalidFormat
+	case errors.Is(err, ErrTypeMismatch), errors.Is(err, errNoConv):
+		return TypeMismatch
+	case errors.Is(err, errDims):
+		return InvalidDims
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return Truncated
+	case errors.Is(err, ErrInvalidType):
+		return Unsupported
+	case errors.Is(err, ErrTooLargeForMemory):
+		return TooLarge
+	case errors.Is(err, ErrOutOfRange):
+		return OutOfRange
+	case errors.Is(err, ErrNotContiguous):
+		return NotContiguous
+	case errors.Is(err, errNilPtr), errors.Is(err, errNotPtr):
+		return InvalidArgument
+	}
+	return Other
+}
@@ -0,0 +1,363 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestErrorKind(t *testing.T) {
+	write := func(v interface{}) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		err := Write(buf, v)
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+		return buf
+	}
+	read := func(r io.Reader, ptr interface{}, opts ...ReadOption) error {
+		rr, err := NewReader(r, opts...)
+		if err != nil {
+			return err
+		}
+		return rr.Read(ptr)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		err      func() error
+		op       string
+		kind     ErrorKind
+		sentinel error
+	}{
+		{
+			name: "invalid-format",
+			err: func() error {
+				return read(bytes.NewReader([]byte("not a numpy file")), new([]float64))
+			},
+			op:       "header",
+			kind:     InvalidFormat,
+			sentinel: ErrInvalidNumPyFormat,
+		},
+		{
+			name: "type-mismatch",
+			err: func() error {
+				return read(write([]float64{1, 2}), new([]int32))
+			},
+			op:       "read",
+			kind:     TypeMismatch,
+			sentinel: ErrTypeMismatch,
+		},
+		{
+			name: "no-conversion",
+			err: func() error {
+				return read(write([]float64{1, 2}), new(bool), WithConvert())
+			},
+			op:       "read",
+			kind:     TypeMismatch,
+			sentinel: ErrTypeMismatch,
+		},
+		{
+			name: "truncated",
+			err: func() error {
+				buf := write([]float64{1, 2})
+				buf.Truncate(buf.Len() - 4)
+				return read(buf, new([]float64))
+			},
+			op:       "read",
+			kind:     Truncated,
+			sentinel: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "unsupported",
+			err: func() error {
+				return Write(new(bytes.Buffer), []int{1, 2})
+			},
+			op:       "write",
+			kind:     Unsupported,
+			sentinel: ErrInvalidType,
+		},
+		{
+			name: "dims",
+			err: func() error {
+				return Write(new(bytes.Buffer), [][]float64{{1, 2}, {3}})
+			},
+			op:   "write",
+			kind: InvalidDims,
+		},
+		{
+			name: "too-large",
+			err: func() error {
+				return read(write([]float64{1, 2}), new([]float64), WithMaxBytes(8))
+			},
+			op:       "read",
+			kind:     TooLarge,
+			sentinel: ErrTooLargeForMemory,
+		},
+		{
+			name: "out-of-range",
+			err: func() error {
+				return WriteAs(new(bytes.Buffer), []int64{1 << 40}, "<i4")
+			},
+			op:       "write",
+			kind:     OutOfRange,
+			sentinel: ErrOutOfRange,
+		},
+		{
+			name: "nil-pointer",
+			err: func() error {
+				return read(write([]float64{1, 2}), (*[]float64)(nil))
+			},
+			op:   "read",
+			kind: InvalidArgument,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err()
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("error %v (%T) is not an *Error", err, err)
+			}
+			if got, want := e.Kind, tc.kind; got != want {
+				t.Fatalf("invalid kind: got=%v, want=%v (err=%v)", got, want, err)
+			}
+			if got, want := e.Op, tc.op; got != want {
+				t.Fatalf("invalid op: got=%q, want=%q", got, want)
+			}
+			if tc.sentinel != nil && !errors.Is(err, tc.sentinel) {
+				t.Fatalf("error %v does not match %v", err, tc.sentinel)
+			}
+		})
+	}
+}
+
+func TestErrorEOF(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, []float64{1})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+
+	r, err := NewReader(buf)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+
+	var v float64
+	err = r.Read(&v)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+
+	err = r.Read(&v)
+	if err != io.EOF {
+		t.Fatalf("invalid error: got=%v, want=%v", err, io.EOF)
+	}
+}
+
+func TestHeaderError(t *testing.T) {
+	raw := func(major byte, hdr string) []byte {
+		buf := new(bytes.Buffer)
+		buf.Write(Magic[:])
+		buf.Write([]byte{major, 0})
+		buf.Write([]byte{byte(len(hdr)), byte(len(hdr) >> 8)})
+		buf.WriteString(hdr)
+		return buf.Bytes()
+	}
+
+	for _, tc := range []struct {
+		name   string
+		raw    []byte
+		offset int64
+		msg    string
+		err    error
+	}{
+		{
+			name:   "unterminated-dict",
+			raw:    raw(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (2,), \n"),
+			offset: 10 + 56,
+			msg:    "unterminated dict",
+		},
+		{
+			name:   "missing-shape",
+			raw:    raw(1, "{'descr': '<f8', 'fortran_order': False, }\n"),
+			offset: 10,
+			msg:    `missing "shape" key`,
+		},
+		{
+			name:   "invalid-shape",
+			raw:    raw(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (-1,), }\n"),
+			offset: 10,
+			msg:    "invalid 'shape' value ((-1,))",
+		},
+		{
+			name:   "no-newline",
+			raw:    raw(1, "{'descr': '<f8', 'shape': (2,) 'fortran_order': False}"),
+			offset: 10 + 31,
+			msg:    `expected ',' or '}', got '\''`,
+		},
+		{
+			name:   "version",
+			raw:    raw(4, "{}\n"),
+			offset: 6,
+			msg:    "invalid major version number (4)",
+		},
+		{
+			name:   "truncated",
+			raw:    raw(1, "{'descr': '<f8', 'fortran_order': False, 'shape': (2,), }\n")[:30],
+			offset: 30,
+			msg:    "truncated header (20 bytes, want 58)",
+			err:    io.ErrUnexpectedEOF,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReader(bytes.NewReader(tc.raw))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !errors.Is(err, ErrInvalidNumPyFormat) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidNumPyFormat)
+			}
+
+			var herr *HeaderError
+			if !errors.As(err, &herr) {
+				t.Fatalf("invalid error type: %T", err)
+			}
+			if got, want := herr.Offset, tc.offset; got != want {
+				t.Fatalf("invalid offset: got=%d, want=%d", got, want)
+			}
+			if got, want := herr.Msg, tc.msg; got != want {
+				t.Fatalf("invalid message: got=%q, want=%q", got, want)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestMismatchError(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, [][]float32{{1, 2, 3}, {4, 5, 6}})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	for _, tc := range []struct {
+		name string
+		ptr  interface{}
+		want MismatchError
+		msg  string
+	}{
+		{
+			name: "slice",
+			ptr:  new([]int64),
+			want: MismatchError{Descr: "<f4", Shape: []int{2, 3}, Count: 6, Type: reflect.TypeOf([]int64(nil))},
+			msg:  "npy: can not read <f4 shape [2 3] (6 elements) into []int64",
+		},
+		{
+			name: "scalar",
+			ptr:  new(bool),
+			want: MismatchError{Descr: "<f4", Shape: []int{2, 3}, Count: 6, Type: reflect.TypeOf(false)},
+			msg:  "npy: can not read <f4 shape [2 3] (6 elements) into bool",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(bytes.NewReader(raw), tc.ptr)
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+			}
+			var merr *MismatchError
+			if !errors.As(err, &merr) {
+				t.Fatalf("invalid error type: %T", err)
+			}
+			if !reflect.DeepEqual(*merr, tc.want) {
+				t.Fatalf("invalid mismatch:\ngot= %+v\nwant=%+v", *merr, tc.want)
+			}
+			if got, want := err.Error(), tc.msg; got != want {
+				t.Fatalf("invalid message:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestMismatchErrorWrapped(t *testing.T) {
+	type point struct {
+		X float64
+		Y int32
+	}
+
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		opts  []ReadOption
+		ptr   interface{}
+		descr string
+		count int
+	}{
+		{
+			name:  "records-into-floats",
+			val:   []point{{1, 2}, {3, 4}},
+			ptr:   new([]float64),
+			descr: "[('X', '<f8'), ('Y', '<i4')]",
+			count: 2,
+		},
+		{
+			name: "record-field",
+			val:  []point{{1, 2}},
+			ptr: new([]struct {
+				X string `npy:"X"`
+			}),
+			descr: "[('X', '<f8'), ('Y', '<i4')]",
+			count: 1,
+		},
+		{
+			name:  "dtype-hint",
+			val:   []float64{1, 2, 3},
+			opts:  []ReadOption{WithDtypeHint("<i4")},
+			ptr:   new(interface{}),
+			descr: "<f8",
+			count: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			err = Read(buf, tc.ptr, tc.opts...)
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+			}
+			var merr *MismatchError
+			if !errors.As(err, &merr) {
+				t.Fatalf("invalid error type: %T", err)
+			}
+			if got, want := merr.Descr, tc.descr; got != want {
+				t.Fatalf("invalid descr: got=%q, want=%q", got, want)
+			}
+			if got, want := merr.Count, tc.count; got != want {
+				t.Fatalf("invalid count: got=%d, want=%d", got, want)
+			}
+			if got, want := merr.Type, reflect.TypeOf(tc.ptr).Elem(); got != want {
+				t.Fatalf("invalid type: got=%v, want=%v", got, want)
+			}
+			if merr.Err == nil {
+				t.Fatalf("missing mismatch details")
+			}
+		})
+	}
+}
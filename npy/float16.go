@@ -0,0 +1,25 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"encoding/binary"
+	"reflect"
+
+	"github.com/sbinet/npyio/float16"
+)
+
+var float16Type = reflect.TypeOf(float16.Num(0))
+
+// Float16ToFloat32 decodes the little-endian half-precision values of raw,
+// as stored in the data section of a '<f2' array, into float32 values.
+// A trailing odd byte is ignored.
+func Float16ToFloat32(raw []byte) []float32 {
+	dst := make([]float32, len(raw)/2)
+	for i := range dst {
+		dst[i] = float16.Num(binary.LittleEndian.Uint16(raw[2*i:])).Float32()
+	}
+	return dst
+}
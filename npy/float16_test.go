@@ -0,0 +1,212 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sbinet/npyio/float16"
+)
+
+func TestReadFloat16(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/data_float16.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	var (
+		inf  = float32(math.Inf(+1))
+		want = []float32{0, 0, 1, -2, 65504, inf, -inf, float32(math.NaN()), 0x1p-24, 0x1p-14, 0.33325195}
+		bits = []float16.Num{0x0000, 0x8000, 0x3c00, 0xc000, 0x7bff, 0x7c00, 0xfc00, 0x7e00, 0x0001, 0x0400, 0x3555}
+	)
+
+	equal := func(got, want []float32) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			switch {
+			case math.IsNaN(float64(want[i])):
+				if !math.IsNaN(float64(got[i])) {
+					return false
+				}
+			case got[i] != want[i]:
+				return false
+			}
+		}
+		return true
+	}
+
+	r, err := NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	if got, want := r.Header.Kind(), Float16; got != want {
+		t.Fatalf("invalid kind: got=%v, want=%v", got, want)
+	}
+
+	var f32 []float32
+	err = r.Read(&f32)
+	if err != nil {
+		t.Fatalf("could not read float32 data: %+v", err)
+	}
+	if !equal(f32, want) {
+		t.Fatalf("invalid float32 data:\ngot= %v\nwant=%v", f32, want)
+	}
+
+	var f16 []float16.Num
+	err = Read(bytes.NewReader(raw), &f16)
+	if err != nil {
+		t.Fatalf("could not read float16 data: %+v", err)
+	}
+	if !reflect.DeepEqual(f16, bits) {
+		t.Fatalf("invalid float16 data:\ngot= %v\nwant=%v", f16, bits)
+	}
+
+	if got := Float16ToFloat32(raw[r.data:]); !equal(got, want) {
+		t.Fatalf("invalid raw float16 data:\ngot= %v\nwant=%v", got, want)
+	}
+
+	var scalar float32
+	err = Read(bytes.NewReader(raw), &scalar)
+	if err != nil {
+		t.Fatalf("could not read float32 scalar: %+v", err)
+	}
+
+	var f64 []float64
+	err = Read(bytes.NewReader(raw), &f64)
+	if err != nil {
+		t.Fatalf("could not read float64 data: %+v", err)
+	}
+	if got := float32s(f64); !equal(got, want) {
+		t.Fatalf("invalid float64 data:\ngot= %v\nwant=%v", got, want)
+	}
+
+	var a64 [11]float64
+	err = Read(bytes.NewReader(raw), &a64)
+	if err != nil {
+		t.Fatalf("could not read float64 array: %+v", err)
+	}
+	if got := float32s(a64[:]); !equal(got, want) {
+		t.Fatalf("invalid float64 array:\ngot= %v\nwant=%v", got, want)
+	}
+
+	var a16 [11]float16.Num
+	err = Read(bytes.NewReader(raw), &a16)
+	if err != nil {
+		t.Fatalf("could not read float16 array: %+v", err)
+	}
+	if !reflect.DeepEqual(a16[:], bits) {
+		t.Fatalf("invalid float16 array:\ngot= %v\nwant=%v", a16, bits)
+	}
+
+	var u16 []uint16
+	err = Read(bytes.NewReader(raw), &u16)
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+	}
+
+	buf := new(bytes.Buffer)
+	err = Write(buf, bits)
+	if err != nil {
+		t.Fatalf("could not write float16 data: %+v", err)
+	}
+	w, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	if got, want := w.Header.Descr.Type, "<f2"; got != want {
+		t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+	}
+	if got, want := buf.Bytes()[w.data:], raw[r.data:]; !bytes.Equal(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+// float32s returns the values of vs as float32 values.
+func float32s(vs []float64) []float32 {
+	out := make([]float32, len(vs))
+	for i, v := range vs {
+		out[i] = float32(v)
+	}
+	return out
+}
+
+func TestReadFloat16BigEndian(t *testing.T) {
+	src := []float64{1, -2, 0.5, 65504}
+	buf := new(bytes.Buffer)
+	err := WriteAs(buf, src, ">f2")
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	var f64 []float64
+	err = Read(bytes.NewReader(raw), &f64)
+	if err != nil {
+		t.Fatalf("could not read float64 data: %+v", err)
+	}
+	if !reflect.DeepEqual(f64, src) {
+		t.Fatalf("invalid float64 data:\ngot= %v\nwant=%v", f64, src)
+	}
+
+	var f32 []float32
+	err = Read(bytes.NewReader(raw), &f32)
+	if err != nil {
+		t.Fatalf("could not read float32 data: %+v", err)
+	}
+	if want := float32s(src); !reflect.DeepEqual(f32, want) {
+		t.Fatalf("invalid float32 data:\ngot= %v\nwant=%v", f32, want)
+	}
+
+	var a32 [4]float32
+	err = Read(bytes.NewReader(raw), &a32)
+	if err != nil {
+		t.Fatalf("could not read float32 array: %+v", err)
+	}
+	if want := float32s(src); !reflect.DeepEqual(a32[:], want) {
+		t.Fatalf("invalid float32 array:\ngot= %v\nwant=%v", a32, want)
+	}
+}
+
+func TestWriteAsFloat16(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := WriteAs(buf, []float64{1, -2, 1.0 / 3, 1e-8}, "<f2")
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	var got []float16.Num
+	err = Read(buf, &got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if want := []float16.Num{0x3c00, 0xc000, 0x3555, 0x0000}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+
+	err = WriteAs(new(bytes.Buffer), []float64{1e5}, "<f2")
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrOutOfRange)
+	}
+
+	buf.Reset()
+	err = WriteAs(buf, []float64{1e5, -1e5}, "<f2", WithCast(CastClamp))
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	got = nil
+	err = Read(buf, &got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if want := []float16.Num{0x7bff, 0xfbff}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+}
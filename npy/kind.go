@@ -0,0 +1,158 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of the elements of a NumPy array, as described by its
+// data type descriptor, independently of the byte order.
+type Kind int
+
+const (
+	Invalid Kind = iota // unknown or malformed data type descriptor
+	Bool
+	Int8
+	Int16
+	Int32
+	Int64
+	Uint8
+	Uint16
+	Uint32
+	Uint64
+	Float16
+	Float32
+	Float64
+	Complex64
+	Complex128
+	Bytes     // fixed-size byte strings ('S')
+	Unicode   // fixed-size unicode strings ('U')
+	Datetime  // datetimes ('M')
+	Timedelta // time deltas ('m')
+	Record    // structured arrays and raw void data ('V')
+	Object    // Python objects ('O')
+)
+
+var kindNames = [...]string{
+	Invalid:    "invalid",
+	Bool:       "bool",
+	Int8:       "int8",
+	Int16:      "int16",
+	Int32:      "int32",
+	Int64:      "int64",
+	Uint8:      "uint8",
+	Uint16:     "uint16",
+	Uint32:     "uint32",
+	Uint64:     "uint64",
+	Float16:    "float16",
+	Float32:    "float32",
+	Float64:    "float64",
+	Complex64:  "complex64",
+	Complex128: "complex128",
+	Bytes:      "bytes",
+	Unicode:    "unicode",
+	Datetime:   "datetime",
+	Timedelta:  "timedelta",
+	Record:     "record",
+	Object:     "object",
+}
+
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Kind returns the kind of the elements of the array described by h.
+// Kind returns Invalid if the data type descriptor is not recognized.
+func (h Header) Kind() Kind {
+	return kindOfDescr(h.Descr.Type)
+}
+
+// kindOfDescr returns the kind of the elements described by the provided
+// data type descriptor, e.g. '<f8' or "[('x', '<f8')]".
+func kindOfDescr(descr string) Kind {
+	if isRecDescr(descr) {
+		return Record
+	}
+	for k, name := range kindNames {
+		if k != int(Invalid) && descr == name {
+			return Kind(k)
+		}
+	}
+
+	m := reItemsize.FindStringSubmatch(descr)
+	if m == nil {
+		if strings.TrimLeft(descr, "<>|=") == "O" {
+			return Object
+		}
+		return Invalid
+	}
+	size, err := strconv.Atoi(m[2])
+	if err != nil {
+		return Invalid
+	}
+
+	switch m[1] {
+	case "b":
+		if size == 1 {
+			return Bool
+		}
+	case "i":
+		switch size {
+		case 1:
+			return Int8
+		case 2:
+			return Int16
+		case 4:
+			return Int32
+		case 8:
+			return Int64
+		}
+	case "u":
+		switch size {
+		case 1:
+			return Uint8
+		case 2:
+			return Uint16
+		case 4:
+			return Uint32
+		case 8:
+			return Uint64
+		}
+	case "f":
+		switch size {
+		case 2:
+			return Float16
+		case 4:
+			return Float32
+		case 8:
+			return Float64
+		}
+	case "c":
+		switch size {
+		case 8:
+			return Complex64
+		case 16:
+			return Complex128
+		}
+	case "S", "a":
+		return Bytes
+	case "U":
+		return Unicode
+	case "M":
+		return Datetime
+	case "m":
+		return Timedelta
+	case "V":
+		return Record
+	case "O":
+		return Object
+	}
+	return Invalid
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"os"
+	"testing"
+)
+
+func TestHeaderKind(t *testing.T) {
+	for _, tc := range []struct {
+		descr string
+		want  Kind
+	}{
+		{"|b1", Bool},
+		{"bool", Bool},
+		{"|i1", Int8},
+		{"<i2", Int16},
+		{">i4", Int32},
+		{"<i8", Int64},
+		{"int64", Int64},
+		{"|u1", Uint8},
+		{"<u2", Uint16},
+		{"<u4", Uint32},
+		{">u8", Uint64},
+		{"<f2", Float16},
+		{"<f4", Float32},
+		{">f8", Float64},
+		{"float64", Float64},
+		{"<c8", Complex64},
+		{"<c16", Complex128},
+		{"|S8", Bytes},
+		{"|a3", Bytes},
+		{"<U3", Unicode},
+		{"<M8[ns]", Datetime},
+		{"<m8[s]", Timedelta},
+		{"|V16", Record},
+		{"[('x', '<f8'), ('y', '<i4')]", Record},
+		{"|O", Object},
+		{"|O8", Object},
+		{"<i3", Invalid},
+		{"<x8", Invalid},
+		{"", Invalid},
+	} {
+		t.Run(tc.descr, func(t *testing.T) {
+			var hdr Header
+			hdr.Descr.Type = tc.descr
+			if got, want := hdr.Kind(), tc.want; got != want {
+				t.Fatalf("invalid kind: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestHeaderKindFile(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		want  Kind
+	}{
+		{"../testdata/data_float64_2x3_corder.npy", Float64},
+		{"../testdata/data_records.npy", Record},
+		{"../testdata/data_unicode_U8.npy", Unicode},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			r, err := NewReader(f)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Kind(), tc.want; got != want {
+				t.Fatalf("invalid kind: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// literal parses the subset of Python literals used in NumPy headers:
+// dicts, lists, tuples, strings, integers, booleans and None.
+//
+// Parsed values are represented as:
+//   - dicts: []item, in the order of appearance,
+//   - lists: []interface{},
+//   - tuples: tuple,
+//   - strings: string,
+//   - integers: int,
+//   - booleans: bool,
+//   - None: nil.
+type literal struct {
+	buf []byte
+	pos int
+}
+
+// tuple is a Python tuple.
+type tuple []interface{}
+
+// item is a key/value pair of a Python dict.
+type item struct {
+	key string
+	val interface{}
+}
+
+// errorf returns a *HeaderError at the current position of the parser.
+// Offsets are relative to the start of the parsed buffer.
+func (p *literal) errorf(format string, args ...interface{}) error {
+	return &HeaderError{
+		Offset: int64(p.pos),
+		Header: fragment(p.buf, p.pos),
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *literal) skip() {
+	for p.pos < len(p.buf) {
+		switch p.buf[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *literal) peek() byte {
+	p.skip()
+	if p.pos >= len(p.buf) {
+		return 0
+	}
+	return p.buf[p.pos]
+}
+
+func (p *literal) expect(c byte) error {
+	if p.peek() != c {
+		if p.pos >= len(p.buf) {
+			return p.errorf("expected %q, got end of header", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.buf[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+func (p *literal) value() (interface{}, error) {
+	switch c := p.peek(); {
+	case c == '{':
+		return p.dict()
+	case c == '[':
+		return p.seq('[', ']')
+	case c == '(':
+		vs, err := p.seq('(', ')')
+		return tuple(vs), err
+	case c == '\'' || c == '"':
+		return p.str()
+	case c == '-' || ('0' <= c && c <= '9'):
+		return p.int()
+	case c == 0:
+		return nil, p.errorf("unexpected end of header")
+	default:
+		beg := p.pos
+		for p.pos < len(p.buf) && isIdent(p.buf[p.pos]) {
+			p.pos++
+		}
+		switch name := string(p.buf[beg:p.pos]); name {
+		case "True":
+			return true, nil
+		case "False":
+			return false, nil
+		case "None":
+			return nil, nil
+		default:
+			p.pos = beg
+			return nil, p.errorf("unexpected character %q", c)
+		}
+	}
+}
+
+func (p *literal) dict() ([]item, error) {
+	err := p.expect('{')
+	if err != nil {
+		return nil, err
+	}
+
+	var items []item
+	for {
+		switch p.peek() {
+		case '}':
+			p.pos++
+			return items, nil
+		case 0:
+			return nil, p.errorf("unterminated dict")
+		}
+
+		if c := p.peek(); c != '\'' && c != '"' {
+			return nil, p.errorf("expected a string key")
+		}
+		key, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expect(':')
+		if err != nil {
+			return nil, err
+		}
+		val, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item{key: key, val: val})
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return items, nil
+		case 0:
+			return nil, p.errorf("unterminated dict")
+		default:
+			return nil, p.errorf("expected ',' or '}', got %q", p.buf[p.pos])
+		}
+	}
+}
+
+func (p *literal) seq(beg, end byte) ([]interface{}, error) {
+	err := p.expect(beg)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := make([]interface{}, 0)
+	for {
+		if p.peek() == end {
+			p.pos++
+			return vs, nil
+		}
+
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		vs = append(vs, v)
+
+		if p.peek() != ',' {
+			err = p.expect(end)
+			if err != nil {
+				return nil, err
+			}
+			return vs, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *literal) str() (string, error) {
+	quote := p.peek()
+	beg := p.pos
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.buf) {
+		c := p.buf[p.pos]
+		p.pos++
+		switch c {
+		case quote:
+			return sb.String(), nil
+		case '\\':
+			if p.pos >= len(p.buf) {
+				break
+			}
+			sb.WriteByte(p.buf[p.pos])
+			p.pos++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	p.pos = beg
+	return "", p.errorf("unterminated string")
+}
+
+func (p *literal) int() (int, error) {
+	beg := p.pos
+	if p.buf[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.buf) && '0' <= p.buf[p.pos] && p.buf[p.pos] <= '9' {
+		p.pos++
+	}
+	v, err := strconv.Atoi(string(p.buf[beg:p.pos]))
+	if err != nil {
+		p.pos = beg
+		return 0, p.errorf("invalid integer: %v", err)
+	}
+	// Python 2 long integers.
+	if p.pos < len(p.buf) && (p.buf[p.pos] == 'L' || p.buf[p.pos] == 'l') {
+		p.pos++
+	}
+	return v, nil
+}
+
+func isIdent(c byte) bool {
+	return c == '_' ||
+		('a' <= c && c <= 'z') ||
+		('A' <= c && c <= 'Z') ||
+		('0' <= c && c <= '9')
+}
+
+// repr returns the Python representation of the provided parsed literal.
+func repr(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case int:
+		return strconv.Itoa(v)
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", `\'`) + "'"
+	case []item:
+		var sb strings.Builder
+		sb.WriteString("{")
+		for i, it := range v {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(repr(it.key) + ": " + repr(it.val))
+		}
+		sb.WriteString("}")
+		return sb.String()
+	case tuple:
+		switch len(v) {
+		case 1:
+			return "(" + repr(v[0]) + ",)"
+		default:
+			return "(" + strings.TrimSuffix(strings.TrimPrefix(repr([]interface{}(v)), "["), "]") + ")"
+		}
+	case []interface{}:
+		var sb strings.Builder
+		sb.WriteString("[")
+		for i, e := range v {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(repr(e))
+		}
+		sb.WriteString("]")
+		return sb.String()
+	}
+	panic(fmt.Errorf("npy: invalid literal type %T", v))
+}
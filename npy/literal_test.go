@@ -0,0 +1,74 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLiteral(t *testing.T) {
+	for _, tc := range []struct {
+		src  string
+		want interface{}
+		repr string
+		err  bool
+	}{
+		{
+			src:  "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }",
+			want: []item{{"descr", "<f8"}, {"fortran_order", false}, {"shape", tuple{2, 3}}},
+			repr: "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3)}",
+		},
+		{
+			src:  `{"descr":"|u1","fortran_order":True,"shape":(6,)}`,
+			want: []item{{"descr", "|u1"}, {"fortran_order", true}, {"shape", tuple{6}}},
+			repr: "{'descr': '|u1', 'fortran_order': True, 'shape': (6,)}",
+		},
+		{
+			src:  "{'shape': (), 'x': None, 'y': -1, 'z': 2L}",
+			want: []item{{"shape", tuple{}}, {"x", nil}, {"y", -1}, {"z", 2}},
+			repr: "{'shape': (), 'x': None, 'y': -1, 'z': 2}",
+		},
+		{
+			src: "{'descr': [('x', '<f8'), ('y', '<i4', (3,))], }",
+			want: []item{{"descr", []interface{}{
+				tuple{"x", "<f8"},
+				tuple{"y", "<i4", tuple{3}},
+			}}},
+			repr: "{'descr': [('x', '<f8'), ('y', '<i4', (3,))]}",
+		},
+		{
+			src:  `{'it\'s': 'ok'}`,
+			want: []item{{"it's", "ok"}},
+			repr: `{'it\'s': 'ok'}`,
+		},
+		{src: "{'descr': '<f8'", err: true},
+		{src: "{'descr': '<f8}", err: true},
+		{src: "{'descr' '<f8'}", err: true},
+		{src: "{descr: '<f8'}", err: true},
+		{src: "{'shape': (2, 3}", err: true},
+		{src: "{'x': Nope}", err: true},
+	} {
+		t.Run(tc.src, func(t *testing.T) {
+			p := literal{buf: []byte(tc.src)}
+			got, err := p.dict()
+			switch {
+			case tc.err && err == nil:
+				t.Fatalf("expected an error, got %v", got)
+			case tc.err:
+				return
+			case err != nil:
+				t.Fatalf("could not parse: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid value:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+			if got, want := repr(got), tc.repr; got != want {
+				t.Fatalf("invalid repr:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
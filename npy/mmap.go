@@ -0,0 +1,186 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"unsafe"
+)
+
+// MappedArray is a NumPy array whose data is memory-mapped from a file.
+//
+// The slices returned by the accessors of a MappedArray alias the mapped
+// memory: they are read-only, and using them after Close has been called
+// is undefined behavior (it may crash the program).
+type MappedArray struct {
+	Header Header
+
+	dt   dType
+	data []byte // array data
+	mmap []byte // mapped file, nil if the file has been read in memory
+}
+
+// OpenMmap memory-maps the NumPy data file at path and parses its header.
+//
+// On platforms without memory mapping, the file is read in memory instead.
+func OpenMmap(path string) (*MappedArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	mm, mapped, err := mmapFile(f, fi.Size())
+	if err != nil {
+		return nil, fmt.Errorf("npy: could not map file %q: %w", path, err)
+	}
+
+	m := &MappedArray{}
+	if mapped {
+		m.mmap = mm
+	}
+	err = m.init(mm)
+	if err != nil {
+		_ = m.Close()
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *MappedArray) init(buf []byte) error {
+	r, err := NewReader(bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+	m.Header = r.Header
+
+	m.dt, err = newDtype(m.Header.Descr.Type)
+	if err != nil {
+		return wrapErr("header", fmt.Errorf("npy: can not map array of dtype %q: %w", m.Header.Descr.Type, ErrInvalidType))
+	}
+	n, err := dataSize(m.Header)
+	if err != nil {
+		return err
+	}
+	if int64(len(buf))-r.data < n {
+		return wrapErr("header", fmt.Errorf("npy: array data of %d bytes exceeds file size: %w", n, io.ErrUnexpectedEOF))
+	}
+	m.data = buf[r.data : r.data+n]
+	return nil
+}
+
+// Close releases the memory mapping.
+// The slices previously returned by the accessors of m must not be used
+// anymore.
+func (m *MappedArray) Close() error {
+	mm := m.mmap
+	m.mmap = nil
+	m.data = nil
+	m.dt = dType{}
+	if mm == nil {
+		return nil
+	}
+	return munmap(mm)
+}
+
+// Bytes returns the raw array data.
+func (m *MappedArray) Bytes() []byte { return m.data }
+
+// Bools returns the array data as a slice of bools.
+//
+// Go bools can only hold the bytes 0 and 1: as with NumPy, arrays holding
+// other non-zero bytes are read as true, in a copy of the array data that
+// does not alias the mapped memory.
+func (m *MappedArray) Bools() ([]bool, error) {
+	vs, err := mappedSlice[bool](m)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range m.data {
+		if b > 1 {
+			vs = make([]bool, len(m.data))
+			for i, b := range m.data {
+				vs[i] = b != 0
+			}
+			return vs, nil
+		}
+	}
+	return vs, nil
+}
+
+// Int8s returns the array data as a slice of int8 values.
+func (m *MappedArray) Int8s() ([]int8, error) { return mappedSlice[int8](m) }
+
+// Int16s returns the array data as a slice of int16 values.
+func (m *MappedArray) Int16s() ([]int16, error) { return mappedSlice[int16](m) }
+
+// Int32s returns the array data as a slice of int32 values.
+func (m *MappedArray) Int32s() ([]int32, error) { return mappedSlice[int32](m) }
+
+// Int64s returns the array data as a slice of int64 values.
+func (m *MappedArray) Int64s() ([]int64, error) { return mappedSlice[int64](m) }
+
+// Uint8s returns the array data as a slice of uint8 values.
+func (m *MappedArray) Uint8s() ([]uint8, error) { return mappedSlice[uint8](m) }
+
+// Uint16s returns the array data as a slice of uint16 values.
+func (m *MappedArray) Uint16s() ([]uint16, error) { return mappedSlice[uint16](m) }
+
+// Uint32s returns the array data as a slice of uint32 values.
+func (m *MappedArray) Uint32s() ([]uint32, error) { return mappedSlice[uint32](m) }
+
+// Uint64s returns the array data as a slice of uint64 values.
+func (m *MappedArray) Uint64s() ([]uint64, error) { return mappedSlice[uint64](m) }
+
+// Float32s returns the array data as a slice of float32 values.
+func (m *MappedArray) Float32s() ([]float32, error) { return mappedSlice[float32](m) }
+
+// Float64s returns the array data as a slice of float64 values.
+func (m *MappedArray) Float64s() ([]float64, error) { return mappedSlice[float64](m) }
+
+// Complex64s returns the array data as a slice of complex64 values.
+func (m *MappedArray) Complex64s() ([]complex64, error) { return mappedSlice[complex64](m) }
+
+// Complex128s returns the array data as a slice of complex128 values.
+func (m *MappedArray) Complex128s() ([]complex128, error) { return mappedSlice[complex128](m) }
+
+// mappedSlice returns the array data of m as a slice of T aliasing the
+// mapped memory.
+func mappedSlice[T any](m *MappedArray) ([]T, error) {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	switch {
+	case m.dt.rt == nil:
+		return nil, fmt.Errorf("npy: mapped array is closed")
+	case m.dt.rt != rt:
+		return nil, fmt.Errorf(
+			"npy: can not view array of dtype %q as %v: %w",
+			m.Header.Descr.Type, rt, ErrTypeMismatch,
+		)
+	case m.dt.size > 1 && m.dt.order != nativeEndian:
+		return nil, fmt.Errorf(
+			"npy: can not view array of dtype %q with non-native byte order as %v: %w",
+			m.Header.Descr.Type, rt, ErrTypeMismatch,
+		)
+	}
+
+	n := len(m.data) / int(rt.Size())
+	if n == 0 {
+		return []T{}, nil
+	}
+	p := unsafe.Pointer(&m.data[0])
+	if uintptr(p)%uintptr(rt.Align()) != 0 {
+		return nil, fmt.Errorf("npy: array data of dtype %q is not aligned in memory", m.Header.Descr.Type)
+	}
+	return unsafe.Slice((*T)(p), n), nil
+}
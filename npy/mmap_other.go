@@ -0,0 +1,30 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix
+
+package npy
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile reads the size bytes of f in memory, on platforms without
+// memory mapping.
+func mmapFile(f *os.File, size int64) ([]byte, bool, error) {
+	if size != int64(int(size)) {
+		return nil, false, ErrTooLargeForMemory
+	}
+	b := make([]byte, size)
+	_, err := io.ReadFull(f, b)
+	if err != nil {
+		return nil, false, err
+	}
+	return b, false, nil
+}
+
+func munmap(b []byte) error {
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOpenMmap(t *testing.T) {
+	m, err := OpenMmap("../testdata/data_float64_2x3_corder.npy")
+	if err != nil {
+		t.Fatalf("could not map file: %+v", err)
+	}
+	defer m.Close()
+
+	if got, want := m.Header.Descr.Shape, []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+
+	got, err := m.Float64s()
+	if err != nil {
+		t.Fatalf("could not view data: %+v", err)
+	}
+	if want := []float64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := len(m.Bytes()), 6*8; got != want {
+		t.Fatalf("invalid data size: got=%d, want=%d", got, want)
+	}
+
+	_, err = m.Int64s()
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+	}
+
+	err = m.Close()
+	if err != nil {
+		t.Fatalf("could not close mapped array: %+v", err)
+	}
+	_, err = m.Float64s()
+	if err == nil {
+		t.Fatalf("expected an error after close")
+	}
+	err = m.Close()
+	if err != nil {
+		t.Fatalf("could not close mapped array twice: %+v", err)
+	}
+}
+
+func TestOpenMmapTypes(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		view  func(m *MappedArray) (interface{}, error)
+		want  interface{}
+	}{
+		{
+			fname: "../testdata/data_int32_6x1_corder.npy",
+			view:  func(m *MappedArray) (interface{}, error) { return m.Int32s() },
+			want:  []int32{0, 1, 2, 3, 4, 5},
+		},
+		{
+			fname: "../testdata/data_uint8_6x1_corder.npy",
+			view:  func(m *MappedArray) (interface{}, error) { return m.Uint8s() },
+			want:  []uint8{0, 1, 2, 3, 4, 5},
+		},
+		{
+			fname: "../testdata/data_float32_2x3_forder.npy",
+			view:  func(m *MappedArray) (interface{}, error) { return m.Float32s() },
+			want:  []float32{0, 1, 2, 3, 4, 5},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			m, err := OpenMmap(tc.fname)
+			if err != nil {
+				t.Fatalf("could not map file: %+v", err)
+			}
+			defer m.Close()
+
+			got, err := tc.view(m)
+			if err != nil {
+				t.Fatalf("could not view data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOpenMmapInvalid(t *testing.T) {
+	t.Run("big-endian", func(t *testing.T) {
+		m, err := OpenMmap("../testdata/data_complex128_bigendian.npy")
+		if err != nil {
+			t.Fatalf("could not map file: %+v", err)
+		}
+		defer m.Close()
+
+		_, err = m.Complex128s()
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		raw, err := os.ReadFile("../testdata/data_float64_2x3_corder.npy")
+		if err != nil {
+			t.Fatalf("could not read file: %+v", err)
+		}
+		fname := filepath.Join(t.TempDir(), "truncated.npy")
+		err = os.WriteFile(fname, raw[:len(raw)-4], 0644)
+		if err != nil {
+			t.Fatalf("could not write file: %+v", err)
+		}
+
+		_, err = OpenMmap(fname)
+		var e *Error
+		if !errors.As(err, &e) || e.Kind != Truncated {
+			t.Fatalf("invalid error: got=%v, want a truncated error", err)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		fname := filepath.Join(t.TempDir(), "empty.npy")
+		err := os.WriteFile(fname, nil, 0644)
+		if err != nil {
+			t.Fatalf("could not write file: %+v", err)
+		}
+
+		_, err = OpenMmap(fname)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestMmapBools(t *testing.T) {
+	var hdr Header
+	hdr.Major = 1
+	hdr.Descr.Type = "|b1"
+	hdr.Descr.Shape = []int{4}
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		want []bool
+	}{
+		{"canonical", []byte{0, 1, 1, 0}, []bool{false, true, true, false}},
+		{"non-canonical", []byte{0, 1, 2, 0xff}, []bool{false, true, true, true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "bools.npy")
+			f, err := os.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+			defer f.Close()
+			err = WriteStream(f, hdr, bytes.NewReader(tc.raw))
+			if err != nil {
+				t.Fatalf("could not write stream: %+v", err)
+			}
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+
+			m, err := OpenMmap(fname)
+			if err != nil {
+				t.Fatalf("could not map file: %+v", err)
+			}
+			defer m.Close()
+
+			got, err := m.Bools()
+			if err != nil {
+				t.Fatalf("could not view data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package npy
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the size bytes of f in memory, read-only.
+func mmapFile(f *os.File, size int64) ([]byte, bool, error) {
+	if size == 0 {
+		// empty files can not be mapped.
+		return nil, false, nil
+	}
+	if size != int64(int(size)) {
+		return nil, false, ErrTooLargeForMemory
+	}
+	b, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+func munmap(b []byte) error {
+	return syscall.Munmap(b)
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+// rawElem is the set of element types whose in-memory representation is
+// their NumPy on-disk representation, in native byte order.
+type rawElem interface {
+	int8 | int16 | int32 | int64 |
+		uint8 | uint16 | uint32 | uint64 |
+		float32 | float64 | complex64 | complex128
+}
+
+// readRaw reads the array data straight into the memory of s, without any
+// intermediate buffer.
+// readRaw must only be used for on-disk data in native byte order.
+func readRaw[T rawElem](r *Reader, s []T) error {
+	if len(s) == 0 {
+		return r.err
+	}
+	var v T
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), len(s)*int(unsafe.Sizeof(v)))
+	_, err := r.read(raw)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return r.err
+}
+
+// batchLen is the number of elements readBatch decodes at once.
+const batchLen = 8192
+
+// readBatch reads the array data into s, decoding each element of size
+// bytes with dec.
+// The data is read a batch of elements at a time, rather than element by
+// element.
+func readBatch[T any](r *Reader, s []T, size int, dec func(b []byte) T) error {
+	buf := make([]byte, min(len(s), batchLen)*size)
+	for beg := 0; beg < len(s); beg += batchLen {
+		end := min(beg+batchLen, len(s))
+		raw := buf[:(end-beg)*size]
+		_, err := r.read(raw)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		for i := range s[beg:end] {
+			s[beg+i] = dec(raw[i*size:])
+		}
+	}
+	return r.err
+}
+
+// writeNative writes v, a slice of numeric values, straight from its memory
+// when dt describes its elements in native byte order.
+// writeNative reports whether v was handled.
+func writeNative(w io.Writer, v interface{}, dt dType) (bool, error) {
+	if dt.order != nativeEndian {
+		return false, nil
+	}
+	switch v := v.(type) {
+	case []int8:
+		return writeRaw(w, v, dt)
+	case []int16:
+		return writeRaw(w, v, dt)
+	case []int32:
+		return writeRaw(w, v, dt)
+	case []int64:
+		return writeRaw(w, v, dt)
+	case []uint16:
+		return writeRaw(w, v, dt)
+	case []uint32:
+		return writeRaw(w, v, dt)
+	case []uint64:
+		return writeRaw(w, v, dt)
+	case []float32:
+		return writeRaw(w, v, dt)
+	case []float64:
+		return writeRaw(w, v, dt)
+	case []complex64:
+		return writeRaw(w, v, dt)
+	case []complex128:
+		return writeRaw(w, v, dt)
+	}
+	return false, nil
+}
+
+// writeRaw writes the memory of s with a single call to w.Write.
+// writeRaw only handles s when dt describes its elements exactly, so the
+// written bytes are the ones the per-element encoders would produce.
+func writeRaw[T rawElem](w io.Writer, s []T, dt dType) (bool, error) {
+	var v T
+	if dt.rt != reflect.TypeOf(v) || dt.size != int(unsafe.Sizeof(v)) {
+		return false, nil
+	}
+	if len(s) == 0 {
+		return true, nil
+	}
+	raw := unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), len(s)*dt.size)
+	_, err := w.Write(raw)
+	return true, err
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestReadRaw(t *testing.T) {
+	src := []float64{-2, -1, 0, 1, 2.5}
+	for _, tc := range []struct {
+		dtype string
+		ptr   interface{}
+		want  interface{}
+	}{
+		{dtype: "<i4", ptr: new([]int32), want: []int32{-2, -1, 0, 1, 2}},
+		{dtype: ">i4", ptr: new([]int32), want: []int32{-2, -1, 0, 1, 2}},
+		{dtype: "<f4", ptr: new([]float32), want: []float32{-2, -1, 0, 1, 2.5}},
+		{dtype: ">f4", ptr: new([]float32), want: []float32{-2, -1, 0, 1, 2.5}},
+		{dtype: "<f8", ptr: new([]float64), want: []float64{-2, -1, 0, 1, 2.5}},
+		{dtype: ">f8", ptr: new([]float64), want: []float64{-2, -1, 0, 1, 2.5}},
+		{dtype: "<i4", ptr: &[]int32{0, 0}, want: []int32{-2, -1}},
+		{dtype: ">f4", ptr: &[]float32{0, 0}, want: []float32{-2, -1}},
+	} {
+		t.Run(tc.dtype, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteAs(buf, src, tc.dtype, WithCast(CastWrap))
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			err = Read(buf, tc.ptr)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got := reflect.ValueOf(tc.ptr).Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("short", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Write(buf, []int32{1, 2, 3})
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+		buf.Truncate(buf.Len() - 2)
+
+		var got []int32
+		err = Read(buf, &got)
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+func TestReadBatch(t *testing.T) {
+	const n = 2*batchLen + 3
+	gen := func(rt reflect.Type) reflect.Value {
+		vs := reflect.MakeSlice(reflect.SliceOf(rt), n, n)
+		for i := 0; i < n; i++ {
+			v := float64(i) - n/2
+			switch rt.Kind() {
+			case reflect.Complex64, reflect.Complex128:
+				vs.Index(i).SetComplex(complex(v, -v/4))
+			case reflect.Float32, reflect.Float64:
+				vs.Index(i).SetFloat(v / 8)
+			case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				vs.Index(i).SetInt(int64(v))
+			default:
+				vs.Index(i).SetUint(uint64(i))
+			}
+		}
+		return vs
+	}
+
+	for _, tc := range []struct {
+		code string
+		rt   reflect.Type
+	}{
+		{"i1", int8Type},
+		{"u1", uint8Type},
+		{"i2", int16Type},
+		{"i4", int32Type},
+		{"i8", int64Type},
+		{"u2", uint16Type},
+		{"u4", uint32Type},
+		{"u8", uint64Type},
+		{"f4", float32Type},
+		{"f8", float64Type},
+		{"c8", complex64Type},
+		{"c16", complex128Type},
+	} {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			descr := "<" + tc.code
+			if order == binary.BigEndian {
+				descr = ">" + tc.code
+			}
+			t.Run(descr, func(t *testing.T) {
+				want := gen(tc.rt)
+				payload := new(bytes.Buffer)
+				err := binary.Write(payload, order, want.Interface())
+				if err != nil {
+					t.Fatalf("could not encode data: %+v", err)
+				}
+
+				var hdr Header
+				hdr.Major = 1
+				hdr.Descr.Type = descr
+				hdr.Descr.Shape = []int{n}
+				buf := new(bytes.Buffer)
+				err = WriteStream(buf, hdr, payload)
+				if err != nil {
+					t.Fatalf("could not write stream: %+v", err)
+				}
+
+				r, err := NewReader(buf)
+				if err != nil {
+					t.Fatalf("could not create reader: %+v", err)
+				}
+				// read a first chunk, not aligned on batches, then the rest.
+				const chunk = batchLen + 1
+				head := reflect.New(want.Type())
+				head.Elem().Set(reflect.MakeSlice(want.Type(), chunk, chunk))
+				err = r.Read(head.Interface())
+				if err != nil {
+					t.Fatalf("could not read first chunk: %+v", err)
+				}
+				tail := reflect.New(want.Type())
+				tail.Elem().Set(reflect.MakeSlice(want.Type(), n-chunk, n-chunk))
+				err = r.Read(tail.Interface())
+				if err != nil {
+					t.Fatalf("could not read second chunk: %+v", err)
+				}
+
+				got := reflect.AppendSlice(head.Elem(), tail.Elem())
+				if !reflect.DeepEqual(got.Interface(), want.Interface()) {
+					t.Fatalf("invalid data")
+				}
+			})
+		}
+	}
+}
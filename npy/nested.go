@@ -0,0 +1,101 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isNested returns whether rt is a slice of slices.
+func isNested(rt reflect.Type) bool {
+	return rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Slice
+}
+
+// readNested reads the array into rv, a slice of slices nested as many
+// times as the array has dimensions.
+// The nested slices are indexed in C-order, whatever the memory order of
+// the array.
+func (r *Reader) readNested(rv reflect.Value) error {
+	var (
+		rt    = rv.Type()
+		et    = rt
+		depth = 0
+		shape = r.Header.Descr.Shape
+	)
+	for et.Kind() == reflect.Slice {
+		et = et.Elem()
+		depth++
+	}
+	if depth != len(shape) {
+		return fmt.Errorf(
+			"npy: can not read array of shape %v into %d-dimensional %v: %w",
+			shape, depth, rt, errDims,
+		)
+	}
+
+	flat := reflect.New(reflect.SliceOf(et))
+	err := r.Read(flat.Interface())
+	if err != nil {
+		return err
+	}
+	data := flat.Elem()
+	if r.Header.Descr.Fortran {
+		data = cOrder(data, shape)
+	}
+
+	rv.Set(nest(rt, data, shape))
+	return nil
+}
+
+// cOrder returns the elements of the Fortran-ordered array data with the
+// provided shape, laid out in C-order.
+func cOrder(data reflect.Value, shape []int) reflect.Value {
+	var (
+		n       = data.Len()
+		dst     = reflect.MakeSlice(data.Type(), n, n)
+		idx     = make([]int, len(shape))
+		strides = make([]int, len(shape))
+		stride  = 1
+	)
+	for i := range shape {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+
+	off := 0
+	for i := 0; i < n; i++ {
+		dst.Index(i).Set(data.Index(off))
+		// increment the C-order index, last axis first.
+		for j := len(idx) - 1; j >= 0; j-- {
+			idx[j]++
+			off += strides[j]
+			if idx[j] < shape[j] {
+				break
+			}
+			off -= idx[j] * strides[j]
+			idx[j] = 0
+		}
+	}
+	return dst
+}
+
+// nest returns a value of the nested slice type rt, with the provided
+// shape, whose innermost slices share the C-ordered elements of data.
+func nest(rt reflect.Type, data reflect.Value, shape []int) reflect.Value {
+	if len(shape) == 1 {
+		return data.Slice3(0, shape[0], shape[0])
+	}
+
+	var (
+		n    = shape[0]
+		out  = reflect.MakeSlice(rt, n, n)
+		size = data.Len() / max(n, 1)
+	)
+	for i := 0; i < n; i++ {
+		out.Index(i).Set(nest(rt.Elem(), data.Slice(i*size, (i+1)*size), shape[1:]))
+	}
+	return out
+}
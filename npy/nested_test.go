@@ -0,0 +1,99 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadNested(t *testing.T) {
+	want := [][][]float64{
+		{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9, 10, 11}},
+		{{12, 13, 14, 15}, {16, 17, 18, 19}, {20, 21, 22, 23}},
+	}
+
+	for _, fname := range []string{
+		"../testdata/data_float64_2x3x4_corder.npy",
+		"../testdata/data_float64_2x3x4_forder.npy",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			f, err := os.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", fname, err)
+			}
+			defer f.Close()
+
+			var got [][][]float64
+			err = Read(f, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadNestedSlices(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		opts []ReadOption
+		want interface{}
+	}{
+		{
+			name: "2d",
+			val:  [][]int32{{0, 1, 2}, {3, 4, 5}},
+			want: [][]int32{{0, 1, 2}, {3, 4, 5}},
+		},
+		{
+			name: "convert",
+			val:  [][]int32{{0, 1, 2}, {3, 4, 5}},
+			opts: []ReadOption{WithConvert()},
+			want: [][]int64{{0, 1, 2}, {3, 4, 5}},
+		},
+		{
+			name: "empty-rows",
+			val:  [][]uint8{{}, {}},
+			want: [][]uint8{{}, {}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = Read(buf, got.Interface(), tc.opts...)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := got.Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadNestedDims(t *testing.T) {
+	f, err := os.Open("../testdata/data_float64_2x3x4_corder.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	var got [][]float64
+	err = Read(f, &got)
+	if !errors.Is(err, errDims) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, errDims)
+	}
+}
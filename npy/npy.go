@@ -35,6 +35,58 @@
 //	var data uint64
 //	err = npy.Read(f, &data)
 //
+// # Conversions
+//
+// By default, the on-disk data type and the provided one must match.
+// With the WithConvert option, data is converted to the destination type
+// when no information is lost in the process:
+//   - intN to intM, with M > N,
+//   - uintN to uintM and intM, with M > N,
+//   - (u)int{8,16} to float32, (u)int{8,16,32} to float64,
+//   - float32 to float64,
+//   - complex64 to complex128.
+//
+// Data is also converted, value by value, between any two integer types,
+// from integer to floating-point types and from floating-point to integer
+// types, failing with ErrOutOfRange on the first value that is not exactly
+// representable in the destination type: negative values for unsigned
+// types, values overflowing the destination type, integers too large for
+// the precision of a floating-point type, and NaNs, infinities or
+// fractional values for integer types.
+//
+// Other conversions, e.g. from complex to real types or from float64 to
+// float32, fail with ErrTypeMismatch.
+// The WithNarrowing option additionally allows rounding float64 to float32
+// and complex128 to complex64.
+//
+// When writing, WriteAs converts data to a requested, possibly narrower,
+// data type.
+// Values that do not fit in that data type either make WriteAs fail
+// (CastStrict, the default), are saturated to the minimum or maximum value
+// of that data type (CastClamp) or wrap around (CastWrap):
+//
+//	err = npy.WriteAs(f, []float64{-1, 0.5, 255.9, 300}, "|u1", npy.WithCast(npy.CastClamp))
+//	// writes [0, 0, 255, 255]
+//
+// # Structured arrays
+//
+// Structured arrays, whose records are made of named fields, are read into
+// and written from slices of Go structs:
+//
+//	type Point struct {
+//		X float64 `npy:"x"`
+//		Y float64 `npy:"y"`
+//	}
+//	var pts []Point
+//	err = npy.Read(f, &pts)
+//
+// They can also be traversed one record at a time with EachRecord:
+//
+//	err = npy.EachRecord(f, func(i int, p *Point) error {
+//		fmt.Printf("point[%d] = %v\n", i, *p)
+//		return nil
+//	})
+//
 // # Writing
 //
 // Writing into a NumPy data file can be done like so:
@@ -60,6 +112,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -75,6 +130,7 @@ var (
 
 	// ErrTypeMismatch is the error returned by Reader when the on-disk
 	// data type and the user provided one do NOT match.
+	// Reader.Read details the mismatch with a *MismatchError.
 	ErrTypeMismatch = errors.New("npy: types don't match")
 
 	// ErrInvalidType is the error returned by Reader and Writer when
@@ -82,6 +138,21 @@ var (
 	// reliably (de)serialized.
 	ErrInvalidType = errors.New("npy: invalid or unsupported type")
 
+	// ErrTooLargeForMemory is the error returned by Reader when loading
+	// the whole array data would exceed the limit set with WithMaxBytes,
+	// or the size of the largest Go slice.
+	ErrTooLargeForMemory = errors.New("npy: array too large to be loaded in memory")
+
+	// ErrOutOfRange is the error returned by WriteAs, and by Reader in
+	// conversion mode, when a value can not be represented in the
+	// requested data type.
+	ErrOutOfRange = errors.New("npy: value out of range")
+
+	// ErrNotContiguous is the error returned by WriteWith when the
+	// WithContiguous option is set and the value to write is not laid out
+	// contiguously in C-order in memory.
+	ErrNotContiguous = errors.New("npy: value not C-contiguous")
+
 	// Magic header present at the start of a NumPy data file format.
 	// See https://numpy.org/neps/nep-0001-npy-format.html
 	Magic = [6]byte{'\x93', 'N', 'U', 'M', 'P', 'Y'}
@@ -98,11 +169,12 @@ type Header struct {
 	}
 }
 
-// newHeader creates a new Header with the major/minor version numbers that
-// npy currently supports.
+// newHeader creates a new Header with the default major/minor version
+// numbers, 1.0, as NumPy does.
+// Headers that do not fit in version 1.0 are written with a later version.
 func newHeader() Header {
 	return Header{
-		Major: 2,
+		Major: 1,
 		Minor: 0,
 	}
 }
@@ -117,6 +189,68 @@ func (h Header) String() string {
 	)
 }
 
+// ItemSize returns the size in bytes of the elements of the array, e.g. 8
+// for '<f8' arrays, 10 for '|S10' arrays or the size of a record for
+// structured arrays.
+//
+// ItemSize returns an error wrapping ErrInvalidType if the data type of the
+// array is not supported.
+func (h Header) ItemSize() (int, error) {
+	if dt, err := newDtype(h.Descr.Type); err == nil {
+		return dt.size, nil
+	}
+	if rec, err := newRecType(h.Descr.Type); err == nil {
+		return rec.size, nil
+	}
+	if ext, ok := lookupDescr(h.Descr.Type); ok {
+		return ext.size, nil
+	}
+	return 0, fmt.Errorf("npy: invalid descriptor %q: %w", h.Descr.Type, ErrInvalidType)
+}
+
+// Count returns the number of elements of the array, i.e. the product of
+// the dimensions of its shape, or 1 for 0-dimensional arrays.
+func (h Header) Count() int {
+	return numElems(h.Descr.Shape)
+}
+
+// Dtype returns the Go type of the elements Read decodes from the array,
+// as TypeOf does.
+//
+// Dtype returns an error wrapping ErrInvalidType if the data type of the
+// array has no Go equivalent.
+func (h Header) Dtype() (reflect.Type, error) {
+	return TypeOf(h.Descr.Type)
+}
+
+// StringWidth returns the maximum number of characters of the elements of
+// string arrays, i.e. of bytes for '|S' arrays and of code points for '<U'
+// arrays.
+// StringWidth returns 0 for arrays of other data types.
+func (h Header) StringWidth() int {
+	dt, err := newDtype(h.Descr.Type)
+	if err != nil || dt.rt != stringType {
+		return 0
+	}
+	if dt.utf {
+		return dt.size / 4
+	}
+	return dt.size
+}
+
+// TimeUnit returns the unit of the elements of datetime64 and timedelta64
+// arrays, e.g. "ns" for '<M8[ns]' arrays or "D" for '<m8[D]' arrays.
+// Such arrays can be read into int64 values, holding their number of
+// units, NaT being the minimum int64.
+// TimeUnit returns "" for arrays of other data types.
+func (h Header) TimeUnit() string {
+	dt, err := newDtype(h.Descr.Type)
+	if err != nil {
+		return ""
+	}
+	return dt.unit
+}
+
 var (
 	boolType       = reflect.TypeOf(true)
 	uint8Type      = reflect.TypeOf((*uint8)(nil)).Elem()
@@ -143,6 +277,7 @@ type dType struct {
 	size  int
 	order binary.ByteOrder
 	rt    reflect.Type
+	unit  string // unit of datetime64 and timedelta64 data
 }
 
 func newDtype(str string) (dType, error) {
@@ -153,61 +288,74 @@ func newDtype(str string) (dType, error) {
 			order: nativeEndian,
 		}
 	)
-	switch str {
-	case "b1", "<b1", "|b1", "bool":
+	switch typeCode(str) {
+	case "b1", "bool":
 		dt.rt = boolType
 		dt.size = 1
 
-	case "u1", "<u1", "|u1", "uint8":
+	case "u1", "uint8":
 		dt.rt = uint8Type
 		dt.size = 1
 
-	case "u2", "<u2", "|u2", ">u2", "uint16":
+	case "u2", "uint16":
 		dt.rt = uint16Type
 		dt.size = 2
 
-	case "u4", "<u4", "|u4", ">u4", "uint32":
+	case "u4", "uint32":
 		dt.rt = uint32Type
 		dt.size = 4
 
-	case "u8", "<u8", "|u8", ">u8", "uint64":
+	case "u8", "uint64":
 		dt.rt = uint64Type
 		dt.size = 8
 
-	case "i1", "<i1", "|i1", ">i1", "int8":
+	case "i1", "int8":
 		dt.rt = int8Type
 		dt.size = 1
 
-	case "i2", "<i2", "|i2", ">i2", "int16":
+	case "i2", "int16":
 		dt.rt = int16Type
 		dt.size = 2
 
-	case "i4", "<i4", "|i4", ">i4", "int32":
+	case "i4", "int32":
 		dt.rt = int32Type
 		dt.size = 4
 
-	case "i8", "<i8", "|i8", ">i8", "int64":
+	case "i8", "int64":
 		dt.rt = int64Type
 		dt.size = 8
 
-	case "f4", "<f4", "|f4", ">f4", "float32":
+	case "f2", "float16":
+		dt.rt = float16Type
+		dt.size = 2
+
+	case "f4", "float32":
 		dt.rt = float32Type
 		dt.size = 4
 
-	case "f8", "<f8", "|f8", ">f8", "float64":
+	case "f8", "float64":
 		dt.rt = float64Type
 		dt.size = 8
 
-	case "c8", "<c8", "|c8", ">c8", "complex64":
+	case "c8", "complex64":
 		dt.rt = complex64Type
 		dt.size = 8
 
-	case "c16", "<c16", "|c16", ">c16", "complex128":
+	case "c16", "complex128":
 		dt.rt = complex128Type
 		dt.size = 16
 	}
 
 	switch {
+	case reDatetime.MatchString(str):
+		m := reDatetime.FindStringSubmatch(str)
+		dt.rt = timeType
+		if m[1] == "m" {
+			dt.rt = durationType
+		}
+		dt.size = 8
+		dt.unit = m[2]
+
 	case reStrPre.MatchString(str), reStrPost.MatchString(str):
 		dt.rt = stringType
 		dt.size, err = stringLen(str)
@@ -222,20 +370,54 @@ func newDtype(str string) (dType, error) {
 		if err != nil {
 			return dt, err
 		}
+		dt.size *= 4 // UCS-4 code points
 	}
 	if dt.rt == nil {
 		return dt, fmt.Errorf("npy: no reflect.Type for dtype=%v", str)
 	}
 
-	switch dt.str[0] {
+	dt.order = orderFrom(dt.str)
+	return dt, nil
+}
+
+// typeCode returns the dtype string without its byte order character.
+func typeCode(dtype string) string {
+	if len(dtype) > 1 && strings.IndexByte("<>|=", dtype[0]) >= 0 {
+		return dtype[1:]
+	}
+	return dtype
+}
+
+// orderFrom returns the byte order encoded in the dtype string.
+// '|' (not applicable) and '=' (native) select the native byte order.
+func orderFrom(dtype string) binary.ByteOrder {
+	switch dtype[0] {
 	case '<':
-		dt.order = binary.LittleEndian
+		return binary.LittleEndian
 	case '>':
-		dt.order = binary.BigEndian
+		return binary.BigEndian
 	default:
-		dt.order = nativeEndian
+		return nativeEndian
 	}
-	return dt, nil
+}
+
+var reItemsize = regexp.MustCompile(`^[<>|=]?([a-zA-Z])(\d+)(\[\w+\])?$`)
+
+// itemsizeFrom returns the size in bytes of an element of the provided
+// array-protocol type string (e.g. '<f8', '|S10', '>M8[ns]').
+func itemsizeFrom(dtype string) (int, error) {
+	m := reItemsize.FindStringSubmatch(dtype)
+	if m == nil {
+		return 0, fmt.Errorf("npy: could not infer item size of dtype %q", dtype)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return 0, fmt.Errorf("npy: could not infer item size of dtype %q: %w", dtype, err)
+	}
+	if m[1] == "U" {
+		n *= 4 // UCS-4 code points
+	}
+	return n, nil
 }
 
 var nativeEndian binary.ByteOrder
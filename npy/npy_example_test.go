@@ -7,8 +7,10 @@ package npy_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"reflect"
 
 	"gonum.org/v1/gonum/mat"
 
@@ -145,3 +147,42 @@ func Example_partialRead() {
 	// -- rest of data read back --
 	// data = [3 4 5]
 }
+
+func ExampleReader() {
+	buf := new(bytes.Buffer)
+	err := npy.Write(buf, []int16{1, 2, 3, 4})
+	if err != nil {
+		log.Fatalf("error writing data: %v\n", err)
+	}
+
+	// the Reader works with non-seekable readers: the header is
+	// parsed by NewReader and the data is only consumed by Read.
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := io.Copy(pw, buf)
+		pw.CloseWithError(err)
+	}()
+
+	r, err := npy.NewReader(pr)
+	if err != nil {
+		log.Fatalf("error reading header: %v\n", err)
+	}
+
+	// inspect the header before deciding how to read the data.
+	fmt.Printf("dtype: %s, shape: %v\n", r.Header.Descr.Type, r.Header.Descr.Shape)
+
+	rt, err := npy.TypeOf(r.Header.Descr.Type)
+	if err != nil {
+		log.Fatalf("unsupported dtype: %v\n", err)
+	}
+	ptr := reflect.New(reflect.SliceOf(rt))
+	err = r.Read(ptr.Interface())
+	if err != nil {
+		log.Fatalf("error reading data: %v\n", err)
+	}
+	fmt.Printf("data: %v\n", ptr.Elem().Interface())
+
+	// Output:
+	// dtype: <i2, shape: [4]
+	// data: [1 2 3 4]
+}
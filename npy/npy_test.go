@@ -6,12 +6,69 @@ package npy
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"reflect"
 	"testing"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 )
 
+func TestHeaderHelpers(t *testing.T) {
+	for _, tc := range []struct {
+		descr string
+		shape []int
+		size  int
+		count int
+		rt    reflect.Type
+		err   error
+	}{
+		{descr: "<f8", shape: []int{2, 3}, size: 8, count: 6, rt: float64Type},
+		{descr: "|u1", shape: []int{4}, size: 1, count: 4, rt: uint8Type},
+		{descr: ">c8", shape: nil, size: 8, count: 1, rt: complex64Type},
+		{descr: "|S10", shape: []int{0}, size: 10, count: 0, rt: stringType},
+		{descr: "<U3", shape: []int{2}, size: 12, count: 2, rt: stringType},
+		{descr: "<M8[D]", shape: []int{5}, size: 8, count: 5, rt: reflect.TypeOf(time.Time{})},
+		{descr: "[('x', '<f8'), ('y', '<i2')]", shape: []int{3}, size: 10, count: 3, err: ErrInvalidType},
+		{descr: "<x3", shape: []int{3}, count: 3, err: ErrInvalidType},
+	} {
+		t.Run(tc.descr, func(t *testing.T) {
+			var hdr Header
+			hdr.Descr.Type = tc.descr
+			hdr.Descr.Shape = tc.shape
+
+			if got, want := hdr.Count(), tc.count; got != want {
+				t.Fatalf("invalid count: got=%d, want=%d", got, want)
+			}
+
+			size, err := hdr.ItemSize()
+			switch {
+			case tc.size == 0:
+				if !errors.Is(err, ErrInvalidType) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidType)
+				}
+			case err != nil:
+				t.Fatalf("could not compute item size: %+v", err)
+			case size != tc.size:
+				t.Fatalf("invalid item size: got=%d, want=%d", size, tc.size)
+			}
+
+			rt, err := hdr.Dtype()
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+			case err != nil:
+				t.Fatalf("could not retrieve dtype: %+v", err)
+			case rt != tc.rt:
+				t.Fatalf("invalid dtype: got=%v, want=%v", rt, tc.rt)
+			}
+		})
+	}
+}
+
 func BenchmarkWriteDense(b *testing.B) {
 	data := make([]float64, 1000)
 	m := mat.NewDense(100, 10, data)
@@ -43,6 +100,27 @@ func BenchmarkWriteFloat64Slice(b *testing.B) {
 	}
 }
 
+// BenchmarkWriteFloat64SliceOrder compares the native byte order path,
+// which writes the slice memory at once, with the per-element encoding.
+func BenchmarkWriteFloat64SliceOrder(b *testing.B) {
+	data := make([]float64, 1<<16)
+	for _, bc := range []struct {
+		name  string
+		descr string
+	}{
+		{"native", "<f8"},
+		{"swapped", ">f8"},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(int64(8 * len(data)))
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = WriteWithDescr(io.Discard, data, bc.descr, []int{len(data)})
+			}
+		})
+	}
+}
+
 func BenchmarkWriteBoolSlice(b *testing.B) {
 	data := make([]bool, 1000)
 	w := io.Discard
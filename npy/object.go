@@ -0,0 +1,89 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// objectCodec is the decoder of object arrays registered with
+// RegisterObjectCodec.
+var objectCodec struct {
+	sync.RWMutex
+	dec func(raw []byte) (interface{}, error)
+}
+
+// RegisterObjectCodec registers dec as the decoder of the data of object
+// arrays ('|O'), replacing any previously registered decoder.
+// Registering a nil decoder disables the reading of object arrays.
+//
+// NumPy does not store object arrays element by element: np.save pickles
+// the whole array instead.
+// dec is thus handed the raw data of the array, i.e. all the bytes
+// following the header, and returns the decoded array: a []interface{}
+// holding one value per element, in memory order, or any value when
+// reading into an *interface{}.
+// The npy package does not decode pickles itself: dec typically implements
+// the subset of the pickle protocol needed for the objects at hand.
+func RegisterObjectCodec(dec func(raw []byte) (interface{}, error)) {
+	objectCodec.Lock()
+	defer objectCodec.Unlock()
+	objectCodec.dec = dec
+}
+
+// readObjects reads the data of an object array into rv, a []interface{}
+// or an interface{}, with the registered object codec.
+func (r *Reader) readObjects(rv reflect.Value) error {
+	objectCodec.RLock()
+	dec := objectCodec.dec
+	objectCodec.RUnlock()
+	if dec == nil {
+		return fmt.Errorf("npy: object dtype %q requires a codec (see RegisterObjectCodec): %w", r.Header.Descr.Type, ErrInvalidType)
+	}
+
+	switch rv.Type() {
+	case reflect.TypeOf([]interface{}(nil)), reflect.TypeOf((*interface{})(nil)).Elem():
+	default:
+		return fmt.Errorf("npy: can not read object array into %v: %w", rv.Type(), ErrTypeMismatch)
+	}
+
+	src := r.r
+	if r.maxSz > 0 {
+		src = io.LimitReader(src, r.maxSz+1)
+	}
+	raw, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	if r.maxSz > 0 && int64(len(raw)) > r.maxSz {
+		return fmt.Errorf(
+			"npy: object array data exceeds limit of %d bytes: %w",
+			r.maxSz, ErrTooLargeForMemory,
+		)
+	}
+
+	v, err := dec(raw)
+	if err != nil {
+		return fmt.Errorf("npy: could not decode object array: %w", err)
+	}
+
+	if rv.Kind() == reflect.Interface {
+		rv.Set(reflect.ValueOf(&v).Elem())
+		return nil
+	}
+
+	vs, ok := v.([]interface{})
+	if !ok {
+		return fmt.Errorf("npy: object codec returned %T, want []interface{}: %w", v, ErrTypeMismatch)
+	}
+	if n := numElems(r.Header.Descr.Shape); len(vs) != n {
+		return fmt.Errorf("npy: object codec returned %d values, want %d: %w", len(vs), n, errDims)
+	}
+	rv.Set(reflect.ValueOf(vs))
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// objectArray returns a NumPy data file holding an object array of the
+// provided shape, whose data is raw.
+func objectArray(t *testing.T, shape []int, raw string) []byte {
+	t.Helper()
+
+	hdr := newHeader()
+	hdr.Descr.Type = "|O"
+	hdr.Descr.Shape = shape
+
+	buf := new(bytes.Buffer)
+	err := writeHeader(buf, hdr, dType{})
+	if err != nil {
+		t.Fatalf("could not write header: %+v", err)
+	}
+	buf.WriteString(raw)
+	return buf.Bytes()
+}
+
+func TestReadObjects(t *testing.T) {
+	defer RegisterObjectCodec(nil)
+
+	raw := objectArray(t, []int{3}, "a,bb,ccc")
+
+	var got []interface{}
+	err := Read(bytes.NewReader(raw), &got)
+	if !errors.Is(err, ErrInvalidType) || !strings.Contains(err.Error(), "requires a codec") {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidType)
+	}
+
+	// a toy codec for comma-separated strings.
+	RegisterObjectCodec(func(raw []byte) (interface{}, error) {
+		var vs []interface{}
+		for _, v := range strings.Split(string(raw), ",") {
+			vs = append(vs, v)
+		}
+		return vs, nil
+	})
+
+	err = Read(bytes.NewReader(raw), &got)
+	if err != nil {
+		t.Fatalf("could not read objects: %+v", err)
+	}
+	if want := []interface{}{"a", "bb", "ccc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+
+	var v interface{}
+	err = Read(bytes.NewReader(raw), &v)
+	if err != nil {
+		t.Fatalf("could not read objects: %+v", err)
+	}
+	if want := []interface{}{"a", "bb", "ccc"}; !reflect.DeepEqual(v, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", v, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		ptr  interface{}
+		opts []ReadOption
+		err  error
+	}{
+		{"dims", objectArray(t, []int{2}, "a,bb,ccc"), new([]interface{}), nil, errDims},
+		{"type", raw, new([]string), nil, ErrTypeMismatch},
+		{"max-bytes", raw, new([]interface{}), []ReadOption{WithMaxBytes(4)}, ErrTooLargeForMemory},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(bytes.NewReader(tc.raw), tc.ptr, tc.opts...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+
+	RegisterObjectCodec(func(raw []byte) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	err = Read(bytes.NewReader(raw), &got)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("invalid error: got=%v, want=boom", err)
+	}
+}
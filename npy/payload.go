@@ -0,0 +1,69 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+)
+
+// PayloadReader reads the raw bytes of the array data of a NumPy data file,
+// without interpreting them.
+type PayloadReader struct {
+	r io.Reader
+	n int64 // number of bytes left to read
+}
+
+// NewPayloadReader reads the header of the NumPy data file from r and
+// returns a PayloadReader for its array data, together with the header.
+//
+// The PayloadReader yields exactly as many bytes as announced by the
+// header, i.e. the number of elements times the item size, and then
+// io.EOF, even if r holds more data. It returns io.ErrUnexpectedEOF if r
+// holds fewer bytes.
+//
+// The bytes are read as they are stored: no byte-order conversion nor
+// Fortran to C-order transposition is performed. Use the Header to
+// interpret them.
+// Arrays of Python objects ('O'), whose elements have no fixed size, are
+// not supported.
+func NewPayloadReader(r io.Reader) (*PayloadReader, Header, error) {
+	hdr, err := ReadHeader(r)
+	if err != nil {
+		return nil, hdr, err
+	}
+	n, err := dataSize(hdr)
+	if err != nil {
+		return nil, hdr, wrapErr("header", err)
+	}
+	return &PayloadReader{r: r, n: n}, hdr, nil
+}
+
+// Len returns the number of bytes of array data left to read.
+func (p *PayloadReader) Len() int64 {
+	return p.n
+}
+
+// Read implements io.Reader.
+func (p *PayloadReader) Read(b []byte) (int, error) {
+	if p.n <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(b)) > p.n {
+		b = b[:p.n]
+	}
+	n, err := p.r.Read(b)
+	p.n -= int64(n)
+	switch {
+	case err == io.EOF && p.n > 0:
+		err = wrapErr("read", fmt.Errorf(
+			"npy: array data truncated, %d bytes missing: %w",
+			p.n, io.ErrUnexpectedEOF,
+		))
+	case err == io.EOF && n > 0:
+		err = nil
+	}
+	return n, err
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestPayloadReader(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, []uint16{1, 2, 0x0304})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+	want := raw[len(raw)-6:]
+
+	t.Run("exact", func(t *testing.T) {
+		pr, hdr, err := NewPayloadReader(bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("could not create payload reader: %+v", err)
+		}
+		if got, want := hdr.Descr.Shape, []int{3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+		}
+		if got, want := pr.Len(), int64(6); got != want {
+			t.Fatalf("invalid payload length: got=%d, want=%d", got, want)
+		}
+		got, err := io.ReadAll(pr)
+		if err != nil {
+			t.Fatalf("could not read payload: %+v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("invalid payload:\ngot= %x\nwant=%x", got, want)
+		}
+		if got, want := binary.LittleEndian.Uint16(got[4:]), uint16(0x0304); got != want {
+			t.Fatalf("invalid element: got=%#x, want=%#x", got, want)
+		}
+	})
+
+	t.Run("trailing", func(t *testing.T) {
+		src := bytes.NewReader(append(append([]byte(nil), raw...), "trailing"...))
+		pr, _, err := NewPayloadReader(src)
+		if err != nil {
+			t.Fatalf("could not create payload reader: %+v", err)
+		}
+		got, err := io.ReadAll(pr)
+		if err != nil {
+			t.Fatalf("could not read payload: %+v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("invalid payload:\ngot= %x\nwant=%x", got, want)
+		}
+		rest, _ := io.ReadAll(src)
+		if got, want := string(rest), "trailing"; got != want {
+			t.Fatalf("invalid trailing bytes: got=%q, want=%q", got, want)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		pr, _, err := NewPayloadReader(bytes.NewReader(raw[:len(raw)-1]))
+		if err != nil {
+			t.Fatalf("could not create payload reader: %+v", err)
+		}
+		_, err = io.ReadAll(pr)
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, io.ErrUnexpectedEOF)
+		}
+		var e *Error
+		if !errors.As(err, &e) || e.Kind != Truncated {
+			t.Fatalf("invalid error kind: got=%v, want=%v", err, Truncated)
+		}
+	})
+
+	t.Run("objects", func(t *testing.T) {
+		hdr := newHeader()
+		hdr.Descr.Type = "|O"
+		hdr.Descr.Shape = []int{1}
+		buf := new(bytes.Buffer)
+		err := writeHeader(buf, hdr, dType{})
+		if err != nil {
+			t.Fatalf("could not write header: %+v", err)
+		}
+		_, _, err = NewPayloadReader(buf)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
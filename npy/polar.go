@@ -0,0 +1,78 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/cmplx"
+)
+
+// ReadPolar reads a complex numpy-array ('c8' or 'c16') from r and stores
+// the magnitude and the phase of its elements into mag and phase, in the
+// order they are stored on disk, as Read does for slices.
+// The magnitude and the phase are computed with cmplx.Abs and cmplx.Phase.
+//
+// The slices pointed at by mag and phase are reused if their capacity is
+// large enough, and re-allocated otherwise.
+func ReadPolar(r io.Reader, mag, phase *[]float64) error {
+	if mag == nil || phase == nil {
+		return errNilPtr
+	}
+
+	rr, err := NewReader(r)
+	if err != nil {
+		return err
+	}
+
+	dt, err := newDtype(rr.Header.Descr.Type)
+	if err != nil {
+		return err
+	}
+	if dt.rt != complex64Type && dt.rt != complex128Type {
+		return fmt.Errorf("npy: dtype %q is not a complex data type: %w", dt.str, ErrTypeMismatch)
+	}
+
+	n := numElems(rr.Header.Descr.Shape)
+	*mag = resize(*mag, n)
+	*phase = resize(*phase, n)
+
+	buf := make([]byte, dt.size)
+	for i := 0; i < n; i++ {
+		_, err = rr.read(buf)
+		if err != nil {
+			return fmt.Errorf("npy: could not read element #%d: %w", i, err)
+		}
+
+		var c complex128
+		switch dt.rt {
+		case complex64Type:
+			c = complex(
+				float64(math.Float32frombits(dt.order.Uint32(buf[0:]))),
+				float64(math.Float32frombits(dt.order.Uint32(buf[4:]))),
+			)
+		default:
+			c = complex(
+				math.Float64frombits(dt.order.Uint64(buf[0:])),
+				math.Float64frombits(dt.order.Uint64(buf[8:])),
+			)
+		}
+
+		(*mag)[i] = cmplx.Abs(c)
+		(*phase)[i] = cmplx.Phase(c)
+	}
+
+	return nil
+}
+
+// resize returns a slice of n elements, reusing the storage of vs if
+// possible.
+func resize(vs []float64, n int) []float64 {
+	if cap(vs) < n {
+		return make([]float64, n)
+	}
+	return vs[:n]
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/cmplx"
+	"os"
+	"testing"
+)
+
+func TestReadPolar(t *testing.T) {
+	want := []complex128{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i}
+
+	for _, tc := range []struct {
+		name string
+		raw  func() []byte
+	}{
+		{
+			name: "c8-bigendian",
+			raw: func() []byte {
+				raw, err := os.ReadFile("../testdata/data_complex64_bigendian.npy")
+				if err != nil {
+					t.Fatalf("could not read file: %+v", err)
+				}
+				return raw
+			},
+		},
+		{
+			name: "c16-bigendian",
+			raw: func() []byte {
+				raw, err := os.ReadFile("../testdata/data_complex128_bigendian.npy")
+				if err != nil {
+					t.Fatalf("could not read file: %+v", err)
+				}
+				return raw
+			},
+		},
+		{
+			name: "c16",
+			raw: func() []byte {
+				buf := new(bytes.Buffer)
+				err := Write(buf, want)
+				if err != nil {
+					t.Fatalf("could not write data: %+v", err)
+				}
+				return buf.Bytes()
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				mag   = make([]float64, 0, 10)
+				phase []float64
+			)
+			err := ReadPolar(bytes.NewReader(tc.raw()), &mag, &phase)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if len(mag) != len(want) || len(phase) != len(want) {
+				t.Fatalf("invalid lengths: mag=%d, phase=%d, want=%d", len(mag), len(phase), len(want))
+			}
+			for i, c := range want {
+				if got, want := mag[i], cmplx.Abs(c); math.Abs(got-want) > 1e-6 {
+					t.Fatalf("invalid magnitude #%d: got=%v, want=%v", i, got, want)
+				}
+				if got, want := phase[i], cmplx.Phase(c); math.Abs(got-want) > 1e-6 {
+					t.Fatalf("invalid phase #%d: got=%v, want=%v", i, got, want)
+				}
+			}
+		})
+	}
+
+	f, err := os.Open("../testdata/data_float64_2x3_corder.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	var mag, phase []float64
+	err = ReadPolar(f, &mag, &phase)
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: %+v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// ReadRange reads the elements [lo, hi) of the NumPy array held in r, in
+// C-order, into the provided pointed at value dst, as Read does for a
+// 1-dimensional array of hi-lo elements.
+// Only the header and the requested elements are read from r.
+//
+// ReadRange fails with an error wrapping ErrInvalidType for Fortran-ordered
+// arrays of more than one dimension, whose elements are not stored in
+// C-order.
+func ReadRange(r io.ReaderAt, lo, hi int, dst interface{}, opts ...ReadOption) error {
+	return wrapErr("read", readRange(r, lo, hi, dst, opts))
+}
+
+func readRange(r io.ReaderAt, lo, hi int, dst interface{}, opts []ReadOption) error {
+	rr, err := NewReader(io.NewSectionReader(r, 0, math.MaxInt64), opts...)
+	if err != nil {
+		return err
+	}
+
+	shape := rr.Header.Descr.Shape
+	if rr.Header.Descr.Fortran && len(shape) > 1 {
+		return fmt.Errorf("npy: ranged reads of Fortran-ordered arrays not supported: %w", ErrInvalidType)
+	}
+	if n := rr.Header.Count(); lo < 0 || hi < lo || hi > n {
+		return fmt.Errorf("npy: range [%d, %d) out of bounds for array of %d elements: %w", lo, hi, n, errDims)
+	}
+
+	size, err := rr.Header.ItemSize()
+	if err != nil {
+		return err
+	}
+
+	var (
+		off = rr.data + int64(lo)*int64(size)
+		n   = int64(hi-lo) * int64(size)
+	)
+	rr.r = io.NewSectionReader(r, off, n)
+	if rr.pad != nil {
+		rr.pad = &shortReader{r: rr.r, n: n}
+		rr.r = rr.pad
+	}
+	rr.Header.Descr.Shape = []int{hi - lo}
+
+	return rr.readPtr(dst)
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// countReaderAt counts the bytes read from an io.ReaderAt.
+type countReaderAt struct {
+	r io.ReaderAt
+	n int64
+}
+
+func (r *countReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.r.ReadAt(p, off)
+	r.n += int64(n)
+	return n, err
+}
+
+func TestReadRange(t *testing.T) {
+	type point struct {
+		X float64
+		Y int32
+	}
+
+	for _, tc := range []struct {
+		name   string
+		val    interface{}
+		lo, hi int
+		ptr    interface{}
+		opts   []ReadOption
+		want   interface{}
+	}{
+		{
+			name: "head",
+			val:  []float64{0, 1, 2, 3, 4, 5},
+			lo:   0, hi: 2,
+			ptr:  new([]float64),
+			want: []float64{0, 1},
+		},
+		{
+			name: "window",
+			val:  []int16{0, -1, -2, -3, -4, -5},
+			lo:   2, hi: 5,
+			ptr:  new([]int16),
+			want: []int16{-2, -3, -4},
+		},
+		{
+			name: "tail",
+			val:  mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5}),
+			lo:   4, hi: 6,
+			ptr:  new([2]float64),
+			want: [2]float64{4, 5},
+		},
+		{
+			name: "empty",
+			val:  []uint8{1, 2, 3},
+			lo:   3, hi: 3,
+			ptr:  new([]uint8),
+			want: []uint8{},
+		},
+		{
+			name: "convert",
+			val:  []int32{0, 1, 2, 3},
+			lo:   1, hi: 3,
+			ptr:  new([]float64),
+			opts: []ReadOption{WithConvert()},
+			want: []float64{1, 2},
+		},
+		{
+			name: "records",
+			val:  []point{{0, 0}, {1, -1}, {2, -2}},
+			lo:   1, hi: 3,
+			ptr:  new([]point),
+			want: []point{{1, -1}, {2, -2}},
+		},
+		{
+			name: "strings",
+			val:  []string{"a", "bb", "ccc"},
+			lo:   1, hi: 2,
+			ptr:  new([]string),
+			want: []string{"bb"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r := &countReaderAt{r: bytes.NewReader(buf.Bytes())}
+			err = ReadRange(r, tc.lo, tc.hi, tc.ptr, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not read range: %+v", err)
+			}
+			if got := reflect.ValueOf(tc.ptr).Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+
+			hdr, off, err := ReadHeaderAt(bytes.NewReader(buf.Bytes()), 0)
+			if err != nil {
+				t.Fatalf("could not read header: %+v", err)
+			}
+			size, err := dataSize(hdr)
+			if err != nil {
+				t.Fatalf("could not compute data size: %+v", err)
+			}
+			elem := size / int64(numElems(hdr.Descr.Shape))
+			if got, want := r.n, off+int64(tc.hi-tc.lo)*elem; got > want {
+				t.Fatalf("too many bytes read: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestReadRangeInvalid(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, []float64{0, 1, 2})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := bytes.NewReader(buf.Bytes())
+
+	for _, tc := range []struct {
+		name   string
+		lo, hi int
+	}{
+		{"negative", -1, 2},
+		{"reversed", 2, 1},
+		{"overflow", 1, 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []float64
+			err := ReadRange(raw, tc.lo, tc.hi, &got)
+			if !errors.Is(err, errDims) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, errDims)
+			}
+		})
+	}
+
+	f, err := os.Open("../testdata/data_float64_2x3_forder.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+	var got []float64
+	err = ReadRange(f, 0, 2, &got)
+	if !errors.Is(err, ErrInvalidType) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidType)
+	}
+}
@@ -7,15 +7,18 @@ package npy
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"os"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
-	"unicode/utf8"
+	"time"
 
+	"github.com/sbinet/npyio/float16"
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -27,17 +30,98 @@ import (
 // If a *mat.Dense matrix is passed to Read, the numpy-array data is loaded
 // into the Dense matrix, honouring Fortran/C-order and dimensions/shape
 // parameters.
+// 1-dimensional numpy-arrays are loaded as row vectors, unless the
+// WithVector option is provided.
 //
-// Only numpy-arrays with up to 2 dimensions are supported.
-// Only numpy-arrays with elements convertible to float64 are supported.
-func Read(r io.Reader, ptr interface{}) error {
-	rr, err := NewReader(r)
+// If a *interface{} is passed to Read, it is set to a scalar for
+// 0-dimensional arrays and to a slice otherwise, with elements of the Go
+// type of the on-disk data type, or of the WithDtypeHint option.
+//
+// Multi-byte elements are decoded with the byte order of the on-disk data
+// type: little-endian ('<'), big-endian ('>') or native ('=' and '|').
+//
+// N-dimensional arrays can be read into slices of slices nested N times,
+// e.g. a [][][]float64 for a 3-dimensional array, indexed in C-order
+// whatever the memory order of the array.
+//
+// Half-precision arrays ('<f2') are read into float16.Num values, or
+// converted exactly into float32 and float64 values.
+//
+// Datetime arrays ('<M8[unit]') are read into time.Time values, in UTC, and
+// time delta arrays ('<m8[unit]') into time.Duration values, for units
+// from years ('Y') or weeks ('W') down to nanoseconds ('ns').
+// NaT is read as the zero time.Time and as the minimum time.Duration.
+//
+// Structured arrays are read into a struct, or a slice or an array of
+// structs, mapping fields as EachRecord does.
+// Structured arrays with exactly two fields named 'real' and 'imag', in
+// that order, of the same float32 or float64 data type, can also be read
+// into complex values, as written by the WithSplitComplex option.
+//
+// Slices are reused whenever possible: an empty slice, or a slice holding
+// at least as many elements as the array, is resliced to exactly the number
+// of elements of the array if its capacity allows it, and re-allocated
+// otherwise.
+// A non-empty slice holding fewer elements than the array is filled with
+// the next len(slice) elements of the array, to read the array in chunks.
+// Nested slices and object arrays are always read as a whole.
+//
+// Object arrays ('|O') are read into a []interface{}, or an interface{},
+// with the decoder registered with RegisterObjectCodec. Their data is read
+// until the end of r.
+//
+// Arrays whose data can not be addressed by a Go slice are too large to be
+// loaded as a whole: Read then fails with ErrTooLargeForMemory, but such
+// arrays can still be read in chunks with pre-sized slices or ReadToChan.
+//
+// Read fails with an error wrapping io.ErrUnexpectedEOF if the array data
+// is shorter than announced by the header, e.g. for a file left partially
+// written. This is checked before reading any data when the length of r is
+// known (regular files, bytes.Reader, io.Seeker values, ...), and as the
+// data is read otherwise. See WithPadShort to zero-fill such arrays
+// instead.
+//
+// ptr must point to one of:
+//   - a value of a Go type matching the on-disk data type: bool, fixed-size
+//     integers, floating-point and complex numbers, float16.Num, strings,
+//     time.Time, time.Duration, a struct for structured arrays, or a type
+//     registered with RegisterType;
+//   - a slice or an array of such values, or nested slices of them, as well
+//     as a [][]byte for byte strings ('|S');
+//   - a *mat.Dense, for arrays of up to 2 dimensions;
+//   - an interface{}.
+//
+// Read returns an error for any other destination, or if the on-disk data
+// type can not be converted to the provided one.
+func Read(r io.Reader, ptr interface{}, opts ...ReadOption) error {
+	_, err := ReadWithHeader(r, ptr, opts...)
+	return err
+}
+
+// ReadWithHeader reads the data from the r NumPy data file io.Reader into
+// the provided pointed at value ptr, as Read does, and returns the header
+// of the file, e.g. to retrieve the original data type, shape or memory
+// order of the array.
+// The header is also returned when reading the data fails, provided it
+// could be decoded.
+func ReadWithHeader(r io.Reader, ptr interface{}, opts ...ReadOption) (Header, error) {
+	rr, err := NewReader(r, opts...)
 	if err != nil {
-		return err
+		return Header{}, err
 	}
 
-	return rr.Read(ptr)
+	return rr.Header, rr.Read(ptr)
 }
+
+// MustRead is like Read but panics if the data can not be read.
+// It is intended for tests and scripts where errors are fatal.
+func MustRead(r io.Reader, ptr interface{}, opts ...ReadOption) {
+	err := Read(r, ptr, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func ReadToChan(r io.Reader, ptr *chan any) error {
 	rr, err := NewReader(r)
 	if err != nil {
@@ -47,24 +131,358 @@ func ReadToChan(r io.Reader, ptr *chan any) error {
 }
 
 // Reader reads data from a NumPy data file.
+//
+// The header of the file is parsed by NewReader and exposed through the
+// Header field, so that it can be inspected before reading the array data
+// with Read.
 type Reader struct {
 	r   io.Reader
 	err error // last error
 
 	Header Header
 	order  binary.ByteOrder
+	data   int64 // offset of the array data, from the start of the file
+
+	vector Vector // how 1-dim arrays are loaded into matrices
+	maxSz  int64  // maximum number of bytes to load in memory at once
+
+	convert  bool // whether to convert on-disk data to the destination type
+	narrow   bool // whether conversions may round floating-point values
+	narrowed bool // whether floating-point values have been rounded
+
+	strictKeys bool // whether to reject unknown and duplicate header keys
+
+	hint string // data type descriptor preferred for dynamic reads
+
+	bf16 bool // whether to decode 2-byte void data as bfloat16 values
+
+	keepNULs bool // whether to keep the trailing NULs of '|S' strings
+
+	padShort bool         // whether to zero-fill a short data section
+	pad      *shortReader // zero-filling reader of the data section
+
+	strict  bool         // whether to reject data past the array data
+	trailed bool         // whether data past the array data was checked for
+	sized   bool         // whether the length of src was checked for
+	src     io.Reader    // underlying reader, past any wrapping reader
+	count   *tallyReader // reader counting the bytes of array data read
+	size    int64        // size of the array data in bytes, or -1 if unknown
+}
+
+// ReadOption configures a Reader.
+type ReadOption func(r *Reader)
+
+// Vector describes how a 1-dimensional array is loaded into a matrix.
+type Vector int
+
+const (
+	RowVector Vector = iota // n elements are loaded as a 1×n matrix
+	ColVector               // n elements are loaded as a n×1 matrix
+)
+
+// WithVector configures how 1-dimensional arrays are loaded into
+// a mat.Dense.
+// The default is to load them as row vectors.
+func WithVector(v Vector) ReadOption {
+	return func(r *Reader) {
+		r.vector = v
+	}
+}
+
+// WithMaxBytes sets the maximum number of bytes Read may allocate when
+// loading a whole array in memory.
+// Reading a larger array into an empty slice or a mat.Dense then fails with
+// ErrTooLargeForMemory, while partial reads into pre-sized slices and
+// ReadToChan still work.
+// The default is to have no limit.
+func WithMaxBytes(n int64) ReadOption {
+	return func(r *Reader) {
+		r.maxSz = n
+	}
+}
+
+// WithConvert enables the conversion mode of a Reader: on-disk data is
+// converted to the element type of the destination whenever that conversion
+// preserves values.
+// Conversions that only preserve some values, e.g. from int64 to uint8,
+// fail with ErrOutOfRange on the first value that can not be represented.
+// See the package documentation for the conversion rules.
+func WithConvert() ReadOption {
+	return func(r *Reader) {
+		r.convert = true
+	}
+}
+
+// WithNarrowing allows, in conversion mode (see WithConvert), the lossy
+// conversion of floating-point data to a narrower destination type:
+// float64 to float32 and complex128 to complex64.
+// Values are rounded to the nearest representable value; values too large
+// for a float32 become infinities.
+// Reader.Narrowed then reports whether such a conversion took place.
+// The default is to fail with ErrTypeMismatch.
+func WithNarrowing() ReadOption {
+	return func(r *Reader) {
+		r.narrow = true
+	}
+}
+
+// Narrowed reports whether Read rounded floating-point data to a narrower
+// destination type, as allowed by the WithNarrowing option.
+func (r *Reader) Narrowed() bool {
+	return r.narrowed
+}
+
+// WithStrictKeys configures a Reader to reject headers with unknown,
+// duplicate or missing keys.
+// The default is to ignore unknown keys, for forward compatibility with
+// newer versions of the format, to use the last value of duplicate keys,
+// as NumPy does, and to assume C-order when 'fortran_order' is missing.
+func WithStrictKeys() ReadOption {
+	return func(r *Reader) {
+		r.strictKeys = true
+	}
+}
+
+// WithPadShort configures a Reader to zero-fill the missing trailing
+// elements of an array whose data section is shorter than announced by
+// its header, e.g. a file left partially written by a crashed producer,
+// instead of failing.
+// Reader.Truncated then reports whether the data was zero-filled.
+//
+// This is a lossy recovery mode: zero-filled elements are
+// indistinguishable from genuine zeros.
+// The default is to fail on short data sections.
+func WithPadShort() ReadOption {
+	return func(r *Reader) {
+		r.padShort = true
+	}
+}
+
+// WithDtypeHint sets the NumPy data type descriptor (e.g. '<i8') of the
+// values Read stores into an interface{} destination.
+// The array data is converted to the Go type of the hint, which must
+// either match the on-disk data type or be a lossless widening of it (see
+// WithConvert); Read fails with ErrTypeMismatch otherwise.
+// The default is to use the on-disk data type.
+func WithDtypeHint(descr string) ReadOption {
+	return func(r *Reader) {
+		r.hint = descr
+	}
+}
+
+// WithStrict configures a Reader to fail with ErrInvalidNumPyFormat when
+// the array data is followed by trailing bytes, e.g. for files damaged by
+// a bad concatenation.
+// The check is done once Read has consumed the whole array data, by
+// reading one more byte from the underlying reader: on streams (pipes,
+// network connections, ...), Read thus blocks until the end of the stream
+// or until another byte is available.
+// The default is to ignore trailing bytes, which allows reading arrays
+// embedded in larger streams or followed by a checksum footer (see
+// WriteChecksummed).
+func WithStrict() ReadOption {
+	return func(r *Reader) {
+		r.strict = true
+	}
 }
 
 // NewReader creates a new NumPy data file format reader.
-func NewReader(r io.Reader) (*Reader, error) {
-	rr := &Reader{r: r}
+//
+// The Reader never reads past the end of the array data, unless the
+// WithStrict option is set: r may be positioned at a NumPy array embedded
+// within a larger stream (e.g. a tar entry), and left positioned right
+// after that array once the data has been read.
+// Object arrays ('|O') are the exception: their size is not known from the
+// header, so their data is read until the end of r, which must then only
+// hold that array, e.g. an io.LimitReader or an io.SectionReader.
+func NewReader(r io.Reader, opts ...ReadOption) (*Reader, error) {
+	rr := &Reader{r: r, src: r}
+	for _, opt := range opts {
+		opt(rr)
+	}
 	rr.readHeader()
 	if rr.err != nil {
-		return nil, rr.err
+		return nil, wrapErr("header", rr.err)
+	}
+	n, err := dataSize(rr.Header)
+	switch {
+	case err == nil:
+		rr.size = n
+	case rr.padShort || rr.strict:
+		return nil, wrapErr("header", err)
+	default:
+		// e.g. arrays of Python objects, whose elements have no fixed size.
+		rr.size = -1
 	}
+	if rr.padShort {
+		rr.pad = &shortReader{r: rr.r, n: rr.size}
+		rr.r = rr.pad
+	}
+	rr.count = &tallyReader{r: rr.r}
+	rr.r = rr.count
 	return rr, rr.err
 }
 
+// Truncated reports whether the data section of the array was shorter than
+// announced by its header and has been zero-filled.
+// Truncated always reports false unless the WithPadShort option is set.
+func (r *Reader) Truncated() bool {
+	return r.pad != nil && r.pad.short
+}
+
+// dataSize returns the size in bytes of the data section of the array
+// described by hdr.
+func dataSize(hdr Header) (int64, error) {
+	size, err := itemsizeFrom(hdr.Descr.Type)
+	if err != nil {
+		if rec, err := newRecType(hdr.Descr.Type); err == nil {
+			size = rec.size
+		} else {
+			dt, err := newDtype(hdr.Descr.Type)
+			if err != nil {
+				return 0, err
+			}
+			size = dt.size
+		}
+	}
+	n, ok := numElems64(hdr.Descr.Shape)
+	if !ok || (size > 0 && n > math.MaxInt64/int64(size)) {
+		return 0, fmt.Errorf("npy: array data of shape %v too large: %w", hdr.Descr.Shape, ErrInvalidNumPyFormat)
+	}
+	return n * int64(size), nil
+}
+
+// tallyReader counts the bytes read from r.
+type tallyReader struct {
+	r io.Reader
+	n int64 // number of bytes read
+}
+
+func (r *tallyReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// checkTrailing returns an error if the array data, once fully read, is
+// followed by trailing bytes in the underlying reader.
+func (r *Reader) checkTrailing() error {
+	if !r.strict || r.trailed || r.count.n != r.size {
+		return nil
+	}
+	r.trailed = true
+
+	var buf [1]byte
+	n, err := io.ReadFull(r.src, buf[:])
+	switch {
+	case n > 0:
+		return fmt.Errorf("npy: %d bytes of array data followed by trailing data: %w", r.size, ErrInvalidNumPyFormat)
+	case err != io.EOF:
+		return err
+	}
+	return nil
+}
+
+// checkSize checks, before any array data is read, that the underlying
+// reader holds the whole array data, when its length is known (e.g. for
+// regular files and bytes.Reader).
+// Readers of unknown length are checked as their data is read.
+func (r *Reader) checkSize() error {
+	if r.sized || r.pad != nil || r.size <= 0 || r.count.n != 0 {
+		return nil
+	}
+	r.sized = true
+
+	n, ok, err := remaining(r.src)
+	switch {
+	case err != nil:
+		return err
+	case ok && n < r.size:
+		return r.short(n)
+	}
+	return nil
+}
+
+// short returns the error reporting that only n bytes of array data are
+// available.
+func (r *Reader) short(n int64) error {
+	return fmt.Errorf(
+		"npy: payload too short: expected %d bytes, got %d: %w",
+		r.size, n, io.ErrUnexpectedEOF,
+	)
+}
+
+// remaining returns the number of bytes left to read from r, and whether
+// that number could be determined without reading from r.
+func remaining(r io.Reader) (int64, bool, error) {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		// bytes.Reader, bytes.Buffer, strings.Reader.
+		return int64(r.Len()), true, nil
+
+	case *os.File:
+		fi, err := r.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return 0, false, nil
+		}
+		cur, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false, nil
+		}
+		return fi.Size() - cur, true, nil
+
+	case io.Seeker:
+		cur, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false, nil
+		}
+		end, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false, nil
+		}
+		_, err = r.Seek(cur, io.SeekStart)
+		if err != nil {
+			return 0, false, fmt.Errorf("npy: could not seek back to array data: %w", err)
+		}
+		return end - cur, true, nil
+	}
+	return 0, false, nil
+}
+
+// shortReader reads n bytes from r, zero-filling them once r is exhausted.
+type shortReader struct {
+	r     io.Reader
+	n     int64 // number of bytes left
+	short bool  // whether r was exhausted before n bytes were read
+}
+
+func (r *shortReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.n {
+		p = p[:r.n]
+	}
+	if !r.short {
+		n, err := r.r.Read(p)
+		r.n -= int64(n)
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			r.short = r.n > 0
+		case err != nil:
+			return n, err
+		}
+		if n > 0 || !r.short {
+			return n, nil
+		}
+	}
+	for i := range p {
+		p[i] = 0
+	}
+	r.n -= int64(len(p))
+	return len(p), nil
+}
+
 func (r *Reader) readHeader() {
 	if r.err != nil {
 		return
@@ -89,81 +507,164 @@ func (r *Reader) readHeader() {
 		var v uint16
 		r.readAny(&v)
 		hdrLen = int(v)
-	case 2:
+		r.data = int64(len(Magic) + 2 + 2 + hdrLen)
+	case 2, 3:
 		var v uint32
 		r.readAny(&v)
 		hdrLen = int(v)
+		r.data = int64(len(Magic) + 2 + 4 + hdrLen)
 	default:
-		r.err = fmt.Errorf("npy: invalid major version number (%d)", r.Header.Major)
+		r.err = &HeaderError{
+			Offset: int64(len(Magic)),
+			Msg:    fmt.Sprintf("invalid major version number (%d)", r.Header.Major),
+		}
 	}
 
 	if r.err != nil {
 		return
 	}
 
+	off := r.data - int64(hdrLen)
 	hdr := make([]byte, hdrLen)
-	r.readAny(&hdr)
-	idx := bytes.LastIndexByte(hdr, '\n')
-	hdr = hdr[:idx]
-	r.readDescr(hdr)
+	n, err := io.ReadFull(r.r, hdr)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		r.err = &HeaderError{
+			Offset: off + int64(n),
+			Header: fragment(hdr[:n], n),
+			Msg:    fmt.Sprintf("truncated header (%d bytes, want %d)", n, hdrLen),
+			Err:    err,
+		}
+		return
+	}
+	if idx := bytes.LastIndexByte(hdr, '\n'); idx >= 0 {
+		hdr = hdr[:idx]
+	}
+	r.readDescr(hdr, off)
 }
 
-func (r *Reader) readDescr(buf []byte) {
+// readDescr parses the header dict buf, which starts at offset off in the
+// file.
+func (r *Reader) readDescr(buf []byte, off int64) {
 	if r.err != nil {
 		return
 	}
 
-	var (
-		descrKey = []byte("'descr': ")
-		orderKey = []byte("'fortran_order': ")
-		shapeKey = []byte("'shape': ")
-		trailer  = []byte(", ")
-	)
+	errorf := func(format string, args ...interface{}) {
+		r.err = &HeaderError{
+			Offset: off,
+			Header: string(bytes.TrimSpace(buf)),
+			Msg:    fmt.Sprintf(format, args...),
+		}
+	}
 
-	begDescr := bytes.Index(buf, descrKey)
-	begOrder := bytes.Index(buf, orderKey)
-	begShape := bytes.Index(buf, shapeKey)
-	endDescr := bytes.Index(buf, []byte("}"))
-	if begDescr < 0 || begOrder < 0 || begShape < 0 {
-		r.err = fmt.Errorf("npy: invalid dictionary format")
+	p := literal{buf: buf}
+	dict, err := p.dict()
+	if err != nil {
+		var herr *HeaderError
+		if errors.As(err, &herr) {
+			herr.Offset += off
+		}
+		r.err = err
 		return
 	}
 
-	descr := string(buf[begDescr+len(descrKey)+1 : begOrder-len(trailer)-1])
-	order := string(buf[begOrder+len(orderKey) : begShape-len(trailer)])
-	shape := buf[begShape+len(shapeKey) : endDescr-len(trailer)]
+	seen := make(map[string]bool, len(dict))
+	for _, it := range dict {
+		if seen[it.key] && r.strictKeys {
+			errorf("duplicate key %q", it.key)
+			return
+		}
+		seen[it.key] = true
+
+		switch it.key {
+		case "descr":
+			switch v := it.val.(type) {
+			case string:
+				// some writers pad the descriptor with spaces.
+				r.Header.Descr.Type = strings.TrimSpace(v)
+			case []interface{}, []item:
+				r.Header.Descr.Type = repr(v)
+			default:
+				errorf("invalid 'descr' value (%v)", repr(it.val))
+				return
+			}
 
-	r.Header.Descr.Type = descr // FIXME(sbinet): better handling
-	switch order {
-	case "False":
-		r.Header.Descr.Fortran = false
-	case "True":
-		r.Header.Descr.Fortran = true
-	default:
-		r.err = fmt.Errorf("npy: invalid 'fortran_order' value (%v)", order)
-		return
-	}
+		case "fortran_order":
+			v, ok := it.val.(bool)
+			if !ok {
+				errorf("invalid 'fortran_order' value (%v)", repr(it.val))
+				return
+			}
+			r.Header.Descr.Fortran = v
 
-	if string(shape) == "()" {
-		r.Header.Descr.Shape = nil
-		return
+		case "shape":
+			v, ok := it.val.(tuple)
+			if !ok {
+				errorf("invalid 'shape' value (%v)", repr(it.val))
+				return
+			}
+			r.Header.Descr.Shape = nil
+			for _, dim := range v {
+				i, ok := dim.(int)
+				if !ok || i < 0 {
+					errorf("invalid 'shape' value (%v)", repr(it.val))
+					return
+				}
+				r.Header.Descr.Shape = append(r.Header.Descr.Shape, i)
+			}
+			if _, ok := numElems64(r.Header.Descr.Shape); !ok {
+				errorf("too many elements in 'shape' value (%v)", repr(it.val))
+				return
+			}
+
+		default:
+			if r.strictKeys {
+				errorf("unknown key %q", it.key)
+				return
+			}
+		}
 	}
 
-	shape = shape[1 : len(shape)-1]
-	toks := strings.Split(string(shape), ",")
-	for _, tok := range toks {
-		tok = strings.TrimSpace(tok)
-		if tok == "" {
+	for _, key := range []string{"descr", "fortran_order", "shape"} {
+		if key == "fortran_order" && !r.strictKeys {
+			// some third-party writers omit the memory layout.
+			// NumPy defaults to C-order in that case.
 			continue
 		}
-		i, err := strconv.Atoi(tok)
-		if err != nil {
-			r.err = err
+		if !seen[key] {
+			errorf("missing %q key", key)
 			return
 		}
-		r.Header.Descr.Shape = append(r.Header.Descr.Shape, int(i))
 	}
+}
 
+// ReadHeader reads the header of the NumPy data file from r, leaving r
+// positioned at the start of the array data.
+// ReadHeader does not read any of the array data.
+//
+// ReadHeader returns ErrInvalidNumPyFormat if r does not hold a NumPy data
+// file.
+func ReadHeader(r io.Reader) (Header, error) {
+	rr, err := NewReader(r)
+	if err != nil {
+		return Header{}, err
+	}
+	return rr.Header, nil
+}
+
+// ReadHeaderAt reads the header of a NumPy data file starting at offset off
+// in r.
+// ReadHeaderAt returns the header and the offset in r where the array data
+// starts.
+func ReadHeaderAt(r io.ReaderAt, off int64) (Header, int64, error) {
+	rr, err := NewReader(io.NewSectionReader(r, off, math.MaxInt64-off))
+	if err != nil {
+		return Header{}, 0, err
+	}
+	return rr.Header, off + rr.data, nil
 }
 
 // Read reads the numpy-array data from the underlying NumPy file.
@@ -172,6 +673,47 @@ func (r *Reader) readDescr(buf []byte) {
 //
 // See npy.Read() for documentation.
 func (r *Reader) Read(ptr interface{}) error {
+	err := r.checkSize()
+	if err != nil {
+		return wrapErr("read", err)
+	}
+	err = r.readPtr(ptr)
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		if r.size >= 0 && r.count.n < r.size {
+			err = r.short(r.count.n)
+		}
+	case errors.Is(err, ErrTypeMismatch) || errors.Is(err, errNoConv):
+		err = r.mismatch(ptr, err)
+	case err == nil || err == io.EOF:
+		if e := r.checkTrailing(); e != nil {
+			err = e
+		}
+	}
+	return wrapErr("read", err)
+}
+
+// mismatch returns the error describing why the array can not be read
+// into ptr, from the type mismatch err.
+func (r *Reader) mismatch(ptr interface{}, err error) error {
+	var merr *MismatchError
+	if errors.As(err, &merr) {
+		// reported while reading the elements of ptr.
+		err = merr.Err
+	}
+	if err == ErrTypeMismatch || err == errNoConv {
+		err = nil
+	}
+	return &MismatchError{
+		Descr: r.Header.Descr.Type,
+		Shape: r.Header.Descr.Shape,
+		Count: numElems(r.Header.Descr.Shape),
+		Type:  reflect.TypeOf(ptr).Elem(),
+		Err:   err,
+	}
+}
+
+func (r *Reader) readPtr(ptr interface{}) error {
 	if r.err != nil {
 		return r.err
 	}
@@ -185,13 +727,64 @@ func (r *Reader) Read(ptr interface{}) error {
 		return errNilPtr
 	}
 
+	descr := r.Header.Descr.Type
+	if kindOfDescr(descr) == Object {
+		return r.readObjects(rv.Elem())
+	}
+	if order, ok := bfloat16Order(descr); ok && r.bf16 {
+		return r.readBFloat16(ptr, order)
+	}
+	if ext, ok := lookupDescr(descr); ok {
+		return r.readExt(rv.Elem(), ext)
+	}
+	if isRecDescr(descr) {
+		c, ok := complexDescr(descr)
+		if !ok || !isComplexElem(rv.Type()) {
+			return r.readRecords(rv.Elem())
+		}
+		// (real, imag) records share the memory layout of complex data.
+		descr = c
+	}
+
 	nelems := numElems(r.Header.Descr.Shape)
-	dt, err := newDtype(r.Header.Descr.Type)
+	dt, err := newDtype(descr)
 	if err != nil {
 		return err
 	}
 	r.order = dt.order
 
+	if rv.Elem().Kind() == reflect.Slice && rv.Elem().Len() == 0 {
+		err := r.checkMem(dt.size)
+		if err != nil {
+			return err
+		}
+	}
+
+	if vptr, ok := ptr.(*interface{}); ok {
+		return r.readDynamic(vptr, dt, nelems)
+	}
+	if vptr, ok := ptr.(*ComplexParts); ok {
+		return r.readParts(vptr, dt)
+	}
+
+	if vptr, ok := ptr.(*[][]byte); ok && dt.rt == stringType && !dt.utf {
+		return r.readByteStrings(vptr, dt, nelems)
+	}
+	if isNested(rv.Elem().Type()) {
+		return r.readNested(rv.Elem())
+	}
+
+	if r.convert {
+		et := elemType(rv.Elem().Type())
+		switch {
+		case canWiden(dt.rt, et), canCheck(dt.rt, et):
+			return r.readReflect(rv.Elem(), dt, nelems)
+		case r.narrow && canNarrow(dt.rt, et):
+			r.narrowed = true
+			return r.readReflect(rv.Elem(), dt, nelems)
+		}
+	}
+
 	switch vptr := ptr.(type) {
 	case *int, *uint, *[]int, *[]uint:
 		return ErrInvalidType
@@ -208,6 +801,12 @@ func (r *Reader) Read(ptr interface{}) error {
 			r.err = err
 			return r.err
 		}
+		if len(r.Header.Descr.Shape) == 1 && r.vector == RowVector {
+			nrows, ncols = ncols, nrows
+		}
+		if nrows == 0 || ncols == 0 {
+			return fmt.Errorf("npy: can not load empty array into a matrix: %w", errDims)
+		}
 		if r.Header.Descr.Fortran {
 			*vptr = *mat.NewDense(nrows, ncols, nil)
 			i := 0
@@ -232,23 +831,16 @@ func (r *Reader) Read(ptr interface{}) error {
 			r.err = err
 			return r.err
 		}
-		switch buf[0] {
-		case 0:
-			*vptr = false
-		case 1:
-			*vptr = true
-		}
+		// as with NumPy, any non-zero byte is true.
+		*vptr = buf[0] != 0
 		return r.err
 
 	case *[]bool:
 		if dt.rt != boolType {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]bool, n)
-		}
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
 		var buf [1]byte
 		for i := 0; i < n; i++ {
 			_, err := r.read(buf[:])
@@ -256,12 +848,7 @@ func (r *Reader) Read(ptr interface{}) error {
 				r.err = err
 				return r.err
 			}
-			switch buf[0] {
-			case 0:
-				(*vptr)[i] = false
-			case 1:
-				(*vptr)[i] = true
-			}
+			(*vptr)[i] = buf[0] != 0
 		}
 		return r.err
 
@@ -282,21 +869,9 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != int8Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]int8, n)
-		}
-		var buf [1]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = int8(buf[0])
-		}
-		return r.err
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		return readRaw(r, (*vptr)[:n])
 
 	case *int16:
 		if dt.rt != int16Type {
@@ -315,21 +890,14 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != int16Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]int16, n)
-		}
-		var buf [2]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = int16(dt.order.Uint16(buf[:]))
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 2, func(b []byte) int16 {
+			return int16(dt.order.Uint16(b))
+		})
 
 	case *int32:
 		if dt.rt != int32Type {
@@ -348,24 +916,17 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != int32Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]int32, n)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		var buf [4]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = int32(dt.order.Uint32(buf[:]))
-		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 4, func(b []byte) int32 {
+			return int32(dt.order.Uint32(b))
+		})
 
 	case *int64:
-		if dt.rt != int64Type {
+		if dt.rt != int64Type && dt.unit == "" {
 			return ErrTypeMismatch
 		}
 		var buf [8]byte
@@ -378,24 +939,17 @@ func (r *Reader) Read(ptr interface{}) error {
 		return r.err
 
 	case *[]int64:
-		if dt.rt != int64Type {
+		if dt.rt != int64Type && dt.unit == "" {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]int64, n)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		var buf [8]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = int64(dt.order.Uint64(buf[:]))
-		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 8, func(b []byte) int64 {
+			return int64(dt.order.Uint64(b))
+		})
 
 	case *uint8:
 		if dt.rt != uint8Type {
@@ -414,21 +968,9 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != uint8Type {
 			return ErrTypeMismatch
 		}
-		var buf [1]byte
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]uint8, n)
-		}
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = buf[0]
-		}
-		return r.err
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		return readRaw(r, (*vptr)[:n])
 
 	case *uint16:
 		if dt.rt != uint16Type {
@@ -447,21 +989,14 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != uint16Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]uint16, n)
-		}
-		var buf [2]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = dt.order.Uint16(buf[:])
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 2, func(b []byte) uint16 {
+			return dt.order.Uint16(b)
+		})
 
 	case *uint32:
 		if dt.rt != uint32Type {
@@ -480,21 +1015,14 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != uint32Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]uint32, n)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		var buf [4]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = dt.order.Uint32(buf[:])
-		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 4, func(b []byte) uint32 {
+			return dt.order.Uint32(b)
+		})
 
 	case *uint64:
 		if dt.rt != uint64Type {
@@ -513,23 +1041,86 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != uint64Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]uint64, n)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		var buf [8]byte
+		return readBatch(r, (*vptr)[:n], 8, func(b []byte) uint64 {
+			return dt.order.Uint64(b)
+		})
+
+	case *float16.Num:
+		if dt.rt != float16Type {
+			return ErrTypeMismatch
+		}
+		var buf [2]byte
+		_, err := r.read(buf[:])
+		if err != nil && err != io.EOF {
+			r.err = err
+			return r.err
+		}
+		*vptr = float16.Num(dt.order.Uint16(buf[:]))
+		return r.err
+
+	case *[]float16.Num:
+		if dt.rt != float16Type {
+			return ErrTypeMismatch
+		}
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		var buf [2]byte
 		for i := 0; i < n; i++ {
 			_, err := r.read(buf[:])
 			if err != nil && err != io.EOF {
 				r.err = err
 				return r.err
 			}
-			(*vptr)[i] = dt.order.Uint64(buf[:])
+			(*vptr)[i] = float16.Num(dt.order.Uint16(buf[:]))
 		}
 		return r.err
 
+	case *time.Time:
+		if dt.rt != timeType {
+			return ErrTypeMismatch
+		}
+		var v [1]time.Time
+		err := r.readTimes(v[:], dt)
+		*vptr = v[0]
+		return err
+
+	case *[]time.Time:
+		if dt.rt != timeType {
+			return ErrTypeMismatch
+		}
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		return r.readTimes((*vptr)[:n], dt)
+
+	case *time.Duration:
+		if dt.rt != durationType {
+			return ErrTypeMismatch
+		}
+		var v [1]time.Duration
+		err := r.readDurations(v[:], dt)
+		*vptr = v[0]
+		return err
+
+	case *[]time.Duration:
+		if dt.rt != durationType {
+			return ErrTypeMismatch
+		}
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		return r.readDurations((*vptr)[:n], dt)
+
 	case *float32:
+		if dt.rt == float16Type {
+			var v float16.Num
+			err := r.Read(&v)
+			*vptr = v.Float32()
+			return err
+		}
 		if dt.rt != float32Type {
 			return ErrTypeMismatch
 		}
@@ -543,26 +1134,30 @@ func (r *Reader) Read(ptr interface{}) error {
 		return r.err
 
 	case *[]float32:
-		if dt.rt != float32Type {
+		if dt.rt != float32Type && dt.rt != float16Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]float32, n)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.rt == float16Type {
+			return readBatch(r, (*vptr)[:n], 2, func(b []byte) float32 {
+				return float16.Num(dt.order.Uint16(b)).Float32()
+			})
 		}
-		var buf [4]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = math.Float32frombits(dt.order.Uint32(buf[:]))
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 4, func(b []byte) float32 {
+			return math.Float32frombits(dt.order.Uint32(b))
+		})
 
 	case *float64:
+		if dt.rt == float16Type {
+			var v float16.Num
+			err := r.Read(&v)
+			*vptr = v.Float64()
+			return err
+		}
 		if dt.rt != float64Type {
 			return ErrTypeMismatch
 		}
@@ -576,24 +1171,22 @@ func (r *Reader) Read(ptr interface{}) error {
 		return r.err
 
 	case *[]float64:
-		if dt.rt != float64Type {
+		if dt.rt != float64Type && dt.rt != float16Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]float64, n)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.rt == float16Type {
+			return readBatch(r, (*vptr)[:n], 2, func(b []byte) float64 {
+				return float16.Num(dt.order.Uint16(b)).Float64()
+			})
 		}
-		var buf [8]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			(*vptr)[i] = math.Float64frombits(dt.order.Uint64(buf[:]))
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 8, func(b []byte) float64 {
+			return math.Float64frombits(dt.order.Uint64(b))
+		})
 
 	case *complex64:
 		if dt.rt != complex64Type {
@@ -614,23 +1207,17 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != complex64Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]complex64, n)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		var buf [8]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			rcplx := math.Float32frombits(dt.order.Uint32(buf[0:4]))
-			icplx := math.Float32frombits(dt.order.Uint32(buf[4:8]))
-			(*vptr)[i] = complex(rcplx, icplx)
-		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 8, func(b []byte) complex64 {
+			return complex(
+				math.Float32frombits(dt.order.Uint32(b[0:4])),
+				math.Float32frombits(dt.order.Uint32(b[4:8])),
+			)
+		})
 
 	case *complex128:
 		if dt.rt != complex128Type {
@@ -651,23 +1238,17 @@ func (r *Reader) Read(ptr interface{}) error {
 		if dt.rt != complex128Type {
 			return ErrTypeMismatch
 		}
-		n := min(len(*vptr), nelems)
-		if n == 0 {
-			n = nelems
-			*vptr = make([]complex128, n)
-		}
-		var buf [16]byte
-		for i := 0; i < n; i++ {
-			_, err := r.read(buf[:])
-			if err != nil && err != io.EOF {
-				r.err = err
-				return r.err
-			}
-			rcplx := math.Float64frombits(dt.order.Uint64(buf[0:8]))
-			icplx := math.Float64frombits(dt.order.Uint64(buf[8:16]))
-			(*vptr)[i] = complex(rcplx, icplx)
+		*vptr = resizeSlice(*vptr, nelems)
+		n := len(*vptr)
+		if dt.order == nativeEndian {
+			return readRaw(r, (*vptr)[:n])
 		}
-		return r.err
+		return readBatch(r, (*vptr)[:n], 16, func(b []byte) complex128 {
+			return complex(
+				math.Float64frombits(dt.order.Uint64(b[0:8])),
+				math.Float64frombits(dt.order.Uint64(b[8:16])),
+			)
+		})
 
 	case *string:
 		if dt.rt != stringType {
@@ -676,18 +1257,12 @@ func (r *Reader) Read(ptr interface{}) error {
 
 		switch {
 		case dt.utf:
-			raw, err := io.ReadAll(io.LimitReader(r.r, utf8.UTFMax*int64(dt.size)))
+			raw := make([]byte, dt.size)
+			_, err := r.read(raw)
 			if err != nil {
-				r.err = err
 				return r.err
 			}
-			var str string
-			for len(raw) > 0 {
-				r, size := utf8.DecodeRune(raw)
-				str += string(r)
-				raw = raw[size:]
-			}
-			*vptr = str
+			*vptr = decodeUCS4(raw, dt.order)
 			return r.err
 
 		case !dt.utf:
@@ -696,31 +1271,79 @@ func (r *Reader) Read(ptr interface{}) error {
 				r.err = err
 				return r.err
 			}
-			n := bytes.Index(buf, []byte{0})
-			if n > 0 {
-				buf = buf[:n]
-			}
-			*vptr = string(buf)
+			*vptr = string(r.trimNULs(buf))
 			return r.err
 		}
 	}
 
-	rv = reflect.Indirect(rv)
+	return r.readReflect(reflect.Indirect(rv), dt, nelems)
+}
+
+// readDynamic reads the array into ptr, as a scalar for 0-dimensional
+// arrays and as a slice otherwise.
+// The Go type of the elements is given by the dtype hint, if any, or by
+// the on-disk data type dt.
+func (r *Reader) readDynamic(ptr *interface{}, dt dType, nelems int) error {
+	err := r.checkMem(dt.size)
+	if err != nil {
+		return err
+	}
+
+	rt := dt.rt
+	if r.hint != "" {
+		ht, err := TypeOf(r.hint)
+		if err != nil {
+			return err
+		}
+		if ht != dt.rt && !canWiden(dt.rt, ht) {
+			return fmt.Errorf(
+				"npy: dtype hint %q conflicts with array dtype %q: %w",
+				r.hint, r.Header.Descr.Type, ErrTypeMismatch,
+			)
+		}
+		rt = ht
+	}
+	if len(r.Header.Descr.Shape) > 0 {
+		rt = reflect.SliceOf(rt)
+	}
+
+	v := reflect.New(rt)
+	switch {
+	case elemType(rt) == dt.rt:
+		err = r.Read(v.Interface())
+	default:
+		err = r.readReflect(v.Elem(), dt, nelems)
+	}
+	if err != nil {
+		return err
+	}
+	*ptr = v.Elem().Interface()
+	return nil
+}
+
+// readReflect reads nelems elements of type dt into the value rv,
+// converting them to the Go type of rv.
+func (r *Reader) readReflect(rv reflect.Value, dt dType, nelems int) error {
 	switch rv.Kind() {
 	case reflect.Slice:
-		rv.SetLen(0)
 		elt := rv.Type().Elem()
+		if !canConvert(dt.rt, elt) {
+			return errNoConv
+		}
+		n := resizeValue(rv, nelems)
 		v := reflect.New(dt.rt).Elem()
-		slice := rv
-		for i := 0; i < nelems; i++ {
+		for i := 0; i < n; i++ {
 			err := r.Read(v.Addr().Interface())
 			if err != nil && err != io.EOF {
 				r.err = err
 				return r.err
 			}
-			slice = reflect.Append(slice, v.Convert(elt))
+			e, err := convertValue(v, elt)
+			if err != nil {
+				return err
+			}
+			rv.Index(i).Set(e)
 		}
-		rv.Set(slice)
 		return r.err
 
 	case reflect.Array:
@@ -729,6 +1352,9 @@ func (r *Reader) Read(ptr interface{}) error {
 		}
 
 		elt := rv.Type().Elem()
+		if !canConvert(dt.rt, elt) {
+			return errNoConv
+		}
 		v := reflect.New(dt.rt).Elem()
 		for i := 0; i < nelems; i++ {
 			err := r.Read(v.Addr().Interface())
@@ -736,7 +1362,11 @@ func (r *Reader) Read(ptr interface{}) error {
 				r.err = err
 				return r.err
 			}
-			rv.Index(i).Set(v.Convert(elt))
+			e, err := convertValue(v, elt)
+			if err != nil {
+				return err
+			}
+			rv.Index(i).Set(e)
 		}
 		return r.err
 
@@ -746,7 +1376,7 @@ func (r *Reader) Read(ptr interface{}) error {
 		}
 		var v [1]byte
 		r.read(v[:])
-		rv.SetBool(v[0] == 1)
+		rv.SetBool(v[0] != 0)
 		return r.err
 
 	case reflect.Int, reflect.Uint,
@@ -759,14 +1389,20 @@ func (r *Reader) Read(ptr interface{}) error {
 			return errNoConv
 		}
 		r.readAny(v.Addr().Interface())
-		rv.Set(v.Convert(rv.Type()))
-		return r.err
+		if r.err != nil {
+			return r.err
+		}
+		e, err := convertValue(v, rv.Type())
+		if err != nil {
+			return err
+		}
+		rv.Set(e)
+		return nil
 
-	case reflect.String, reflect.Map, reflect.Chan, reflect.Interface, reflect.Struct:
-		return fmt.Errorf("npy: type %v not supported", rv.Addr().Type())
+	default:
+		// pointers, functions, channels, maps, ...
+		return fmt.Errorf("npy: type %v not supported: %w", rv.Addr().Type(), ErrTypeMismatch)
 	}
-
-	panic("unreachable")
 }
 
 func dimsFromShape(shape []int) (int, int, error) {
@@ -809,21 +1445,89 @@ func (r *Reader) read(p []byte) (int, error) {
 	return n, r.err
 }
 
+// decodeUCS4 decodes the NUL-padded UCS-4 encoded string raw.
+// As with NumPy, trailing NULs are removed but embedded ones are kept.
+func decodeUCS4(raw []byte, order binary.ByteOrder) string {
+	n := len(raw) / 4
+	for n > 0 && order.Uint32(raw[4*(n-1):]) == 0 {
+		n--
+	}
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteRune(rune(order.Uint32(raw[4*i:])))
+	}
+	return sb.String()
+}
+
+// maxInt is the largest value of an int.
+const maxInt = int64(^uint(0) >> 1)
+
+// numElems returns the number of elements of an array with the provided
+// shape, capped to the largest int: arrays with more elements can only be
+// read in chunks.
 func numElems(shape []int) int {
-	n := 1
+	n, _ := numElems64(shape)
+	if n > maxInt {
+		return int(maxInt)
+	}
+	return int(n)
+}
+
+// numElems64 returns the number of elements of an array with the provided
+// shape, and whether that number fits in an int64.
+func numElems64(shape []int) (int64, bool) {
+	n := int64(1)
 	for _, v := range shape {
-		n *= v
+		if v != 0 && n > math.MaxInt64/int64(v) {
+			return math.MaxInt64, false
+		}
+		n *= int64(v)
 	}
-	return n
+	return n, true
+}
+
+// checkMem returns an error if the whole array data, made of elements of
+// type dt, can not be loaded in memory.
+func (r *Reader) checkMem(size int) error {
+	n, _ := numElems64(r.Header.Descr.Shape)
+	if n > maxInt || (size > 0 && n > maxInt/int64(size)) {
+		return fmt.Errorf(
+			"npy: array of %d elements of %d bytes does not fit in memory (read it in chunks with pre-sized slices or ReadToChan instead): %w",
+			n, size, ErrTooLargeForMemory,
+		)
+	}
+	if sz := n * int64(size); r.maxSz > 0 && sz > r.maxSz {
+		return fmt.Errorf(
+			"npy: array of %d bytes exceeds limit of %d bytes (read it in chunks with pre-sized slices or ReadToChan instead): %w",
+			sz, r.maxSz, ErrTooLargeForMemory,
+		)
+	}
+	return nil
 }
 
 // TypeFrom returns the reflect.Type corresponding to the numpy-dtype string, if any.
 func TypeFrom(dtype string) reflect.Type {
-	dt, err := newDtype(dtype)
+	rt, err := TypeOf(dtype)
 	if err != nil {
 		return nil
 	}
-	return dt.rt
+	return rt
+}
+
+// TypeOf returns the Go type of the elements Read decodes from arrays with
+// the provided NumPy data type descriptor (e.g. '<f8' gives float64).
+//
+// TypeOf returns an error wrapping ErrInvalidType if the descriptor is not
+// supported.
+func TypeOf(descr string) (reflect.Type, error) {
+	if ext, ok := lookupDescr(descr); ok {
+		return ext.rt, nil
+	}
+	dt, err := newDtype(descr)
+	if err != nil {
+		return nil, fmt.Errorf("npy: invalid descriptor %q: %w", descr, ErrInvalidType)
+	}
+	return dt.rt, nil
 }
 
 var (
@@ -865,6 +1569,36 @@ func stringLen(dtype string) (int, error) {
 	return 0, fmt.Errorf("npy: %q is not a string-like dtype", dtype)
 }
 
+// resizeSlice returns the slice to read an array of nelems elements into,
+// reusing the storage of s whenever possible:
+//   - a slice shorter than the array is returned as is, to read the array
+//     in chunks of len(s) elements,
+//   - a slice with enough capacity is resliced to exactly nelems elements,
+//   - a new slice of nelems elements is allocated otherwise.
+func resizeSlice[T any](s []T, nelems int) []T {
+	switch {
+	case len(s) > 0 && len(s) < nelems:
+		return s
+	case s != nil && cap(s) >= nelems:
+		return s[:nelems]
+	}
+	return make([]T, nelems)
+}
+
+// resizeValue resizes the slice rv as resizeSlice does, and returns the
+// number of elements to read into it.
+func resizeValue(rv reflect.Value, nelems int) int {
+	switch {
+	case rv.Len() > 0 && rv.Len() < nelems:
+		// read the array in chunks of len(rv) elements.
+	case !rv.IsNil() && rv.Cap() >= nelems:
+		rv.SetLen(nelems)
+	default:
+		rv.Set(reflect.MakeSlice(rv.Type(), nelems, nelems))
+	}
+	return rv.Len()
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -872,7 +1606,18 @@ func min(a, b int) int {
 	return b
 }
 
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func (r *Reader) ReadToChan(ptr *chan any) error {
+	return wrapErr("read", r.readToChan(ptr))
+}
+
+func (r *Reader) readToChan(ptr *chan any) error {
 	if r.err != nil {
 		return r.err
 	}
@@ -887,7 +1632,7 @@ func (r *Reader) ReadToChan(ptr *chan any) error {
 		return errNilPtr
 	}
 
-	nelems := numElems(r.Header.Descr.Shape)
+	nelems, _ := numElems64(r.Header.Descr.Shape)
 	dt, err := newDtype(r.Header.Descr.Type)
 	if err != nil {
 		return err
@@ -898,7 +1643,7 @@ func (r *Reader) ReadToChan(ptr *chan any) error {
 		return ErrTypeMismatch
 	}
 	var buf [8]byte
-	for i := 0; i < nelems; i++ {
+	for i := int64(0); i < nelems; i++ {
 		_, err := r.read(buf[:])
 		if err != nil && err != io.EOF {
 			r.err = err
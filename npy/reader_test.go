@@ -5,12 +5,20 @@
 package npy
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -72,6 +80,35 @@ func TestReaderDense(t *testing.T) {
 	}
 }
 
+func TestReaderDenseReuse(t *testing.T) {
+	// a matrix of a different size is replaced by the one read.
+	m := mat.NewDense(3, 3, []float64{9, 9, 9, 9, 9, 9, 9, 9, 9})
+	for _, tc := range []struct {
+		fname string
+		want  *mat.Dense
+	}{
+		{"../testdata/data_float64_2x3_forder.npy", mat.NewDense(2, 3, []float64{0, 2, 4, 1, 3, 5})},
+		{"../testdata/data_float64_2x3_corder.npy", mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5})},
+		{"../testdata/data_float64_6x1_corder.npy", mat.NewDense(6, 1, []float64{0, 1, 2, 3, 4, 5})},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			err = Read(f, m)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !mat.Equal(m, tc.want) {
+				t.Fatalf("invalid matrix:\ngot= %v\nwant=%v", mat.Formatted(m), mat.Formatted(tc.want))
+			}
+		})
+	}
+}
+
 func TestReaderSlice(t *testing.T) {
 	want := map[string]map[string]interface{}{
 		"float32": {
@@ -198,6 +235,124 @@ func TestReaderSlice(t *testing.T) {
 		}
 	}
 }
+func TestReadStrict(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, []int32{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+	junk := append(append([]byte(nil), raw...), 0xde, 0xad)
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		opts []ReadOption
+		err  error
+	}{
+		{"lenient-junk", junk, nil, nil},
+		{"strict", raw, []ReadOption{WithStrict()}, nil},
+		{"strict-junk", junk, []ReadOption{WithStrict()}, ErrInvalidNumPyFormat},
+		{"strict-pad-short", raw[:len(raw)-4], []ReadOption{WithStrict(), WithPadShort()}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []int32
+			err := Read(bytes.NewReader(tc.raw), &got, tc.opts...)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+			case err != nil:
+				t.Fatalf("could not read data: %+v", err)
+			}
+		})
+	}
+
+	t.Run("chunks", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(junk), WithStrict())
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		chunk := make([]int32, 2)
+		err = r.Read(&chunk)
+		if err != nil {
+			t.Fatalf("could not read first chunk: %+v", err)
+		}
+		err = r.Read(&chunk)
+		if !errors.Is(err, ErrInvalidNumPyFormat) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidNumPyFormat)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.Write(raw)
+			pw.Close()
+		}()
+		var got []int32
+		err := Read(pr, &got, WithStrict())
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if want := []int32{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data: got=%v, want=%v", got, want)
+		}
+	})
+}
+
+func TestReadScalar(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		want  interface{}
+	}{
+		{"../testdata/data_float64_scalar_corder.npy", float64(42)},
+		{"../testdata/data_int64_scalar_corder.npy", int64(42)},
+		{"../testdata/data_uint8_scalar_corder.npy", uint8(42)},
+		{"../testdata/data_complex64_scalar_corder.npy", complex64(3.5 - 1.25i)},
+		{"../testdata/data_complex128_scalar_corder.npy", complex128(3.5 - 1.25i)},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			raw, err := os.ReadFile(tc.fname)
+			if err != nil {
+				t.Fatalf("could not read file: %+v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			hdr, err := ReadWithHeader(bytes.NewReader(raw), got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := got.Elem().Interface(); got != tc.want {
+				t.Fatalf("invalid data: got=%v, want=%v", got, tc.want)
+			}
+			if len(hdr.Descr.Shape) != 0 {
+				t.Fatalf("invalid shape: got=%v, want=[]", hdr.Descr.Shape)
+			}
+
+			buf := new(bytes.Buffer)
+			err = Write(buf, tc.want)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+			if !strings.Contains(buf.String(), "'shape': (), ") {
+				t.Fatalf("invalid header: %q", buf.String())
+			}
+			payload := func(raw []byte) []byte {
+				r, err := NewReader(bytes.NewReader(raw))
+				if err != nil {
+					t.Fatalf("could not create reader: %+v", err)
+				}
+				return raw[r.data:]
+			}
+			if got, want := payload(buf.Bytes()), payload(raw); !bytes.Equal(got, want) {
+				t.Fatalf("invalid payload: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
 func TestReaderChan(t *testing.T) {
 	//want := map[string]map[string]interface{}{
 	//	"float32": {
@@ -323,8 +478,49 @@ func TestReaderNDimSlice(t *testing.T) {
 	}
 }
 
+func TestReaderDenseVector(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []ReadOption
+		want *mat.Dense
+	}{
+		{
+			name: "default",
+			want: mat.NewDense(1, 3, []float64{1, 2, 3}),
+		},
+		{
+			name: "col",
+			opts: []ReadOption{WithVector(ColVector)},
+			want: mat.NewDense(3, 1, []float64{1, 2, 3}),
+		},
+		{
+			name: "row",
+			opts: []ReadOption{WithVector(RowVector)},
+			want: mat.NewDense(1, 3, []float64{1, 2, 3}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, []float64{1, 2, 3})
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			var m mat.Dense
+			err = Read(buf, &m, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if !mat.Equal(&m, tc.want) {
+				t.Fatalf("invalid matrix:\ngot= %v\nwant=%v", mat.Formatted(&m), mat.Formatted(tc.want))
+			}
+		})
+	}
+}
+
 func TestReaderNaNsInf(t *testing.T) {
-	want := mat.NewDense(4, 1, []float64{math.NaN(), math.Inf(-1), 0, math.Inf(+1)})
+	want := mat.NewDense(1, 4, []float64{math.NaN(), math.Inf(-1), 0, math.Inf(+1)})
 	f, err := os.Open("../testdata/nans_inf.npy")
 	if err != nil {
 		t.Errorf("error: %v\n", err)
@@ -339,12 +535,12 @@ func TestReaderNaNsInf(t *testing.T) {
 
 	for i, v := range []bool{
 		math.IsNaN(m.At(0, 0)),
-		math.IsInf(m.At(1, 0), -1),
-		m.At(2, 0) == 0,
-		math.IsInf(m.At(3, 0), +1),
+		math.IsInf(m.At(0, 1), -1),
+		m.At(0, 2) == 0,
+		math.IsInf(m.At(0, 3), +1),
 	} {
 		if !v {
-			t.Errorf("read test m.At(%d,0) failed\n got=%#v\nwant=%#v\n", i, m.At(i, 0), want.At(i, 0))
+			t.Errorf("read test m.At(0,%d) failed\n got=%#v\nwant=%#v\n", i, m.At(0, i), want.At(0, i))
 		}
 	}
 }
@@ -516,3 +712,1258 @@ func TestStringLenDtype(t *testing.T) {
 		}
 	}
 }
+
+func TestReaderBigEndianComplex(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		want  interface{}
+	}{
+		{
+			fname: "../testdata/data_complex64_bigendian.npy",
+			want:  []complex64{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+		{
+			fname: "../testdata/data_complex64_bigendian.npy",
+			want:  [4]complex64{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+		{
+			fname: "../testdata/data_complex128_bigendian.npy",
+			want:  []complex128{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+		{
+			fname: "../testdata/data_complex128_bigendian.npy",
+			want:  [4]complex128{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = Read(f, got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestReaderBigEndian(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		want  interface{}
+	}{
+		{
+			fname: "../testdata/data_float64_2x3_bigendian.npy",
+			want:  []float64{0, 1, 2, 3, 4, 5},
+		},
+		{
+			fname: "../testdata/data_float64_2x3_bigendian.npy",
+			want:  [6]float64{0, 1, 2, 3, 4, 5},
+		},
+		{
+			fname: "../testdata/data_float64_2x3_bigendian.npy",
+			want:  *mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5}),
+		},
+		{
+			fname: "../testdata/data_int32_2x3_bigendian.npy",
+			want:  []int32{0, 1, -2, 3, -4, 1 << 30},
+		},
+		{
+			fname: "../testdata/data_int32_2x3_bigendian.npy",
+			want:  [6]int32{0, 1, -2, 3, -4, 1 << 30},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = Read(f, got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestReaderNativeOrder(t *testing.T) {
+	for _, tc := range []struct {
+		descr string
+		want  interface{}
+	}{
+		{descr: "=f8", want: []float64{0, 1, -2.5, 1e300}},
+		{descr: "=i4", want: []int32{0, 1, -2, 1 << 30}},
+		{descr: "=u2", want: []uint16{0, 1, 2, 1 << 15}},
+		{descr: "=c8", want: []complex64{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i}},
+	} {
+		t.Run(tc.descr, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWithDescr(buf, tc.want, tc.descr, []int{4})
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = Read(buf, got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestReaderMaxBytes(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, []float64{0, 1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	for _, tc := range []struct {
+		name string
+		max  int64
+		ptr  interface{}
+		err  error
+	}{
+		{name: "no-limit", max: 0, ptr: new([]float64)},
+		{name: "below-limit", max: 48, ptr: new([]float64)},
+		{name: "above-limit", max: 47, ptr: new([]float64), err: ErrTooLargeForMemory},
+		{name: "dense", max: 47, ptr: new(mat.Dense), err: ErrTooLargeForMemory},
+		{name: "presized", max: 16, ptr: func() *[]float64 { v := make([]float64, 2); return &v }()},
+		{name: "array", max: 16, ptr: new([6]float64)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(bytes.NewReader(raw), tc.ptr, WithMaxBytes(tc.max))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestReadHeader(t *testing.T) {
+	f, err := os.Open("../testdata/data_float64_2x3_corder.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	hdr, err := ReadHeader(f)
+	if err != nil {
+		t.Fatalf("could not read header: %+v", err)
+	}
+	if got, want := hdr.Descr.Type, "<f8"; got != want {
+		t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+	}
+	if got, want := hdr.Descr.Shape, []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+
+	pos, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		t.Fatalf("could not retrieve position: %+v", err)
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		t.Fatalf("could not stat file: %+v", err)
+	}
+	if got, want := stat.Size()-pos, int64(6*8); got != want {
+		t.Fatalf("invalid number of bytes left: got=%d, want=%d", got, want)
+	}
+
+	var data []float64
+	err = (&Reader{r: f, Header: hdr}).Read(&data)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if want := []float64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(data, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", data, want)
+	}
+
+	_, err = ReadHeader(strings.NewReader("not a NumPy data file"))
+	if !errors.Is(err, ErrInvalidNumPyFormat) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidNumPyFormat)
+	}
+}
+
+func TestReadWithHeader(t *testing.T) {
+	for _, tc := range []struct {
+		fname   string
+		fortran bool
+	}{
+		{"../testdata/data_float64_2x3_corder.npy", false},
+		{"../testdata/data_float64_2x3_forder.npy", true},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			var m mat.Dense
+			hdr, err := ReadWithHeader(f, &m)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got, want := hdr.Descr.Type, "<f8"; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+			if got, want := hdr.Descr.Fortran, tc.fortran; got != want {
+				t.Fatalf("invalid memory order: got=%v, want=%v", got, want)
+			}
+			if got, want := hdr.Descr.Shape, []int{2, 3}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+			if r, c := m.Dims(); r != 2 || c != 3 {
+				t.Fatalf("invalid dims: got=(%d, %d), want=(2, 3)", r, c)
+			}
+		})
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Write(buf, []int32{1, 2, 3})
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+		var data []float64
+		hdr, err := ReadWithHeader(buf, &data)
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+		}
+		if got, want := hdr.Descr.Type, "<i4"; got != want {
+			t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+		}
+	})
+}
+
+func TestReadHeaderAt(t *testing.T) {
+	var (
+		buf  = new(bytes.Buffer)
+		offs []int64
+		vals = []interface{}{
+			[]float64{1, 2, 3},
+			[]int8{1, 2},
+			uint16(42),
+		}
+	)
+	buf.WriteString("some container prefix")
+	for _, v := range vals {
+		offs = append(offs, int64(buf.Len()))
+		err := Write(buf, v)
+		if err != nil {
+			t.Fatalf("could not write %T: %+v", v, err)
+		}
+	}
+	raw := bytes.NewReader(buf.Bytes())
+
+	for i, off := range offs {
+		hdr, data, err := ReadHeaderAt(raw, off)
+		if err != nil {
+			t.Fatalf("could not read header #%d: %+v", i, err)
+		}
+
+		want := newHeader()
+		want.Descr.Type, _ = dtypeFrom(reflect.ValueOf(vals[i]), reflect.TypeOf(vals[i]))
+		want.Descr.Shape, _ = shapeFrom(reflect.ValueOf(vals[i]))
+		if !reflect.DeepEqual(hdr, want) {
+			t.Fatalf("invalid header #%d:\ngot= %v\nwant=%v", i, hdr, want)
+		}
+
+		got := reflect.New(reflect.TypeOf(vals[i]))
+		sr := io.NewSectionReader(raw, data, int64(buf.Len())-data)
+		err = (&Reader{r: sr, Header: hdr}).Read(got.Interface())
+		if err != nil {
+			t.Fatalf("could not read data #%d: %+v", i, err)
+		}
+		if got, want := got.Elem().Interface(), vals[i]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data #%d:\ngot= %v\nwant=%v", i, got, want)
+		}
+	}
+
+	_, _, err := ReadHeaderAt(raw, 0)
+	if !errors.Is(err, ErrInvalidNumPyFormat) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidNumPyFormat)
+	}
+}
+
+func TestReaderHeaderKeys(t *testing.T) {
+	for _, tc := range []struct {
+		fname  string
+		strict bool
+		err    bool
+	}{
+		{fname: "../testdata/data_float64_extrakey.npy"},
+		{fname: "../testdata/data_float64_extrakey.npy", strict: true, err: true},
+		{fname: "../testdata/data_float64_nofortran.npy"},
+		{fname: "../testdata/data_float64_nofortran.npy", strict: true, err: true},
+		{fname: "../testdata/data_float64_dupkey.npy"},
+		{fname: "../testdata/data_float64_dupkey.npy", strict: true, err: true},
+		{fname: "../testdata/data_float64_6x1_corder.npy", strict: true},
+	} {
+		t.Run(fmt.Sprintf("%s-strict=%v", tc.fname, tc.strict), func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", tc.fname, err)
+			}
+			defer f.Close()
+
+			var opts []ReadOption
+			if tc.strict {
+				opts = append(opts, WithStrictKeys())
+			}
+
+			var data []float64
+			err = Read(f, &data, opts...)
+			switch {
+			case tc.err && err == nil:
+				t.Fatalf("expected an error")
+			case tc.err:
+				return
+			case err != nil:
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if got := len(data); got == 0 {
+				t.Fatalf("no data read")
+			}
+		})
+	}
+}
+
+func TestReaderTar(t *testing.T) {
+	fnames := []string{
+		"../testdata/data_float64_2x3_corder.npy",
+		"../testdata/data_int16_scalar_corder.npy",
+		"../testdata/data_uint8_6x1_forder.npy",
+		"../testdata/data_complex64_bigendian.npy",
+		"../testdata/nans_inf.npy",
+	}
+
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for _, fname := range fnames {
+		raw, err := os.ReadFile(fname)
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", fname, err)
+		}
+		err = tw.WriteHeader(&tar.Header{
+			Name: fname,
+			Mode: 0644,
+			Size: int64(len(raw)),
+		})
+		if err != nil {
+			t.Fatalf("could not write tar header: %+v", err)
+		}
+		_, err = tw.Write(raw)
+		if err != nil {
+			t.Fatalf("could not write tar entry: %+v", err)
+		}
+	}
+	err := tw.Close()
+	if err != nil {
+		t.Fatalf("could not close tar writer: %+v", err)
+	}
+
+	tr := tar.NewReader(buf)
+	for _, fname := range fnames {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatalf("could not read tar header: %+v", err)
+		}
+		if hdr.Name != fname {
+			t.Fatalf("invalid tar entry: got=%q, want=%q", hdr.Name, fname)
+		}
+
+		// make sure the reader never requests bytes past the end of
+		// the entry.
+		r := &exactReader{r: tr, n: hdr.Size}
+		rr, err := NewReader(r)
+		if err != nil {
+			t.Fatalf("%s: could not create reader: %+v", fname, err)
+		}
+
+		ptr := reflect.New(reflect.SliceOf(TypeFrom(rr.Header.Descr.Type)))
+		err = rr.Read(ptr.Interface())
+		if err != nil {
+			t.Fatalf("%s: could not read data: %+v", fname, err)
+		}
+		if r.n != 0 {
+			t.Fatalf("%s: %d bytes left unread", fname, r.n)
+		}
+	}
+}
+
+// exactReader fails on reads requesting more than the n remaining bytes.
+type exactReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *exactReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.n {
+		return 0, fmt.Errorf("read past the end (%d bytes requested, %d remaining)", len(p), r.n)
+	}
+	n, err := r.r.Read(p)
+	r.n -= int64(n)
+	return n, err
+}
+
+func TestTypeOf(t *testing.T) {
+	for _, tc := range []struct {
+		descr string
+		want  reflect.Type
+	}{
+		{"<f8", reflect.TypeOf(float64(0))},
+		{">f4", reflect.TypeOf(float32(0))},
+		{"|u1", reflect.TypeOf(uint8(0))},
+		{"<i2", reflect.TypeOf(int16(0))},
+		{">c16", reflect.TypeOf(complex128(0))},
+		{"|b1", reflect.TypeOf(false)},
+		{"|S10", reflect.TypeOf("")},
+		{"<U5", reflect.TypeOf("")},
+		{"<M8[D]", reflect.TypeOf(time.Time{})},
+		{"<m8[ns]", reflect.TypeOf(time.Duration(0))},
+		{"<m8", nil},
+		{"", nil},
+	} {
+		t.Run(tc.descr, func(t *testing.T) {
+			got, err := TypeOf(tc.descr)
+			if tc.want == nil {
+				if !errors.Is(err, ErrInvalidType) {
+					t.Fatalf("invalid error: %+v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not get type: %+v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("invalid type: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReaderPaddedDescr(t *testing.T) {
+	f, err := os.Open("../testdata/data_float64_paddescr.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	if got, want := r.Header.Descr.Type, "<f8"; got != want {
+		t.Fatalf("invalid descr: got=%q, want=%q", got, want)
+	}
+
+	var data []float64
+	err = r.Read(&data)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if got, want := data, []float64{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data: got=%v, want=%v", got, want)
+	}
+}
+
+func TestReaderPadShort(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/data_float64_2x3_corder.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	short := raw[:len(raw)-16]
+
+	t.Run("strict", func(t *testing.T) {
+		var data []float64
+		err := Read(bytes.NewReader(short), &data)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(short), WithPadShort())
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		var data []float64
+		err = r.Read(&data)
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if got, want := data, []float64{0, 1, 2, 3, 0, 0}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data: got=%v, want=%v", got, want)
+		}
+		if !r.Truncated() {
+			t.Fatalf("data should be reported as truncated")
+		}
+	})
+
+	t.Run("dense", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(short), WithPadShort())
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		var m mat.Dense
+		err = r.Read(&m)
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		want := mat.NewDense(2, 3, []float64{0, 1, 2, 3, 0, 0})
+		if !mat.Equal(&m, want) {
+			t.Fatalf("invalid data:\ngot= %v\nwant=%v", mat.Formatted(&m), mat.Formatted(want))
+		}
+		if !r.Truncated() {
+			t.Fatalf("data should be reported as truncated")
+		}
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(raw), WithPadShort())
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		var data []float64
+		err = r.Read(&data)
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if got, want := data, []float64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data: got=%v, want=%v", got, want)
+		}
+		if r.Truncated() {
+			t.Fatalf("data should not be reported as truncated")
+		}
+	})
+}
+
+func TestReadTruncated(t *testing.T) {
+	const fname = "../testdata/data_float64_2x3_truncated.npy"
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	// stream hides the length of the underlying reader.
+	type stream struct{ io.Reader }
+
+	for _, tc := range []struct {
+		name string
+		src  func(t *testing.T) io.Reader
+		ptr  interface{}
+	}{
+		{
+			name: "file",
+			src: func(t *testing.T) io.Reader {
+				f, err := os.Open(fname)
+				if err != nil {
+					t.Fatalf("could not open file: %+v", err)
+				}
+				t.Cleanup(func() { f.Close() })
+				return f
+			},
+			ptr: new([]float64),
+		},
+		{
+			name: "bytes",
+			src:  func(*testing.T) io.Reader { return bytes.NewReader(raw) },
+			ptr:  new([]float64),
+		},
+		{
+			name: "seeker",
+			src: func(*testing.T) io.Reader {
+				return io.NewSectionReader(bytes.NewReader(raw), 0, int64(len(raw)))
+			},
+			ptr: new([]float64),
+		},
+		{
+			name: "stream-slice",
+			src:  func(*testing.T) io.Reader { return stream{bytes.NewReader(raw)} },
+			ptr:  new([]float64),
+		},
+		{
+			name: "stream-array",
+			src:  func(*testing.T) io.Reader { return stream{bytes.NewReader(raw)} },
+			ptr:  new([6]float64),
+		},
+		{
+			name: "stream-dense",
+			src:  func(*testing.T) io.Reader { return stream{bytes.NewReader(raw)} },
+			ptr:  new(mat.Dense),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(tc.src(t), tc.ptr)
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, io.ErrUnexpectedEOF)
+			}
+			if got, want := err.Error(), "payload too short: expected 48 bytes, got 32"; !strings.Contains(got, want) {
+				t.Fatalf("invalid error message: got=%q, want=%q", got, want)
+			}
+			var e *Error
+			if !errors.As(err, &e) || e.Kind != Truncated {
+				t.Fatalf("invalid error kind: got=%v, want=%v", err, Truncated)
+			}
+		})
+	}
+
+	t.Run("seeker-position", func(t *testing.T) {
+		src := io.NewSectionReader(bytes.NewReader(raw), 0, int64(len(raw)))
+		r, err := NewReader(src)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		var data []float64
+		_ = r.Read(&data)
+		pos, err := src.Seek(0, io.SeekCurrent)
+		if err != nil {
+			t.Fatalf("could not seek: %+v", err)
+		}
+		if got, want := pos, r.data; got != want {
+			t.Fatalf("invalid position: got=%d, want=%d", got, want)
+		}
+	})
+}
+
+func TestReaderUnicode(t *testing.T) {
+	want := []string{"hello", "wörld", "日本語テキスト", "", "exactly8"}
+
+	f, err := os.Open("../testdata/data_unicode_U8.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+
+	var got []string
+	err = r.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %q\nwant=%q", got, want)
+	}
+
+	buf := new(bytes.Buffer)
+	err = Write(buf, want)
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+
+	r, err = NewReader(buf)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	if got, want := r.Header.Descr.Type, "<U8"; got != want {
+		t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+	}
+
+	// read elements one at a time, to check element boundaries.
+	for i := range want {
+		var got string
+		err = r.Read(&got)
+		if err != nil {
+			t.Fatalf("could not read element #%d: %+v", i, err)
+		}
+		if got != want[i] {
+			t.Fatalf("invalid element #%d: got=%q, want=%q", i, got, want[i])
+		}
+	}
+}
+
+func TestReaderBytes(t *testing.T) {
+	f, err := os.Open("../testdata/data_bytes_S5.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	if got, want := r.Header.StringWidth(), 5; got != want {
+		t.Fatalf("invalid string width: got=%d, want=%d", got, want)
+	}
+
+	var got []string
+	err = r.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	// trailing NULs are removed, embedded ones are kept.
+	want := []string{"abc", "", "a\x00b", "hello", "\x00\x00x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %q\nwant=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		descr string
+		want  int
+	}{
+		{"<U8", 8},
+		{"|S1", 1},
+		{"<f8", 0},
+	} {
+		var hdr Header
+		hdr.Descr.Type = tc.descr
+		if got := hdr.StringWidth(); got != tc.want {
+			t.Fatalf("invalid string width for %q: got=%d, want=%d", tc.descr, got, tc.want)
+		}
+	}
+}
+
+func TestWriteStrings(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		descr string
+		want  []string
+	}{
+		{"slice", []string{"a", "日本語", ""}, "<U3", []string{"a", "日本語", ""}},
+		{"array", [2]string{"ab", "c"}, "<U2", []string{"ab", "c"}},
+		{"scalar", "wörld", "<U5", []string{"wörld"}},
+		{"nuls", []string{"a\x00b", "\x00"}, "<U3", []string{"a\x00b", ""}},
+		{"empty-string", []string{""}, "<U1", []string{""}},
+		{"empty-slice", []string{}, "<U1", []string{}},
+		{"astral", []string{"😀", "a😀"}, "<U2", []string{"😀", "a😀"}},
+		{"nested", [][]string{{"a", "bcé"}, {"😀x", ""}}, "<U3", []string{"a", "bcé", "😀x", ""}},
+		{"nested-array", [2][2]string{{"a", "bcé"}, {"😀x", ""}}, "<U3", []string{"a", "bcé", "😀x", ""}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Type, tc.descr; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+
+			var got []string
+			if len(r.Header.Descr.Shape) == 0 {
+				var v string
+				err = r.Read(&v)
+				got = []string{v}
+			} else {
+				err = r.Read(&got)
+			}
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %q\nwant=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnicode2D(t *testing.T) {
+	want, err := os.ReadFile("../testdata/data_unicode_2x2_U3.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	vals := [][]string{{"a", "bcé"}, {"😀x", ""}}
+
+	var got [][]string
+	err = Read(bytes.NewReader(want), &got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if !reflect.DeepEqual(got, vals) {
+		t.Fatalf("invalid data:\ngot= %q\nwant=%q", got, vals)
+	}
+
+	buf := new(bytes.Buffer)
+	err = Write(buf, vals)
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	if got := buf.Bytes(); !bytes.Equal(got, want) {
+		t.Fatalf("invalid file content:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+func TestReaderDtypeHint(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		hint string
+		want interface{}
+		err  error
+	}{
+		{name: "no-hint", val: []int32{1, 2, 3}, want: []int32{1, 2, 3}},
+		{name: "scalar", val: 2.5, want: 2.5},
+		{name: "same", val: []int32{1, 2, 3}, hint: "<i4", want: []int32{1, 2, 3}},
+		{name: "byte-order", val: []int32{1, 2, 3}, hint: ">i4", want: []int32{1, 2, 3}},
+		{name: "widen", val: []int32{1, 2, 3}, hint: "<i8", want: []int64{1, 2, 3}},
+		{name: "widen-float", val: []int16{-1, 0, 1}, hint: "<f8", want: []float64{-1, 0, 1}},
+		{name: "widen-scalar", val: float32(1.5), hint: "<f8", want: 1.5},
+		{name: "strings", val: []string{"a", "bc"}, hint: "<U8", want: []string{"a", "bc"}},
+		{name: "narrow", val: []float64{1}, hint: "<f4", err: ErrTypeMismatch},
+		{name: "conflict", val: []int64{1}, hint: "<f8", err: ErrTypeMismatch},
+		{name: "invalid", val: []int64{1}, hint: "<x8", err: ErrInvalidType},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			var opts []ReadOption
+			if tc.hint != "" {
+				opts = append(opts, WithDtypeHint(tc.hint))
+			}
+
+			var got interface{}
+			err = Read(buf, &got, opts...)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadSliceReuse(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, []float64{0, 1, 2, 3})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	for _, tc := range []struct {
+		name  string
+		data  []float64
+		want  []float64
+		reuse bool // whether the storage of data must be reused
+	}{
+		{"nil", nil, []float64{0, 1, 2, 3}, false},
+		{"empty-cap", make([]float64, 0, 8), []float64{0, 1, 2, 3}, true},
+		{"empty-small", make([]float64, 0, 2), []float64{0, 1, 2, 3}, false},
+		{"exact", make([]float64, 4), []float64{0, 1, 2, 3}, true},
+		{"larger", []float64{9, 9, 9, 9, 9, 9}, []float64{0, 1, 2, 3}, true},
+		{"chunk", make([]float64, 3, 8), []float64{0, 1, 2}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.data
+			err := Read(bytes.NewReader(raw), &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+			if reused := cap(tc.data) > 0 && &got[:1][0] == &tc.data[:1][0]; reused != tc.reuse {
+				t.Fatalf("invalid reuse: got=%v, want=%v", reused, tc.reuse)
+			}
+		})
+	}
+}
+
+func TestReadSliceChunks(t *testing.T) {
+	type point struct {
+		X, Y float64
+	}
+
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		chunk interface{}
+		opts  []ReadOption
+		want  []interface{}
+	}{
+		{
+			name:  "strings",
+			val:   []string{"a", "bc", "def", "g"},
+			chunk: make([]string, 2),
+			want:  []interface{}{[]string{"a", "bc"}, []string{"def", "g"}},
+		},
+		{
+			name:  "convert",
+			val:   []int32{1, 2, 3, 4, 5, 6},
+			chunk: make([]int64, 3),
+			opts:  []ReadOption{WithConvert()},
+			want:  []interface{}{[]int64{1, 2, 3}, []int64{4, 5, 6}},
+		},
+		{
+			name:  "records",
+			val:   []point{{1, 2}, {3, 4}, {5, 6}},
+			chunk: make([]point, 1),
+			want:  []interface{}{[]point{{1, 2}}, []point{{3, 4}}, []point{{5, 6}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r, err := NewReader(buf, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			ptr := reflect.New(reflect.TypeOf(tc.chunk))
+			ptr.Elem().Set(reflect.ValueOf(tc.chunk))
+			for i, want := range tc.want {
+				err := r.Read(ptr.Interface())
+				if err != nil {
+					t.Fatalf("could not read chunk #%d: %+v", i, err)
+				}
+				if got := ptr.Elem().Interface(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid chunk #%d:\ngot= %v\nwant=%v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadBools(t *testing.T) {
+	var hdr Header
+	hdr.Major = 1
+	hdr.Descr.Type = "|b1"
+	hdr.Descr.Shape = []int{4}
+
+	buf := new(bytes.Buffer)
+	err := WriteStream(buf, hdr, bytes.NewReader([]byte{0, 1, 2, 0xff}))
+	if err != nil {
+		t.Fatalf("could not write stream: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	want := []bool{false, true, true, true}
+	for _, tc := range []struct {
+		name string
+		ptr  interface{}
+		want interface{}
+	}{
+		{"slice", new([]bool), want},
+		{"reuse", &[]bool{true, true, false, false}, want},
+		{"array", new([4]bool), [4]bool{false, true, true, true}},
+		{"chunk", &[]bool{true, true, true}, want[:3]},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(bytes.NewReader(raw), tc.ptr)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := reflect.ValueOf(tc.ptr).Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+
+	hdr.Descr.Shape = nil
+	buf.Reset()
+	err = WriteStream(buf, hdr, bytes.NewReader([]byte{0x2a}))
+	if err != nil {
+		t.Fatalf("could not write stream: %+v", err)
+	}
+	var v bool
+	err = Read(buf, &v)
+	if err != nil {
+		t.Fatalf("could not read scalar: %+v", err)
+	}
+	if !v {
+		t.Fatalf("invalid scalar: got=%v, want=%v", v, true)
+	}
+}
+
+func TestWriteBools(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		want []byte
+	}{
+		{"scalar-true", true, []byte{1}},
+		{"scalar-false", false, []byte{0}},
+		{"slice", []bool{true, false, true}, []byte{1, 0, 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+			if got, want := buf.Bytes()[buf.Len()-len(tc.want):], tc.want; !bytes.Equal(got, want) {
+				t.Fatalf("invalid payload: got=%v, want=%v", got, want)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Type, "|b1"; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+			got := reflect.New(reflect.TypeOf(tc.val))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := got.Elem().Interface(); !reflect.DeepEqual(got, tc.val) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.val)
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeInt32Slice(b *testing.B) {
+	benchmarkDecode(b, make([]int32, 1000))
+}
+
+func BenchmarkDecodeFloat32Slice(b *testing.B) {
+	benchmarkDecode(b, make([]float32, 1000))
+}
+
+// BenchmarkDecodeFloat64SliceReuse benchmarks the decoding of the array
+// data into the storage of an empty slice with enough capacity.
+func BenchmarkDecodeFloat64SliceReuse(b *testing.B) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, make([]float64, 1000))
+	if err != nil {
+		b.Fatalf("could not write data: %+v", err)
+	}
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		b.Fatalf("could not create reader: %+v", err)
+	}
+
+	var (
+		src  = bytes.NewReader(buf.Bytes()[r.data:])
+		data = make([]float64, 0, 1000)
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		src.Seek(0, io.SeekStart)
+		r.r = src
+		r.err = nil
+		data = data[:0]
+		err := r.Read(&data)
+		if err != nil {
+			b.Fatalf("could not read data: %+v", err)
+		}
+	}
+}
+
+// benchmarkDecode benchmarks the decoding of the array data into the
+// pre-sized slice v, leaving out the parsing of the header.
+func benchmarkDecode(b *testing.B, v interface{}) {
+	buf := new(bytes.Buffer)
+	err := Write(buf, v)
+	if err != nil {
+		b.Fatalf("could not write data: %+v", err)
+	}
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		b.Fatalf("could not create reader: %+v", err)
+	}
+
+	var (
+		src = bytes.NewReader(buf.Bytes()[r.data:])
+		ptr = reflect.New(reflect.TypeOf(v))
+	)
+	ptr.Elem().Set(reflect.ValueOf(v))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		src.Seek(0, io.SeekStart)
+		r.r = src
+		r.err = nil
+		err := r.Read(ptr.Interface())
+		if err != nil {
+			b.Fatalf("could not read data: %+v", err)
+		}
+	}
+}
+
+// countReader endlessly yields the little-endian float64 values 0, 1, 2...
+type countReader struct {
+	n   float64
+	buf []byte
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			r.buf = binary.LittleEndian.AppendUint64(nil, math.Float64bits(r.n))
+			r.n++
+		}
+		c := copy(p[n:], r.buf)
+		r.buf = r.buf[c:]
+		n += c
+	}
+	return n, nil
+}
+
+func TestReaderHugeArray(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("dimensions of the test array overflow int")
+	}
+
+	dt, err := newDtype("<f8")
+	if err != nil {
+		t.Fatalf("could not create dtype: %+v", err)
+	}
+
+	// 2^61 elements, 2^64 bytes: never materialized.
+	shape := []int{1 << 31, 1 << 30}
+	hdr := newHeader()
+	hdr.Descr.Type = "<f8"
+	hdr.Descr.Shape = shape
+
+	open := func(t *testing.T) *Reader {
+		buf := new(bytes.Buffer)
+		err := writeHeader(buf, hdr, dt)
+		if err != nil {
+			t.Fatalf("could not write header: %+v", err)
+		}
+		r, err := NewReader(io.MultiReader(buf, new(countReader)))
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		if got, want := r.Header.Descr.Shape, shape; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+		}
+		return r
+	}
+
+	t.Run("in-memory", func(t *testing.T) {
+		r := open(t)
+		for _, ptr := range []interface{}{
+			new([]float64),
+			new(mat.Dense),
+			new(interface{}),
+		} {
+			err := r.Read(ptr)
+			if !errors.Is(err, ErrTooLargeForMemory) {
+				t.Fatalf("invalid error for %T: got=%v, want=%v", ptr, err, ErrTooLargeForMemory)
+			}
+		}
+	})
+
+	t.Run("chunks", func(t *testing.T) {
+		r := open(t)
+		chunk := make([]float64, 1024)
+		for i := 0; i < 3; i++ {
+			err := r.Read(&chunk)
+			if err != nil {
+				t.Fatalf("could not read chunk #%d: %+v", i, err)
+			}
+			for j, v := range chunk {
+				if want := float64(i*len(chunk) + j); v != want {
+					t.Fatalf("invalid value at chunk #%d, index %d: got=%v, want=%v", i, j, v, want)
+				}
+			}
+		}
+	})
+
+	t.Run("chan", func(t *testing.T) {
+		r := open(t)
+		ch := make(chan any)
+		go r.ReadToChan(&ch)
+		for i := 0; i < 1024; i++ {
+			if v := (<-ch).(float64); v != float64(i) {
+				t.Fatalf("invalid value at index %d: got=%v, want=%v", i, v, float64(i))
+			}
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		hdr := hdr
+		hdr.Descr.Shape = []int{1 << 32, 1 << 32}
+		err := writeHeader(buf, hdr, dt)
+		if err != nil {
+			t.Fatalf("could not write header: %+v", err)
+		}
+		_, err = NewReader(buf)
+		if !errors.Is(err, ErrInvalidNumPyFormat) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, ErrInvalidNumPyFormat)
+		}
+	})
+}
+
+// BenchmarkDecodeInt64SliceLarge decodes a 10M-element int64 array, stored
+// in native and in swapped byte order.
+func BenchmarkDecodeInt64SliceLarge(b *testing.B) {
+	const n = 10_000_000
+	for _, bc := range []struct {
+		name  string
+		descr string
+	}{
+		{"native", "<i8"},
+		{"swapped", ">i8"},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			buf := new(bytes.Buffer)
+			err := WriteWithDescr(buf, make([]int64, n), bc.descr, []int{n})
+			if err != nil {
+				b.Fatalf("could not write data: %+v", err)
+			}
+			var (
+				raw  = buf.Bytes()
+				data = make([]int64, n)
+			)
+			b.SetBytes(8 * n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				err := Read(bytes.NewReader(raw), &data)
+				if err != nil {
+					b.Fatalf("could not read data: %+v", err)
+				}
+			}
+		})
+	}
+}
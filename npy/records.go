@@ -0,0 +1,1104 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EachRecord reads the records of a NumPy structured array from r, one at
+// a time, and calls fn with the index of each record.
+//
+// Records are decoded into the same value of type T, which must be a
+// struct type, reused from one call of fn to the next: fn must copy the
+// record if it needs to retain it.
+// Iteration stops at the first error returned by fn, which is then returned
+// by EachRecord.
+//
+// Fields of the structured array are mapped to the exported fields of T
+// by name, using the name given by the `npy:"name"` struct tag if any, or
+// the name of the Go field otherwise.
+// Data types given in struct tags, as in `npy:"name,|S8"`, are ignored.
+// Exact matches are preferred over case-insensitive ones.
+// Fields tagged with `npy:"-"` are ignored.
+// If no field of T matches a field of the array by name, fields are mapped
+// by order.
+// Go fields must have the same kind as their array field (e.g. float64 for
+// '<f8'); byte strings ('S') and unicode strings ('U') are read into
+// strings, byte strings also into []byte values and into byte arrays at
+// least as long as the field, and datetime64 ('M8') and timedelta64 ('m8')
+// values into time.Time and time.Duration values, or into int64 values as
+// numbers of units.
+// Subarray fields, e.g. ('pos', '<f4', (3,)), are read into Go arrays of
+// their shape, e.g. [3]float32, and nested structured fields into structs,
+// whose fields are mapped the same way.
+// Array fields without a matching Go field are skipped.
+func EachRecord[T any](r io.Reader, fn func(i int, rec *T) error) error {
+	rr, err := NewReader(r)
+	if err != nil {
+		return err
+	}
+
+	rec, err := newRecType(rr.Header.Descr.Type)
+	if err != nil {
+		return err
+	}
+
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	binds, err := rec.bind(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	var (
+		n    = numElems(rr.Header.Descr.Shape)
+		buf  = make([]byte, rec.size)
+		zero = reflect.Zero(rv.Type())
+	)
+	for i := 0; i < n; i++ {
+		_, err = rr.read(buf)
+		if err != nil {
+			return fmt.Errorf("npy: could not read record #%d: %w", i, err)
+		}
+		rv.Set(zero)
+		err = binds.decode(rv, buf)
+		if err != nil {
+			return fmt.Errorf("npy: could not read record #%d: %w", i, err)
+		}
+		err = fn(i, &v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadNullableRecords reads all the records of a NumPy structured array
+// from r into a slice of pointers to T, decoding records as EachRecord
+// does.
+//
+// NumPy has no notion of null records: files usually mark them with a
+// sentinel field value instead. Once a record has been decoded, it is
+// passed to isNull, and its element in the returned slice is set to nil if
+// isNull reports true.
+// A nil isNull keeps all records.
+func ReadNullableRecords[T any](r io.Reader, isNull func(rec *T) bool) ([]*T, error) {
+	var recs []*T
+	err := EachRecord(r, func(i int, rec *T) error {
+		if isNull != nil && isNull(rec) {
+			recs = append(recs, nil)
+			return nil
+		}
+		v := *rec
+		recs = append(recs, &v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recs, nil
+}
+
+// recType describes the layout of the records of a structured array.
+type recType struct {
+	fields []recField
+	size   int // size of a record, in bytes
+}
+
+// recField describes a field of a structured array.
+type recField struct {
+	name   string
+	offset int      // offset of the field within a record, in bytes
+	dt     dType    // data type of the field, or of its elements
+	shape  []int    // shape of subarray fields, nil for scalar fields
+	rec    *recType // layout of nested structured fields, nil otherwise
+}
+
+// elemSize returns the size of the field, or of its elements for subarray
+// fields, in bytes.
+func (f recField) elemSize() int {
+	if f.rec != nil {
+		return f.rec.size
+	}
+	return f.dt.size
+}
+
+// size returns the size of the field, in bytes.
+func (f recField) size() int {
+	return f.elemSize() * numElems(f.shape)
+}
+
+// align returns the alignment of the field within aligned records: the
+// largest alignment of its fields for nested structured fields.
+func (f recField) align() int {
+	if f.rec == nil {
+		return alignOf(f.dt)
+	}
+	a := 1
+	for _, sub := range f.rec.fields {
+		a = max(a, sub.align())
+	}
+	return a
+}
+
+// rePadding matches the descriptor of the anonymous fields NumPy uses to
+// describe padding bytes between fields.
+var rePadding = regexp.MustCompile(`^\|?V(\d+)$`)
+
+// isRecDescr returns whether descr describes a structured array, either
+// as a list of (name, format) tuples or as a dict of field attributes.
+func isRecDescr(descr string) bool {
+	return strings.HasPrefix(descr, "[") || strings.HasPrefix(descr, "{")
+}
+
+// newRecType parses the descriptor of a structured array, in the form of a
+// list of (name, format) tuples, e.g. "[('x', '<f8'), ('y', '<i4')]", or
+// in the form of a dict, e.g.
+// "{'names': ['x', 'y'], 'formats': ['<f8', '<i4'], 'aligned': True}".
+func newRecType(descr string) (recType, error) {
+	var rec recType
+	if !isRecDescr(descr) {
+		return rec, fmt.Errorf("npy: dtype %q is not a structured data type: %w", descr, ErrTypeMismatch)
+	}
+
+	p := literal{buf: []byte(descr)}
+	v, err := p.value()
+	if err != nil {
+		return rec, fmt.Errorf("npy: invalid structured data type %q: %w", descr, err)
+	}
+	switch v := v.(type) {
+	case []interface{}:
+		return newRecList(v)
+	case []item:
+		return newRecDict(v)
+	}
+	return rec, fmt.Errorf("npy: invalid structured data type %q", descr)
+}
+
+// newRecList returns the layout of the records described by list, a list
+// of (name, format) tuples.
+// Fields are packed, but for the anonymous padding fields NumPy uses to
+// describe aligned records.
+func newRecList(list []interface{}) (recType, error) {
+	var rec recType
+	for _, v := range list {
+		tup, ok := v.(tuple)
+		if !ok || len(tup) < 2 || len(tup) > 3 {
+			return rec, fmt.Errorf("npy: invalid structured data type field %s", repr(v))
+		}
+		name, ok := tup[0].(string)
+		if !ok {
+			return rec, fmt.Errorf("npy: invalid structured data type field name %s: %w", repr(tup[0]), ErrInvalidType)
+		}
+
+		format, ok := tup[1].(string)
+		if m := rePadding.FindStringSubmatch(format); ok && m != nil && name == "" && len(tup) == 2 {
+			n, err := strconv.Atoi(m[1])
+			if err != nil {
+				return rec, fmt.Errorf("npy: invalid padding field %s: %w", repr(v), err)
+			}
+			rec.size += n
+			continue
+		}
+
+		var shape interface{}
+		if len(tup) == 3 {
+			shape = tup[2]
+		}
+		f, err := newRecField(name, tup[1], shape)
+		if err != nil {
+			return rec, err
+		}
+		f.offset = rec.size
+		rec.fields = append(rec.fields, f)
+		rec.size += f.size()
+	}
+
+	return rec, nil
+}
+
+// newRecDict returns the layout of the records described by dict, with
+// the 'names' and 'formats' of the fields and, optionally, their
+// 'offsets', the 'itemsize' of the records and whether they are
+// 'aligned', as accepted by numpy.dtype.
+//
+// Without explicit offsets, fields are packed or, for aligned records,
+// placed at the next multiple of their alignment, as a C compiler would.
+func newRecDict(dict []item) (recType, error) {
+	var (
+		rec     recType
+		names   []interface{}
+		formats []interface{}
+		offsets []interface{}
+		size    = -1
+		aligned bool
+	)
+	for _, it := range dict {
+		var ok bool
+		switch it.key {
+		case "names":
+			names, ok = it.val.([]interface{})
+		case "formats":
+			formats, ok = it.val.([]interface{})
+		case "offsets":
+			offsets, ok = it.val.([]interface{})
+		case "itemsize":
+			size, ok = it.val.(int)
+		case "aligned":
+			aligned, ok = it.val.(bool)
+		case "titles":
+			// only fields without titles are supported.
+			ok = it.val == nil
+			if vs, isList := it.val.([]interface{}); isList {
+				ok = true
+				for _, v := range vs {
+					ok = ok && v == nil
+				}
+			}
+		}
+		if !ok {
+			return rec, fmt.Errorf("npy: structured data type key %s: %s not supported: %w", repr(it.key), repr(it.val), ErrInvalidType)
+		}
+	}
+	switch {
+	case names == nil || formats == nil:
+		return rec, fmt.Errorf("npy: structured data type is missing 'names' or 'formats'")
+	case len(names) != len(formats):
+		return rec, fmt.Errorf("npy: structured data type has %d names for %d formats", len(names), len(formats))
+	case offsets != nil && len(offsets) != len(names):
+		return rec, fmt.Errorf("npy: structured data type has %d offsets for %d fields", len(offsets), len(names))
+	}
+
+	var (
+		end   = 0 // end of the last field, in bytes
+		align = 1 // alignment of the records
+	)
+	for i := range names {
+		name, ok := names[i].(string)
+		if !ok {
+			return rec, fmt.Errorf("npy: invalid structured data type field name %s: %w", repr(names[i]), ErrInvalidType)
+		}
+		var (
+			format = formats[i]
+			shape  interface{}
+		)
+		if tup, ok := format.(tuple); ok && len(tup) == 2 {
+			// (format, shape) of a subarray field.
+			format, shape = tup[0], tup[1]
+		}
+		f, err := newRecField(name, format, shape)
+		if err != nil {
+			return rec, err
+		}
+
+		a := f.align()
+		align = max(align, a)
+		switch {
+		case offsets != nil:
+			f.offset, ok = offsets[i].(int)
+			if !ok || f.offset < 0 {
+				return rec, fmt.Errorf("npy: invalid offset %s of structured data type field %q", repr(offsets[i]), name)
+			}
+		case aligned:
+			f.offset = (end + a - 1) / a * a
+		default:
+			f.offset = end
+		}
+		end = max(end, f.offset+f.size())
+		rec.fields = append(rec.fields, f)
+	}
+
+	rec.size = end
+	if aligned {
+		rec.size = (end + align - 1) / align * align
+	}
+	if size >= 0 {
+		if size < end {
+			return rec, fmt.Errorf("npy: item size %d too small for structured data type fields of %d bytes", size, end)
+		}
+		rec.size = size
+	}
+
+	return rec, nil
+}
+
+// newRecField returns the field name of a structured array, of the data
+// type format: a data type descriptor, or the list or dict describing a
+// nested structured data type.
+// If shape is not nil, the field is a subarray of that shape, given as an
+// int or a tuple of ints.
+// The offset of the field is left to the caller.
+func newRecField(name string, format, shape interface{}) (recField, error) {
+	field := tuple{name, format}
+	if shape != nil {
+		field = append(field, shape)
+	}
+
+	f := recField{name: name}
+	switch format := format.(type) {
+	case string:
+		dt, err := newDtype(format)
+		if err != nil {
+			return f, fmt.Errorf("npy: invalid structured data type field %s: %w", repr(field), err)
+		}
+		_, err = itemsizeFrom(format)
+		if err != nil {
+			return f, fmt.Errorf("npy: invalid structured data type field %s: %w", repr(field), err)
+		}
+		f.dt = dt
+	case []interface{}:
+		rec, err := newRecList(format)
+		if err != nil {
+			return f, fmt.Errorf("npy: invalid structured data type field %s: %w", repr(field), err)
+		}
+		f.rec = &rec
+	case []item:
+		rec, err := newRecDict(format)
+		if err != nil {
+			return f, fmt.Errorf("npy: invalid structured data type field %s: %w", repr(field), err)
+		}
+		f.rec = &rec
+	default:
+		return f, fmt.Errorf("npy: structured data type field %s not supported: %w", repr(field), ErrInvalidType)
+	}
+
+	switch shape := shape.(type) {
+	case nil:
+	case int:
+		f.shape = []int{shape}
+	case tuple:
+		for _, v := range shape {
+			n, ok := v.(int)
+			if !ok {
+				return f, fmt.Errorf("npy: invalid shape of structured data type field %s: %w", repr(field), errDims)
+			}
+			f.shape = append(f.shape, n)
+		}
+	default:
+		return f, fmt.Errorf("npy: invalid shape of structured data type field %s: %w", repr(field), errDims)
+	}
+	for _, n := range f.shape {
+		if n < 0 {
+			return f, fmt.Errorf("npy: invalid shape of structured data type field %s: %w", repr(field), errDims)
+		}
+	}
+	return f, nil
+}
+
+// alignOf returns the alignment of values of the data type dt within
+// aligned records, as NumPy computes it.
+func alignOf(dt dType) int {
+	switch dt.rt.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return dt.size / 2
+	case reflect.String:
+		if dt.utf {
+			return 4
+		}
+		return 1
+	}
+	return max(dt.size, 1)
+}
+
+// StructHeader returns the header describing a structured array whose
+// records are the values of the struct type of v.
+// v may be a struct, or a slice or an array of structs, in which case the
+// shape of the header is the length of v.
+//
+// Each exported field of the struct becomes a field of the records, in
+// order, named after the `npy:"name"` struct tag if any, or after the Go
+// field otherwise.
+// The data type of a field is derived from the kind of the Go field
+// (e.g. '<f8' for float64, '<M8[ns]' for time.Time) unless the tag
+// overrides it, as in `npy:"name,|S8"` or `npy:"name,<M8[s]"`: this is
+// required for string and []byte fields, which need a fixed size.
+// Overrides must be able to hold the Go field, as described by EachRecord.
+// Array fields become subarray fields of the shape of the array, e.g.
+// ('pos', '<f4', (3,)) for a [3]float32 field, unless they are byte arrays
+// tagged as byte strings (e.g. `npy:"name,|S8"`), and struct fields other
+// than time.Time become nested structured fields.
+// Fields tagged with `npy:"-"` are ignored.
+// Fields are packed: the item size of a record is the sum of the sizes of
+// its fields.
+func StructHeader(v interface{}) (Header, error) {
+	var (
+		hdr   = newHeader()
+		rv    = reflect.Indirect(reflect.ValueOf(v))
+		rt    = rv.Type()
+		shape []int
+	)
+	switch rt.Kind() {
+	case reflect.Slice, reflect.Array:
+		rt = rt.Elem()
+		shape = []int{rv.Len()}
+	}
+
+	rec, err := recTypeFrom(rt)
+	if err != nil {
+		return hdr, err
+	}
+
+	hdr.Descr.Type = rec.descr()
+	hdr.Descr.Shape = shape
+	return hdr, nil
+}
+
+// structTag describes the `npy:"name,dtype"` tag of a struct field.
+type structTag struct {
+	name  string // name of the field in the structured array
+	dtype string // data type override, if any
+}
+
+// parseTag returns the tag of the struct field f, and whether f is mapped
+// to a field of a structured array.
+func parseTag(f reflect.StructField) (structTag, bool) {
+	tag := structTag{name: f.Name}
+	if !f.IsExported() {
+		return tag, false
+	}
+	v, ok := f.Tag.Lookup("npy")
+	if !ok {
+		return tag, true
+	}
+	if v == "-" {
+		return tag, false
+	}
+	name, dtype, _ := strings.Cut(v, ",")
+	if name != "" {
+		tag.name = name
+	}
+	tag.dtype = dtype
+	return tag, true
+}
+
+// recTypeFrom returns the layout of the records made of values of the
+// struct type rt.
+//
+// Array fields are written as subarray fields of the shape of the array,
+// but for byte arrays whose data type is given as a byte string by their
+// tag, and struct fields other than time.Time as nested structured fields.
+func recTypeFrom(rt reflect.Type) (recType, error) {
+	var rec recType
+	if rt.Kind() != reflect.Struct {
+		return rec, fmt.Errorf("npy: type %v is not a struct: %w", rt, ErrInvalidType)
+	}
+
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, ok := parseTag(f)
+		if !ok {
+			continue
+		}
+
+		field, err := recFieldFrom(f, tag)
+		if err != nil {
+			return rec, err
+		}
+		field.offset = rec.size
+		rec.fields = append(rec.fields, field)
+		rec.size += field.size()
+	}
+
+	if len(rec.fields) == 0 {
+		return rec, fmt.Errorf("npy: struct %v has no field to map: %w", rt, ErrInvalidType)
+	}
+
+	return rec, nil
+}
+
+// recFieldFrom returns the field of a structured array holding the values
+// of the struct field f, with the tag tag.
+func recFieldFrom(f reflect.StructField, tag structTag) (recField, error) {
+	field := recField{name: tag.name}
+
+	var (
+		ft   = f.Type
+		bstr = false // whether the tag describes byte strings
+	)
+	if dt, err := newDtype(tag.dtype); err == nil {
+		bstr = dt.rt == stringType && !dt.utf
+	}
+	for ft.Kind() == reflect.Array && !(bstr && ft.Elem().Kind() == reflect.Uint8) {
+		field.shape = append(field.shape, ft.Len())
+		ft = ft.Elem()
+	}
+
+	if ft.Kind() == reflect.Struct && ft != timeType {
+		if tag.dtype != "" {
+			return field, fmt.Errorf("npy: struct field %q can not have a data type in its struct tag: %w", f.Name, ErrInvalidType)
+		}
+		rec, err := recTypeFrom(ft)
+		if err != nil {
+			return field, fmt.Errorf("npy: invalid nested struct field %q: %w", f.Name, err)
+		}
+		field.rec = &rec
+		return field, nil
+	}
+
+	dtype := tag.dtype
+	if dtype == "" {
+		switch ft.Kind() {
+		case reflect.Int, reflect.Uint:
+			return field, fmt.Errorf("npy: field %q of type %v not supported: %w", f.Name, f.Type, ErrInvalidType)
+		case reflect.String:
+			return field, fmt.Errorf("npy: string field %q needs a data type in its struct tag (e.g. %q): %w",
+				f.Name, `npy:"`+tag.name+`,|S8"`, ErrInvalidType,
+			)
+		}
+		if ft == bytesType {
+			return field, fmt.Errorf("npy: byte slice field %q needs a data type in its struct tag (e.g. %q): %w",
+				f.Name, `npy:"`+tag.name+`,|S8"`, ErrInvalidType,
+			)
+		}
+		var err error
+		dtype, err = dtypeFrom(reflect.Value{}, ft)
+		if err != nil {
+			return field, fmt.Errorf("npy: field %q of type %v not supported: %w", f.Name, f.Type, ErrInvalidType)
+		}
+	}
+
+	dt, err := newDtype(dtype)
+	if err != nil {
+		return field, fmt.Errorf("npy: invalid data type for field %q: %w", f.Name, err)
+	}
+	if !canHold(ft, dt) {
+		return field, fmt.Errorf(
+			"npy: data type %q can not hold field %q of type %v: %w",
+			dtype, f.Name, f.Type, ErrTypeMismatch,
+		)
+	}
+	field.dt = dt
+	return field, nil
+}
+
+// descr returns the NumPy descriptor of the records, as a list of
+// (name, format) tuples.
+func (rec recType) descr() string {
+	return repr(rec.list())
+}
+
+// list returns the list of (name, format) tuples, or of (name, format,
+// shape) tuples for subarray fields, describing the records.
+// The format of nested structured fields is itself such a list.
+func (rec recType) list() []interface{} {
+	var (
+		list = make([]interface{}, 0, len(rec.fields))
+		off  = 0
+	)
+	for _, f := range rec.fields {
+		if f.offset > off {
+			list = append(list, tuple{"", fmt.Sprintf("|V%d", f.offset-off)})
+		}
+		var format interface{} = f.dt.str
+		if f.rec != nil {
+			format = f.rec.list()
+		}
+		field := tuple{f.name, format}
+		if f.shape != nil {
+			shape := make(tuple, len(f.shape))
+			for i, n := range f.shape {
+				shape[i] = n
+			}
+			field = append(field, shape)
+		}
+		list = append(list, field)
+		off = f.offset + f.size()
+	}
+	if rec.size > off {
+		list = append(list, tuple{"", fmt.Sprintf("|V%d", rec.size-off)})
+	}
+	return list
+}
+
+// recBinding associates a field of a structured array with the index of
+// a field of a Go struct.
+type recBinding struct {
+	field recField
+	index int
+	sub   recBindings // bindings of the fields of nested structured fields
+}
+
+type recBindings []recBinding
+
+// bind maps the fields of rec to the fields of the struct type rt.
+func (rec recType) bind(rt reflect.Type) (recBindings, error) {
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("npy: can not read records into %v: %w", rt, ErrTypeMismatch)
+	}
+
+	var (
+		names = make([]string, 0, rt.NumField())
+		index = make([]int, 0, rt.NumField())
+	)
+	for i := 0; i < rt.NumField(); i++ {
+		tag, ok := parseTag(rt.Field(i))
+		if !ok {
+			continue
+		}
+		names = append(names, tag.name)
+		index = append(index, i)
+	}
+
+	var binds recBindings
+	for _, field := range rec.fields {
+		j := -1
+		for k, name := range names {
+			if name == field.name {
+				j = k
+				break
+			}
+			if j < 0 && strings.EqualFold(name, field.name) {
+				j = k
+			}
+		}
+		if j >= 0 {
+			binds = append(binds, recBinding{field: field, index: index[j]})
+		}
+	}
+
+	if len(binds) == 0 {
+		// no field matched by name: map fields by order.
+		if len(index) != len(rec.fields) {
+			return nil, fmt.Errorf(
+				"npy: can not map %d fields of structured data type to %d fields of %v: %w",
+				len(rec.fields), len(index), rt, ErrTypeMismatch,
+			)
+		}
+		for i, field := range rec.fields {
+			binds = append(binds, recBinding{field: field, index: index[i]})
+		}
+	}
+
+	for i, b := range binds {
+		sub, err := b.field.bind(rt.Field(b.index))
+		if err != nil {
+			return nil, err
+		}
+		binds[i].sub = sub
+	}
+
+	return binds, nil
+}
+
+// bind checks that the struct field f can hold the values of the field of
+// a structured array, and returns the bindings of its nested fields.
+// Subarray fields are held by Go arrays of their shape, e.g. [2][3]float32
+// for a '<f4' field of shape (2, 3), and nested structured fields by
+// structs.
+func (field recField) bind(f reflect.StructField) (recBindings, error) {
+	ft := f.Type
+	for _, n := range field.shape {
+		if ft.Kind() != reflect.Array || ft.Len() != n {
+			return nil, fmt.Errorf(
+				"npy: field %q of type %v can not hold subarray field %q of shape %v: %w",
+				f.Name, f.Type, field.name, field.shape, ErrTypeMismatch,
+			)
+		}
+		ft = ft.Elem()
+	}
+
+	if field.rec != nil {
+		if ft.Kind() != reflect.Struct || ft == timeType {
+			return nil, fmt.Errorf(
+				"npy: field %q of type %v can not hold nested structured field %q: %w",
+				f.Name, f.Type, field.name, ErrTypeMismatch,
+			)
+		}
+		return field.rec.bind(ft)
+	}
+
+	if !canHold(ft, field.dt) {
+		return nil, fmt.Errorf(
+			"npy: field %q of type %v can not hold structured data type field %q (%s): %w",
+			f.Name, f.Type, field.name, field.dt.str, ErrTypeMismatch,
+		)
+	}
+	return nil, nil
+}
+
+// canHold returns whether values of the Go type rt can hold the values of
+// a field of type dt, as read by recType.bind.
+//
+// Go fields must have the same kind as their array field, but for byte
+// strings, also read into []byte values and into byte arrays at least as
+// long as the field, and for datetime64 and timedelta64 values, read into
+// time.Time and time.Duration values, or into int64 values as numbers of
+// units.
+func canHold(rt reflect.Type, dt dType) bool {
+	switch {
+	case dt.unit != "":
+		return rt == dt.rt || (rt.Kind() == reflect.Int64 && rt != durationType)
+	case dt.rt == stringType && !dt.utf && rt.Kind() == reflect.Slice:
+		return rt.Elem().Kind() == reflect.Uint8
+	case dt.rt == stringType && !dt.utf && rt.Kind() == reflect.Array:
+		return rt.Elem().Kind() == reflect.Uint8 && rt.Len() >= dt.size
+	}
+	return rt.Kind() == dt.rt.Kind()
+}
+
+// decode decodes the record buf into the struct value rv.
+func (binds recBindings) decode(rv reflect.Value, buf []byte) error {
+	for _, b := range binds {
+		err := b.field.each(rv.Field(b.index), buf[b.field.offset:], func(rv reflect.Value, buf []byte) error {
+			if b.field.rec != nil {
+				return b.sub.decode(rv, buf)
+			}
+			return decodeValue(rv, b.field.dt, buf)
+		})
+		if err != nil {
+			return fmt.Errorf("npy: could not decode field %q: %w", b.field.name, err)
+		}
+	}
+	return nil
+}
+
+// encode encodes the struct value rv into the record buf, which must be
+// zeroed.
+func (binds recBindings) encode(buf []byte, rv reflect.Value) error {
+	for _, b := range binds {
+		err := b.field.each(rv.Field(b.index), buf[b.field.offset:], func(rv reflect.Value, buf []byte) error {
+			if b.field.rec != nil {
+				return b.sub.encode(buf, rv)
+			}
+			return encodeValue(buf, rv, b.field.dt)
+		})
+		if err != nil {
+			return fmt.Errorf("npy: could not encode field %q: %w", b.field.name, err)
+		}
+	}
+	return nil
+}
+
+// each calls fn with rv and buf, the Go value and the bytes of the field,
+// or with each element of rv and its bytes for subarray fields, in C-order.
+func (field recField) each(rv reflect.Value, buf []byte, fn func(rv reflect.Value, buf []byte) error) error {
+	var walk func(rv reflect.Value, shape []int, buf []byte) error
+	walk = func(rv reflect.Value, shape []int, buf []byte) error {
+		if len(shape) == 0 {
+			return fn(rv, buf)
+		}
+		stride := field.elemSize() * numElems(shape[1:])
+		for i := 0; i < shape[0]; i++ {
+			err := walk(rv.Index(i), shape[1:], buf[i*stride:])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(rv, field.shape, buf)
+}
+
+// decodeValue decodes the value of type dt at the start of buf into rv.
+func decodeValue(rv reflect.Value, dt dType, buf []byte) error {
+	if dt.unit != "" {
+		v := int64(dt.order.Uint64(buf))
+		switch rv.Type() {
+		case timeType:
+			t, err := decodeTime(v, dt.unit)
+			if err != nil {
+				return err
+			}
+			rv.Set(reflect.ValueOf(t))
+		case durationType:
+			d, err := decodeDuration(v, dt.unit)
+			if err != nil {
+				return err
+			}
+			rv.SetInt(int64(d))
+		default:
+			rv.SetInt(v)
+		}
+		return nil
+	}
+
+	switch dt.rt.Kind() {
+	case reflect.Bool:
+		rv.SetBool(buf[0] != 0)
+	case reflect.Int8:
+		rv.SetInt(int64(int8(buf[0])))
+	case reflect.Int16:
+		rv.SetInt(int64(int16(dt.order.Uint16(buf))))
+	case reflect.Int32:
+		rv.SetInt(int64(int32(dt.order.Uint32(buf))))
+	case reflect.Int64:
+		rv.SetInt(int64(dt.order.Uint64(buf)))
+	case reflect.Uint8:
+		rv.SetUint(uint64(buf[0]))
+	case reflect.Uint16:
+		rv.SetUint(uint64(dt.order.Uint16(buf)))
+	case reflect.Uint32:
+		rv.SetUint(uint64(dt.order.Uint32(buf)))
+	case reflect.Uint64:
+		rv.SetUint(dt.order.Uint64(buf))
+	case reflect.Float32:
+		rv.SetFloat(float64(math.Float32frombits(dt.order.Uint32(buf))))
+	case reflect.Float64:
+		rv.SetFloat(math.Float64frombits(dt.order.Uint64(buf)))
+	case reflect.Complex64:
+		rv.SetComplex(complex(
+			float64(math.Float32frombits(dt.order.Uint32(buf[0:]))),
+			float64(math.Float32frombits(dt.order.Uint32(buf[4:]))),
+		))
+	case reflect.Complex128:
+		rv.SetComplex(complex(
+			math.Float64frombits(dt.order.Uint64(buf[0:])),
+			math.Float64frombits(dt.order.Uint64(buf[8:])),
+		))
+	case reflect.String:
+		if dt.utf {
+			rv.SetString(decodeUCS4(buf[:dt.size], dt.order))
+			return nil
+		}
+		switch rv.Kind() {
+		case reflect.Slice:
+			rv.SetBytes(append([]byte(nil), bytes.TrimRight(buf[:dt.size], "\x00")...))
+			return nil
+		case reflect.Array:
+			reflect.Copy(rv, reflect.ValueOf(buf[:dt.size]))
+			return nil
+		}
+		str := buf[:dt.size]
+		if i := bytes.IndexByte(str, 0); i >= 0 {
+			str = str[:i]
+		}
+		rv.SetString(string(str))
+	}
+	return nil
+}
+
+// readRecords reads the records of a structured array into rv, a struct,
+// or a slice or an array of structs.
+func (r *Reader) readRecords(rv reflect.Value) error {
+	rec, err := newRecType(r.Header.Descr.Type)
+	if err != nil {
+		return err
+	}
+
+	rt := rv.Type()
+	switch rt.Kind() {
+	case reflect.Slice, reflect.Array:
+		rt = rt.Elem()
+	}
+	binds, err := rec.bind(rt)
+	if err != nil {
+		return err
+	}
+
+	var (
+		nelems = numElems(r.Header.Descr.Shape)
+		buf    = make([]byte, rec.size)
+		zero   = reflect.Zero(rt)
+	)
+	read := func(v reflect.Value) error {
+		_, err := r.read(buf)
+		if err != nil {
+			return err
+		}
+		v.Set(zero)
+		return binds.decode(v, buf)
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return read(rv)
+
+	case reflect.Slice:
+		if rv.Len() == 0 {
+			err := r.checkMem(rec.size)
+			if err != nil {
+				return err
+			}
+		}
+		n := resizeValue(rv, nelems)
+		for i := 0; i < n; i++ {
+			err := read(rv.Index(i))
+			if err != nil {
+				return fmt.Errorf("npy: could not read record #%d: %w", i, err)
+			}
+		}
+		return nil
+
+	case reflect.Array:
+		if nelems > rv.Len() {
+			return errDims
+		}
+		for i := 0; i < nelems; i++ {
+			err := read(rv.Index(i))
+			if err != nil {
+				return fmt.Errorf("npy: could not read record #%d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("npy: can not read records into %v: %w", rv.Type(), ErrTypeMismatch)
+}
+
+// isRecords returns whether values of type rt are written as structured
+// arrays.
+func isRecords(rt reflect.Type) bool {
+	return rt != rtDense && elemType(rt) != timeType && elemType(rt).Kind() == reflect.Struct
+}
+
+// writeRecords writes rv, a struct, or a slice or an array of structs, as
+// a structured array.
+func writeRecords(w io.Writer, rv reflect.Value) error {
+	var (
+		rt    = rv.Type()
+		shape []int
+		n     = 1
+	)
+	switch rt.Kind() {
+	case reflect.Slice, reflect.Array:
+		rt = rt.Elem()
+		n = rv.Len()
+		shape = []int{n}
+	}
+
+	rec, err := recTypeFrom(rt)
+	if err != nil {
+		return err
+	}
+	binds, err := rec.bind(rt)
+	if err != nil {
+		return err
+	}
+
+	hdr := newHeader()
+	hdr.Descr.Type = rec.descr()
+	hdr.Descr.Shape = shape
+	err = writeHeader(w, hdr, dType{})
+	if err != nil {
+		return err
+	}
+
+	var (
+		bw  = bufio.NewWriter(w)
+		buf = make([]byte, rec.size)
+	)
+	for i := 0; i < n; i++ {
+		v := rv
+		if shape != nil {
+			v = rv.Index(i)
+		}
+		for j := range buf {
+			buf[j] = 0
+		}
+		err := binds.encode(buf, v)
+		if err != nil {
+			return fmt.Errorf("npy: could not write record #%d: %w", i, err)
+		}
+		_, err = bw.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// encodeValue encodes the value rv as a value of type dt at the start of
+// buf, which must be zeroed.
+func encodeValue(buf []byte, rv reflect.Value, dt dType) error {
+	if dt.unit != "" {
+		var (
+			v   int64
+			err error
+		)
+		switch rv.Type() {
+		case timeType:
+			v, err = encodeTime(rv.Interface().(time.Time), dt.unit)
+		case durationType:
+			v, err = encodeDuration(time.Duration(rv.Int()), dt.unit)
+		default:
+			v = rv.Int()
+		}
+		if err != nil {
+			return err
+		}
+		dt.order.PutUint64(buf, uint64(v))
+		return nil
+	}
+
+	switch dt.rt.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			buf[0] = 1
+		}
+	case reflect.Int8:
+		buf[0] = byte(rv.Int())
+	case reflect.Int16:
+		dt.order.PutUint16(buf, uint16(rv.Int()))
+	case reflect.Int32:
+		dt.order.PutUint32(buf, uint32(rv.Int()))
+	case reflect.Int64:
+		dt.order.PutUint64(buf, uint64(rv.Int()))
+	case reflect.Uint8:
+		buf[0] = byte(rv.Uint())
+	case reflect.Uint16:
+		dt.order.PutUint16(buf, uint16(rv.Uint()))
+	case reflect.Uint32:
+		dt.order.PutUint32(buf, uint32(rv.Uint()))
+	case reflect.Uint64:
+		dt.order.PutUint64(buf, rv.Uint())
+	case reflect.Float32:
+		dt.order.PutUint32(buf, math.Float32bits(float32(rv.Float())))
+	case reflect.Float64:
+		dt.order.PutUint64(buf, math.Float64bits(rv.Float()))
+	case reflect.Complex64:
+		c := rv.Complex()
+		dt.order.PutUint32(buf[0:], math.Float32bits(float32(real(c))))
+		dt.order.PutUint32(buf[4:], math.Float32bits(float32(imag(c))))
+	case reflect.Complex128:
+		c := rv.Complex()
+		dt.order.PutUint64(buf[0:], math.Float64bits(real(c)))
+		dt.order.PutUint64(buf[8:], math.Float64bits(imag(c)))
+	case reflect.String:
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			// byte strings, as accepted by canHold: trailing NULs are
+			// padding.
+			n := rv.Len()
+			for n > dt.size && rv.Index(n-1).Uint() == 0 {
+				n--
+			}
+			if n > dt.size {
+				return fmt.Errorf("npy: %d bytes too long for data type %q: %w", n, dt.str, ErrOutOfRange)
+			}
+			for i := 0; i < n; i++ {
+				buf[i] = byte(rv.Index(i).Uint())
+			}
+			return nil
+		}
+		str := rv.String()
+		if !dt.utf {
+			if len(str) > dt.size {
+				return fmt.Errorf("npy: string %q too long for data type %q: %w", str, dt.str, ErrOutOfRange)
+			}
+			copy(buf, str)
+			return nil
+		}
+		i := 0
+		for _, c := range str {
+			if i+4 > dt.size {
+				return fmt.Errorf("npy: string %q too long for data type %q: %w", str, dt.str, ErrOutOfRange)
+			}
+			dt.order.PutUint32(buf[i:], uint32(c))
+			i += 4
+		}
+	}
+	return nil
+}
@@ -0,0 +1,920 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEachRecord(t *testing.T) {
+	type record struct {
+		X     float64
+		Y     int32  `npy:"y"`
+		Name  string `npy:"name"`
+		Label string `npy:"label"`
+		Extra int    `npy:"-"`
+	}
+
+	type ordered struct {
+		A float64
+		B int32
+		C string
+		D string
+	}
+
+	type partial struct {
+		Y int32 `npy:"y"`
+	}
+
+	type mismatch struct {
+		Y float64 `npy:"y"`
+	}
+
+	want := []record{
+		{X: 1.5, Y: 2, Name: "abc", Label: "αβ"},
+		{X: -3.25, Y: -4, Name: "hello!!!", Label: "xyz"},
+		{X: 0, Y: 42, Name: "", Label: ""},
+	}
+
+	open := func(t *testing.T) *os.File {
+		f, err := os.Open("../testdata/data_records.npy")
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		return f
+	}
+
+	t.Run("by-name", func(t *testing.T) {
+		f := open(t)
+		defer f.Close()
+
+		var got []record
+		err := EachRecord(f, func(i int, rec *record) error {
+			if i != len(got) {
+				t.Fatalf("invalid record index: got=%d, want=%d", i, len(got))
+			}
+			got = append(got, *rec)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("by-order", func(t *testing.T) {
+		f := open(t)
+		defer f.Close()
+
+		var got []ordered
+		err := EachRecord(f, func(i int, rec *ordered) error {
+			got = append(got, *rec)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		for i := range want {
+			o := ordered{want[i].X, want[i].Y, want[i].Name, want[i].Label}
+			if got[i] != o {
+				t.Fatalf("invalid record #%d: got=%+v, want=%+v", i, got[i], o)
+			}
+		}
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		f := open(t)
+		defer f.Close()
+
+		var got []int32
+		err := EachRecord(f, func(i int, rec *partial) error {
+			got = append(got, rec.Y)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if want := []int32{2, -4, 42}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid values: got=%v, want=%v", got, want)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		f := open(t)
+		defer f.Close()
+
+		stop := errors.New("stop")
+		n := 0
+		err := EachRecord(f, func(i int, rec *partial) error {
+			n++
+			return stop
+		})
+		if !errors.Is(err, stop) {
+			t.Fatalf("invalid error: %+v", err)
+		}
+		if n != 1 {
+			t.Fatalf("invalid number of calls: %d", n)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		f := open(t)
+		defer f.Close()
+
+		err := EachRecord(f, func(i int, rec *mismatch) error { return nil })
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("invalid error: %+v", err)
+		}
+	})
+
+	t.Run("not-structured", func(t *testing.T) {
+		f, err := os.Open("../testdata/data_float64_2x3_corder.npy")
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		err = EachRecord(f, func(i int, rec *partial) error { return nil })
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("invalid error: %+v", err)
+		}
+	})
+}
+
+func TestReadNullableRecords(t *testing.T) {
+	type record struct {
+		X    float64
+		Y    int32  `npy:"y"`
+		Name string `npy:"name"`
+	}
+
+	for _, tc := range []struct {
+		name   string
+		isNull func(rec *record) bool
+		want   []*record
+	}{
+		{
+			name:   "sentinel",
+			isNull: func(rec *record) bool { return rec.Name == "" },
+			want: []*record{
+				{X: 1.5, Y: 2, Name: "abc"},
+				{X: -3.25, Y: -4, Name: "hello!!!"},
+				nil,
+			},
+		},
+		{
+			name: "no-predicate",
+			want: []*record{
+				{X: 1.5, Y: 2, Name: "abc"},
+				{X: -3.25, Y: -4, Name: "hello!!!"},
+				{X: 0, Y: 42, Name: ""},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := os.Open("../testdata/data_records.npy")
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			got, err := ReadNullableRecords(f, tc.isNull)
+			if err != nil {
+				t.Fatalf("could not read records: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadWriteRecords(t *testing.T) {
+	type record struct {
+		X     float64 `npy:"x"`
+		Y     int32   `npy:"y"`
+		Name  string  `npy:"name,|S8"`
+		Label string  `npy:"label,<U3"`
+	}
+
+	want := []record{
+		{X: 1.5, Y: 2, Name: "abc", Label: "αβ"},
+		{X: -3.25, Y: -4, Name: "hello!!!", Label: "xyz"},
+		{X: 0, Y: 42, Name: "", Label: ""},
+	}
+
+	raw, err := os.ReadFile("../testdata/data_records.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	r, err := NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	var got []record
+	err = r.Read(&got)
+	if err != nil {
+		t.Fatalf("could not read records: %+v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	buf := new(bytes.Buffer)
+	err = Write(buf, got)
+	if err != nil {
+		t.Fatalf("could not write records: %+v", err)
+	}
+	w, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	if got, want := w.Header.Descr, r.Header.Descr; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid descr:\ngot= %+v\nwant=%+v", got, want)
+	}
+	if got, want := buf.Bytes()[w.data:], raw[r.data:]; !bytes.Equal(got, want) {
+		t.Fatalf("invalid data:\ngot= %q\nwant=%q", got, want)
+	}
+
+	t.Run("array", func(t *testing.T) {
+		var got [4]record
+		err := Read(bytes.NewReader(raw), &got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if !reflect.DeepEqual(got[:3], want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got[:3], want)
+		}
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		for i := range want {
+			var got record
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read record #%d: %+v", i, err)
+			}
+			if got != want[i] {
+				t.Fatalf("invalid record #%d:\ngot= %+v\nwant=%+v", i, got, want[i])
+			}
+		}
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Write(buf, want[0])
+		if err != nil {
+			t.Fatalf("could not write record: %+v", err)
+		}
+		var got record
+		err = Read(buf, &got)
+		if err != nil {
+			t.Fatalf("could not read record: %+v", err)
+		}
+		if got != want[0] {
+			t.Fatalf("invalid record:\ngot= %+v\nwant=%+v", got, want[0])
+		}
+	})
+
+	t.Run("padding", func(t *testing.T) {
+		type padded struct {
+			A uint8 `npy:"a"`
+			B int32 `npy:"b"`
+		}
+		hdr := newHeader()
+		hdr.Descr.Type = "[('a', '|u1'), ('', '|V3'), ('b', '<i4')]"
+		hdr.Descr.Shape = []int{2}
+
+		buf := new(bytes.Buffer)
+		err := writeHeader(buf, hdr, dType{})
+		if err != nil {
+			t.Fatalf("could not write header: %+v", err)
+		}
+		buf.Write([]byte{
+			1, 0xff, 0xff, 0xff, 2, 0, 0, 0,
+			3, 0xff, 0xff, 0xff, 4, 0, 0, 0,
+		})
+
+		var got []padded
+		err = Read(buf, &got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if want := []padded{{1, 2}, {3, 4}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		type mismatch struct {
+			Y float64 `npy:"y"`
+		}
+		var got []mismatch
+		err := Read(bytes.NewReader(raw), &got)
+		if !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+		}
+	})
+
+	t.Run("utf8-names", func(t *testing.T) {
+		type temperature struct {
+			T float64 `npy:"température"`
+		}
+		buf := new(bytes.Buffer)
+		err := Write(buf, []temperature{{1.5}, {-2}})
+		if err != nil {
+			t.Fatalf("could not write records: %+v", err)
+		}
+
+		r, err := NewReader(buf)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		if got, want := r.Header.Major, byte(3); got != want {
+			t.Fatalf("invalid major version: got=%d, want=%d", got, want)
+		}
+		var got []temperature
+		err = r.Read(&got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if want := []temperature{{1.5}, {-2}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("too-long", func(t *testing.T) {
+		rec := want[0]
+		rec.Name = "way too long"
+		err := Write(new(bytes.Buffer), []record{rec})
+		if !errors.Is(err, ErrOutOfRange) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, ErrOutOfRange)
+		}
+	})
+}
+
+func TestReadAlignedRecords(t *testing.T) {
+	type record struct {
+		A uint8     `npy:"a"`
+		B float64   `npy:"b"`
+		C int16     `npy:"c"`
+		D complex64 `npy:"d"`
+	}
+
+	want := []record{
+		{A: 1, B: 1.5, C: -2, D: 3 + 4i},
+		{A: 255, B: -0.25, C: 1024, D: -1 - 0.5i},
+		{A: 0, B: 0, C: 0, D: 0},
+	}
+
+	t.Run("numpy", func(t *testing.T) {
+		f, err := os.Open("../testdata/data_records_aligned.npy")
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		var got []record
+		err = Read(f, &got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	raw, err := os.ReadFile("../testdata/data_records_aligned.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	hdr, err := ReadHeader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not read header: %+v", err)
+	}
+	data := raw[len(raw)-3*32:]
+
+	for _, tc := range []struct {
+		name  string
+		descr string
+	}{
+		{
+			name:  "aligned",
+			descr: "{'names': ['a', 'b', 'c', 'd'], 'formats': ['|u1', '<f8', '<i2', '<c8'], 'aligned': True}",
+		},
+		{
+			name: "offsets",
+			descr: "{'names': ['d', 'a', 'c', 'b'], 'formats': ['<c8', '|u1', '<i2', '<f8'], " +
+				"'offsets': [20, 0, 16, 8], 'itemsize': 32}",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hdr := hdr
+			hdr.Descr.Type = tc.descr
+
+			buf := new(bytes.Buffer)
+			err := writeHeader(buf, hdr, dType{})
+			if err != nil {
+				t.Fatalf("could not write header: %+v", err)
+			}
+			buf.Write(data)
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Kind(), Record; got != want {
+				t.Fatalf("invalid kind: got=%v, want=%v", got, want)
+			}
+
+			var got []record
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read records: %+v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadRecordsFieldTypes(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/data_records_times.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	t.Run("times", func(t *testing.T) {
+		type record struct {
+			X    float64
+			Y    int32
+			Name string
+			T    time.Time
+			DT   time.Duration `npy:"dt"`
+		}
+		want := []record{
+			{1.5, -2, "alpha", time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), 1500 * time.Millisecond},
+			{-0.25, 7, "gamma123", time.Time{}, -250 * time.Millisecond},
+		}
+
+		var got []record
+		err := Read(bytes.NewReader(raw), &got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		type record struct {
+			Name []byte `npy:"name"`
+			T    int64  `npy:"t"`
+			DT   int64  `npy:"dt"`
+		}
+		want := []record{
+			{[]byte("alpha"), 1614834367, 1500},
+			{[]byte("gamma123"), nat, -250},
+		}
+
+		var got []record
+		err := Read(bytes.NewReader(raw), &got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("byte-array", func(t *testing.T) {
+		type record struct {
+			Name [10]byte `npy:"name"`
+		}
+		want := []record{
+			{[10]byte{'a', 'l', 'p', 'h', 'a'}},
+			{[10]byte{'g', 'a', 'm', 'm', 'a', '1', '2', '3'}},
+		}
+
+		var got []record
+		err := Read(bytes.NewReader(raw), &got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	for _, tc := range []struct {
+		name string
+		ptr  interface{}
+	}{
+		{"short-array", new([]struct {
+			Name [4]byte `npy:"name"`
+		})},
+		{"time-as-duration", new([]struct {
+			T time.Duration `npy:"t"`
+		})},
+		{"duration-as-time", new([]struct {
+			DT time.Time `npy:"dt"`
+		})},
+		{"int-as-bytes", new([]struct {
+			Y []byte `npy:"y"`
+		})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(bytes.NewReader(raw), tc.ptr)
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+			}
+		})
+	}
+}
+
+func TestWriteRecordsFieldTypes(t *testing.T) {
+	want, err := os.ReadFile("../testdata/data_records_times.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	type record struct {
+		X    float64       `npy:"x"`
+		Y    int32         `npy:"y"`
+		Name []byte        `npy:"name,|S8"`
+		T    time.Time     `npy:"t,<M8[s]"`
+		DT   time.Duration `npy:"dt,<m8[ms]"`
+	}
+	type rawRecord struct {
+		X    float64 `npy:"x"`
+		Y    int32   `npy:"y"`
+		Name [8]byte `npy:"name,|S8"`
+		T    int64   `npy:"t,<M8[s]"`
+		DT   int64   `npy:"dt,<m8[ms]"`
+	}
+
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+	}{
+		{
+			name: "times",
+			val: []record{
+				{1.5, -2, []byte("alpha"), time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), 1500 * time.Millisecond},
+				{-0.25, 7, []byte("gamma123"), time.Time{}, -250 * time.Millisecond},
+			},
+		},
+		{
+			name: "raw",
+			val: []rawRecord{
+				{1.5, -2, [8]byte{'a', 'l', 'p', 'h', 'a'}, 1614834367, 1500},
+				{-0.25, 7, [8]byte{'g', 'a', 'm', 'm', 'a', '1', '2', '3'}, nat, -250},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write records: %+v", err)
+			}
+			if got := buf.Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("invalid file content:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+
+	hdr, err := StructHeader(struct {
+		T  time.Time
+		DT time.Duration
+	}{})
+	if err != nil {
+		t.Fatalf("could not create header: %+v", err)
+	}
+	if got, want := hdr.Descr.Type, "[('T', '<M8[ns]'), ('DT', '<m8[ns]')]"; got != want {
+		t.Fatalf("invalid descr:\ngot= %s\nwant=%s", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		err  error
+	}{
+		{
+			name: "bytes-no-dtype",
+			val: []struct {
+				B []byte
+			}{{[]byte("a")}},
+			err: ErrInvalidType,
+		},
+		{
+			name: "bytes-too-long",
+			val: []struct {
+				B []byte `npy:"b,|S2"`
+			}{{[]byte("abc")}},
+			err: ErrOutOfRange,
+		},
+		{
+			name: "time-as-float",
+			val: []struct {
+				T time.Time `npy:"t,<f8"`
+			}{{}},
+			err: ErrTypeMismatch,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Write(new(bytes.Buffer), tc.val)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRecordsNested(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/data_records_nested.npy")
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+
+	type meta struct {
+		OK bool    `npy:"ok"`
+		T  float64 `npy:"t"`
+	}
+	type record struct {
+		ID   int32       `npy:"id"`
+		Pos  [3]float32  `npy:"pos"`
+		Grid [2][2]int16 `npy:"grid"`
+		Meta meta        `npy:"meta"`
+		Pts  [2]struct {
+			X float32 `npy:"x"`
+			Y float32 `npy:"y"`
+		} `npy:"pts"`
+	}
+
+	want := []record{
+		{ID: 1, Pos: [3]float32{1.5, -2, 0.25}, Grid: [2][2]int16{{1, 2}, {3, 4}}, Meta: meta{true, 3.5}},
+		{ID: -7, Pos: [3]float32{0, 0, 1}, Grid: [2][2]int16{{-1, 0}, {0, -1}}, Meta: meta{false, -0.5}},
+	}
+	want[0].Pts[0].X, want[0].Pts[0].Y, want[0].Pts[1].X, want[0].Pts[1].Y = 1, 2, 3, 4
+	want[1].Pts[0].X, want[1].Pts[0].Y, want[1].Pts[1].X, want[1].Pts[1].Y = -1, -2, 0.5, 0.25
+
+	t.Run("read", func(t *testing.T) {
+		var got []record
+		err := Read(bytes.NewReader(raw), &got)
+		if err != nil {
+			t.Fatalf("could not read records: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid records:\ngot= %+v\nwant=%+v", got, want)
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := Write(buf, want)
+		if err != nil {
+			t.Fatalf("could not write records: %+v", err)
+		}
+		if got := buf.Bytes(); !bytes.Equal(got, raw) {
+			t.Fatalf("invalid file content:\ngot= %q\nwant=%q", got, raw)
+		}
+	})
+
+	for _, tc := range []struct {
+		name string
+		ptr  interface{}
+	}{
+		{"short-subarray", new([]struct {
+			Pos [2]float32 `npy:"pos"`
+		})},
+		{"subarray-as-scalar", new([]struct {
+			Pos float32 `npy:"pos"`
+		})},
+		{"subarray-shape", new([]struct {
+			Grid [4]int16 `npy:"grid"`
+		})},
+		{"nested-as-scalar", new([]struct {
+			Meta float64 `npy:"meta"`
+		})},
+		{"nested-field", new([]struct {
+			Meta struct {
+				T int64 `npy:"t"`
+			} `npy:"meta"`
+		})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(bytes.NewReader(raw), tc.ptr)
+			if !errors.Is(err, ErrTypeMismatch) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+			}
+		})
+	}
+}
+
+func TestNewRecType(t *testing.T) {
+	for _, tc := range []struct {
+		descr string
+		want  recType
+		err   bool
+	}{
+		{
+			descr: "[('x', '<f8'), ('', '|V4'), ('y', '>i2')]",
+			want: recType{
+				fields: []recField{
+					{name: "x", offset: 0, dt: dType{str: "<f8", size: 8, order: orderFrom("<"), rt: float64Type}},
+					{name: "y", offset: 12, dt: dType{str: ">i2", size: 2, order: orderFrom(">"), rt: int16Type}},
+				},
+				size: 14,
+			},
+		},
+		{
+			descr: "{'names': ['a', 'b', 'c'], 'formats': ['|u1', '<U2', '|S3'], 'aligned': True}",
+			want: recType{
+				fields: []recField{
+					{name: "a", offset: 0, dt: dType{str: "|u1", size: 1, order: orderFrom("|"), rt: uint8Type}},
+					{name: "b", offset: 4, dt: dType{str: "<U2", size: 8, order: orderFrom("<"), rt: stringType, utf: true}},
+					{name: "c", offset: 12, dt: dType{str: "|S3", size: 3, order: orderFrom("|"), rt: stringType}},
+				},
+				size: 16,
+			},
+		},
+		{
+			descr: "{'names': ['x'], 'formats': ['<f8'], 'titles': [None], 'itemsize': 12}",
+			want: recType{
+				fields: []recField{
+					{name: "x", offset: 0, dt: dType{str: "<f8", size: 8, order: orderFrom("<"), rt: float64Type}},
+				},
+				size: 12,
+			},
+		},
+		{
+			descr: "[('v', '<f4', (2, 3)), ('p', [('x', '|u1'), ('y', '<i2')], 2)]",
+			want: recType{
+				fields: []recField{
+					{name: "v", offset: 0, dt: dType{str: "<f4", size: 4, order: orderFrom("<"), rt: float32Type}, shape: []int{2, 3}},
+					{name: "p", offset: 24, shape: []int{2}, rec: &recType{
+						fields: []recField{
+							{name: "x", offset: 0, dt: dType{str: "|u1", size: 1, order: orderFrom("|"), rt: uint8Type}},
+							{name: "y", offset: 1, dt: dType{str: "<i2", size: 2, order: orderFrom("<"), rt: int16Type}},
+						},
+						size: 3,
+					}},
+				},
+				size: 30,
+			},
+		},
+		{
+			descr: "{'names': ['a', 'v'], 'formats': ['|u1', ('<f8', (2,))], 'aligned': True}",
+			want: recType{
+				fields: []recField{
+					{name: "a", offset: 0, dt: dType{str: "|u1", size: 1, order: orderFrom("|"), rt: uint8Type}},
+					{name: "v", offset: 8, dt: dType{str: "<f8", size: 8, order: orderFrom("<"), rt: float64Type}, shape: []int{2}},
+				},
+				size: 24,
+			},
+		},
+		{descr: "<f8", err: true},
+		{descr: "[('v', '<f4', (-1,))]", err: true},
+		{descr: "[('v', '<f4', ('a',))]", err: true},
+		{descr: "[('v', '<f4', (2,), 1)]", err: true},
+		{descr: "[('p', [('x', '<q9')])]", err: true},
+		{descr: "{'names': ['x'], 'formats': ['<f8'], 'itemsize': 4}", err: true},
+		{descr: "{'names': ['x', 'y'], 'formats': ['<f8']}", err: true},
+		{descr: "{'names': ['x'], 'formats': ['<f8'], 'offsets': [-1]}", err: true},
+		{descr: "{'names': ['x'], 'formats': ['<f8'], 'titles': ['X']}", err: true},
+		{descr: "{'formats': ['<f8']}", err: true},
+		{descr: "[('x', '<f8'", err: true},
+		{descr: "[('x', '<q9')]", err: true},
+		{descr: "[(1, '<f8')]", err: true},
+	} {
+		t.Run(tc.descr, func(t *testing.T) {
+			got, err := newRecType(tc.descr)
+			switch {
+			case tc.err && err == nil:
+				t.Fatalf("expected an error")
+			case tc.err:
+				return
+			case err != nil:
+				t.Fatalf("could not parse descr: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid record type:\ngot= %+v\nwant=%+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStructHeader(t *testing.T) {
+	type point struct {
+		X     float64
+		Y     int32  `npy:"y"`
+		Name  string `npy:"name,|S8"`
+		Label string `npy:",<U3"`
+		Ok    bool
+		Z     float32 `npy:"z,>f4"`
+		Skip  int     `npy:"-"`
+		priv  int
+	}
+
+	for _, tc := range []struct {
+		name  string
+		v     interface{}
+		descr string
+		shape []int
+		size  int
+		err   error
+	}{
+		{
+			name:  "struct",
+			v:     point{},
+			descr: "[('X', '<f8'), ('y', '<i4'), ('name', '|S8'), ('Label', '<U3'), ('Ok', '|b1'), ('z', '>f4')]",
+			size:  8 + 4 + 8 + 12 + 1 + 4,
+		},
+		{
+			name:  "slice",
+			v:     []point{{}, {}, {}},
+			descr: "[('X', '<f8'), ('y', '<i4'), ('name', '|S8'), ('Label', '<U3'), ('Ok', '|b1'), ('z', '>f4')]",
+			shape: []int{3},
+			size:  8 + 4 + 8 + 12 + 1 + 4,
+		},
+		{
+			name:  "ptr-array",
+			v:     &[2]struct{ A, B int16 }{},
+			descr: "[('A', '<i2'), ('B', '<i2')]",
+			shape: []int{2},
+			size:  4,
+		},
+		{
+			name: "no-string-size",
+			v:    struct{ S string }{},
+			err:  ErrInvalidType,
+		},
+		{
+			name: "int",
+			v:    struct{ I int }{},
+			err:  ErrInvalidType,
+		},
+		{
+			name: "mismatch",
+			v: struct {
+				F float64 `npy:"f,<i8"`
+			}{},
+			err: ErrTypeMismatch,
+		},
+		{
+			name: "not-struct",
+			v:    []float64{1},
+			err:  ErrInvalidType,
+		},
+		{
+			name: "empty",
+			v:    struct{}{},
+			err:  ErrInvalidType,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hdr, err := StructHeader(tc.v)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%+v, want=%+v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not create header: %+v", err)
+			}
+
+			if got, want := hdr.Descr.Type, tc.descr; got != want {
+				t.Fatalf("invalid descr:\ngot= %s\nwant=%s", got, want)
+			}
+			if got, want := hdr.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+
+			rec, err := newRecType(hdr.Descr.Type)
+			if err != nil {
+				t.Fatalf("could not parse descr: %+v", err)
+			}
+			if got, want := rec.size, tc.size; got != want {
+				t.Fatalf("invalid itemsize: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Encoder encodes v, a value of a Go type registered with RegisterType,
+// into dst, whose length is the item size of the registered data type.
+type Encoder func(dst []byte, v interface{}) error
+
+// Decoder decodes src, holding one element of a data type registered with
+// RegisterType, into a value of the registered Go type.
+type Decoder func(src []byte) (interface{}, error)
+
+// extType is a data type registered with RegisterType.
+type extType struct {
+	descr string
+	rt    reflect.Type
+	size  int
+	enc   Encoder
+	dec   Decoder
+}
+
+// registry holds the data types registered with RegisterType.
+var registry struct {
+	sync.RWMutex
+	descrs map[string]*extType
+	types  map[reflect.Type]*extType
+}
+
+// RegisterType registers the NumPy data type descr, e.g. '<V2' for the
+// 2-byte bfloat16 type, as decoded into and encoded from values of the Go
+// type rt with dec and enc.
+//
+// Read consults the registered types for arrays whose descriptor is
+// exactly descr: they can be read into values, slices and arrays of rt.
+// Write and WriteWith consult them for values, and slices and arrays,
+// possibly nested, of rt. The WithFortranOrder and WithContiguous options
+// apply as for built-in types, while WriteWith fails with ErrInvalidType
+// if WithByteOrder asks for another byte order than the one of descr.
+//
+// RegisterType is meant for data types the package does not support
+// natively: the built-in data types, e.g. '<i4' or '<f8', are handled by
+// Read and Write directly and can not be registered.
+//
+// rt must be a named type other than the predeclared ones (bool, float64,
+// ...), and descr must hold the item size (e.g. 'V2') and not be supported
+// by the package already.
+// RegisterType panics if these conditions do not hold, if enc or dec is
+// nil, or if descr or rt is already registered.
+//
+// RegisterType is safe for concurrent use, but is typically called from
+// an init function.
+func RegisterType(descr string, rt reflect.Type, enc Encoder, dec Decoder) {
+	switch {
+	case rt == nil || rt.Name() == "" || rt.PkgPath() == "":
+		panic(fmt.Errorf("npy: can not register unnamed or predeclared type %v", rt))
+	case enc == nil || dec == nil:
+		panic(fmt.Errorf("npy: can not register dtype %q without an encoder and a decoder", descr))
+	}
+	if _, err := newDtype(descr); err == nil {
+		panic(fmt.Errorf("npy: can not register built-in dtype %q", descr))
+	}
+	if _, err := newRecType(descr); err == nil {
+		panic(fmt.Errorf("npy: can not register structured dtype %q", descr))
+	}
+	size, err := itemsizeFrom(descr)
+	if err != nil {
+		panic(err)
+	}
+
+	register(&extType{descr: descr, rt: rt, size: size, enc: enc, dec: dec})
+}
+
+func register(ext *extType) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.descrs[ext.descr]; dup {
+		panic(fmt.Errorf("npy: dtype %q already registered", ext.descr))
+	}
+	if _, dup := registry.types[ext.rt]; dup {
+		panic(fmt.Errorf("npy: type %v already registered", ext.rt))
+	}
+	if registry.descrs == nil {
+		registry.descrs = make(map[string]*extType)
+		registry.types = make(map[reflect.Type]*extType)
+	}
+	registry.descrs[ext.descr] = ext
+	registry.types[ext.rt] = ext
+}
+
+// lookupDescr returns the type registered for the data type descr.
+func lookupDescr(descr string) (*extType, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	ext, ok := registry.descrs[descr]
+	return ext, ok
+}
+
+// lookupType returns the type registered for the Go type rt.
+func lookupType(rt reflect.Type) (*extType, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	ext, ok := registry.types[rt]
+	return ext, ok
+}
+
+// lookupElem returns the type registered for the Go type rt, or for the
+// elements of rt if rt is a slice or an array type, possibly nested.
+func lookupElem(rt reflect.Type) (*extType, bool) {
+	for {
+		if ext, ok := lookupType(rt); ok {
+			return ext, true
+		}
+		switch rt.Kind() {
+		case reflect.Slice, reflect.Array:
+			rt = rt.Elem()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// readExt reads the array data into rv, a value, a slice or an array of
+// the Go type of ext.
+func (r *Reader) readExt(rv reflect.Value, ext *extType) error {
+	n := numElems(r.Header.Descr.Shape)
+	switch {
+	case rv.Type() == ext.rt:
+		if n != 1 {
+			return fmt.Errorf("npy: can not read %d elements into %v: %w", n, rv.Type(), errDims)
+		}
+		return r.readExtElems(rv, 1, ext)
+
+	case rv.Kind() == reflect.Slice && rv.Type().Elem() == ext.rt:
+		err := r.checkMem(ext.size)
+		if err != nil {
+			return err
+		}
+		return r.readExtElems(rv, resizeValue(rv, n), ext)
+
+	case rv.Kind() == reflect.Array && rv.Type().Elem() == ext.rt:
+		if rv.Len() != n {
+			return fmt.Errorf("npy: can not read %d elements into %v: %w", n, rv.Type(), errDims)
+		}
+		return r.readExtElems(rv, n, ext)
+	}
+	return ErrTypeMismatch
+}
+
+// readExtElems decodes n elements with the decoder of ext, into rv or into
+// the elements of rv if n is not 1.
+func (r *Reader) readExtElems(rv reflect.Value, n int, ext *extType) error {
+	buf := make([]byte, ext.size)
+	for i := 0; i < n; i++ {
+		_, err := r.read(buf)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		v, err := ext.dec(buf)
+		if err != nil {
+			return fmt.Errorf("npy: could not decode %q element: %w", ext.descr, err)
+		}
+		vv := reflect.ValueOf(v)
+		if !vv.IsValid() || vv.Type() != ext.rt {
+			return fmt.Errorf("npy: decoder of %q returned %T, want %v: %w", ext.descr, v, ext.rt, ErrTypeMismatch)
+		}
+		if rv.Type() == ext.rt {
+			rv.Set(vv)
+			continue
+		}
+		rv.Index(i).Set(vv)
+	}
+	return r.err
+}
+
+// writeExt writes rv, a value, or a slice or an array, possibly nested, of
+// the Go type of ext, as a NumPy array of the data type of ext.
+func writeExt(w io.Writer, rv reflect.Value, ext *extType, cfg writeConfig) error {
+	if cfg.big && ext.descr[0] == '<' {
+		return fmt.Errorf("npy: byte order of registered dtype %q can not be changed: %w", ext.descr, ErrInvalidType)
+	}
+	if cfg.contiguous && !isContiguous(rv) {
+		return fmt.Errorf("npy: value of type %v is not C-contiguous: %w", rv.Type(), ErrNotContiguous)
+	}
+
+	shape, vs, err := extElems(rv, ext.rt)
+	if err != nil {
+		return err
+	}
+
+	hdr := newHeader()
+	hdr.Descr.Type = ext.descr
+	hdr.Descr.Shape = shape
+	hdr.Descr.Fortran = cfg.fortran && len(shape) > 1
+
+	err = writeHeader(w, hdr, dType{})
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, ext.size*len(vs))
+	for i, v := range vs {
+		err := ext.enc(buf[i*ext.size:(i+1)*ext.size], v.Interface())
+		if err != nil {
+			return fmt.Errorf("npy: could not encode %q element: %w", ext.descr, err)
+		}
+	}
+	if hdr.Descr.Fortran {
+		buf = fortranOrder(buf, shape, ext.size)
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// extElems returns the shape of rv, a value, or a slice or an array,
+// possibly nested, of values of type rt, and these values in C-order.
+func extElems(rv reflect.Value, rt reflect.Type) ([]int, []reflect.Value, error) {
+	if rv.Type() == rt {
+		return nil, []reflect.Value{rv}, nil
+	}
+
+	var (
+		n      = rv.Len()
+		eshape []int
+		vs     = make([]reflect.Value, 0, n)
+	)
+	for i := 0; i < n; i++ {
+		shape, evs, err := extElems(rv.Index(i), rt)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case i == 0:
+			eshape = shape
+		case !equalShapes(shape, eshape):
+			return nil, nil, fmt.Errorf(
+				"npy: ragged slice of type %v: element #%d has shape %v, want %v: %w",
+				rv.Type(), i, shape, eshape, errDims,
+			)
+		}
+		vs = append(vs, evs...)
+	}
+	return append([]int{n}, eshape...), vs, nil
+}
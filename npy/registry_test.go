@@ -0,0 +1,261 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// brainFloat is the truncated float32 type used by machine learning
+// frameworks, stored as '<V2' in NumPy data files.
+type brainFloat uint16
+
+func (v brainFloat) Float32() float32 { return math.Float32frombits(uint32(v) << 16) }
+
+var registerBfloat16 sync.Once
+
+func withBfloat16(t *testing.T) {
+	t.Helper()
+	registerBfloat16.Do(func() {
+		RegisterType(
+			"<V2", reflect.TypeOf(brainFloat(0)),
+			func(dst []byte, v interface{}) error {
+				binary.LittleEndian.PutUint16(dst, uint16(v.(brainFloat)))
+				return nil
+			},
+			func(src []byte) (interface{}, error) {
+				return brainFloat(binary.LittleEndian.Uint16(src)), nil
+			},
+		)
+	})
+}
+
+func TestRegisterType(t *testing.T) {
+	withBfloat16(t)
+
+	bf := func(v float32) brainFloat { return brainFloat(math.Float32bits(v) >> 16) }
+	want := []brainFloat{bf(1), bf(-2.5), bf(1024)}
+
+	buf := new(bytes.Buffer)
+	err := Write(buf, want)
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	raw := buf.Bytes()
+
+	hdr, err := ReadHeader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("could not read header: %+v", err)
+	}
+	if got, want := hdr.Descr.Type, "<V2"; got != want {
+		t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+	}
+	if got, want := hdr.Descr.Shape, []int{3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+	if got, err := hdr.ItemSize(); err != nil || got != 2 {
+		t.Fatalf("invalid item size: got=%d, want=%d (err=%v)", got, 2, err)
+	}
+	if got, err := hdr.Dtype(); err != nil || got != reflect.TypeOf(brainFloat(0)) {
+		t.Fatalf("invalid Go type: got=%v, want=%v (err=%v)", got, reflect.TypeOf(brainFloat(0)), err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		ptr  interface{}
+		want interface{}
+		err  error
+	}{
+		{"slice", new([]brainFloat), want, nil},
+		{"array", new([3]brainFloat), [3]brainFloat{want[0], want[1], want[2]}, nil},
+		{"array-len", new([2]brainFloat), nil, errDims},
+		{"scalar", new(brainFloat), nil, errDims},
+		{"mismatch", new([]uint16), nil, ErrTypeMismatch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Read(bytes.NewReader(raw), tc.ptr)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := reflect.ValueOf(tc.ptr).Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+
+	buf.Reset()
+	err = Write(buf, bf(3))
+	if err != nil {
+		t.Fatalf("could not write scalar: %+v", err)
+	}
+	var v brainFloat
+	err = Read(buf, &v)
+	if err != nil {
+		t.Fatalf("could not read scalar: %+v", err)
+	}
+	if got, want := v.Float32(), float32(3); got != want {
+		t.Fatalf("invalid scalar: got=%v, want=%v", got, want)
+	}
+}
+
+func TestRegisterTypeInvalid(t *testing.T) {
+	withBfloat16(t)
+
+	type fixed int32
+	var (
+		enc = func(dst []byte, v interface{}) error { return nil }
+		dec = func(src []byte) (interface{}, error) { return fixed(0), nil }
+	)
+	for _, tc := range []struct {
+		name  string
+		descr string
+		rt    reflect.Type
+		enc   Encoder
+		dec   Decoder
+	}{
+		{"predeclared", "<V4", reflect.TypeOf(int32(0)), enc, dec},
+		{"unnamed", "<V4", reflect.TypeOf([]fixed(nil)), enc, dec},
+		{"no-codec", "<V4", reflect.TypeOf(fixed(0)), nil, dec},
+		{"built-in", "<i4", reflect.TypeOf(fixed(0)), enc, dec},
+		{"no-size", "<V", reflect.TypeOf(fixed(0)), enc, dec},
+		{"dup-descr", "<V2", reflect.TypeOf(fixed(0)), enc, dec},
+		{"dup-type", "<V4", reflect.TypeOf(brainFloat(0)), enc, dec},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if e := recover(); e == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			RegisterType(tc.descr, tc.rt, tc.enc, tc.dec)
+		})
+	}
+
+	// errors of decoders are reported.
+	registerFailing.Do(func() {
+		RegisterType("<V3", reflect.TypeOf(fixed(0)), enc, func(src []byte) (interface{}, error) {
+			return nil, fmt.Errorf("boom")
+		})
+	})
+	buf := new(bytes.Buffer)
+	err := Write(buf, []fixed{1, 2})
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	var got []fixed
+	err = Read(buf, &got)
+	if err == nil {
+		t.Fatalf("expected a decoding error")
+	}
+}
+
+var registerFailing sync.Once
+
+func TestWriteExtOptions(t *testing.T) {
+	withBfloat16(t)
+
+	vs := [][]brainFloat{{1, 2, 3}, {4, 5, 6}}
+	for _, tc := range []struct {
+		name    string
+		val     interface{}
+		opts    []WriteOption
+		shape   []int
+		fortran bool
+		want    []brainFloat
+		err     error
+	}{
+		{
+			name:  "nested",
+			val:   vs,
+			shape: []int{2, 3},
+			want:  []brainFloat{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:  "array",
+			val:   [2][1]brainFloat{{1}, {2}},
+			shape: []int{2, 1},
+			want:  []brainFloat{1, 2},
+		},
+		{
+			name:    "fortran",
+			val:     vs,
+			opts:    []WriteOption{WithFortranOrder(true)},
+			shape:   []int{2, 3},
+			fortran: true,
+			want:    []brainFloat{1, 4, 2, 5, 3, 6},
+		},
+		{
+			name:  "fortran-1d",
+			val:   vs[0],
+			opts:  []WriteOption{WithFortranOrder(true)},
+			shape: []int{3},
+			want:  []brainFloat{1, 2, 3},
+		},
+		{
+			name: "not-contiguous",
+			val:  vs,
+			opts: []WriteOption{WithContiguous()},
+			err:  ErrNotContiguous,
+		},
+		{
+			name: "big-endian",
+			val:  vs[0],
+			opts: []WriteOption{WithByteOrder(binary.BigEndian)},
+			err:  ErrInvalidType,
+		},
+		{
+			name: "ragged",
+			val:  [][]brainFloat{{1, 2}, {3}},
+			err:  errDims,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, tc.val, tc.opts...)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+			if got, want := r.Header.Descr.Fortran, tc.fortran; got != want {
+				t.Fatalf("invalid order: got=%v, want=%v", got, want)
+			}
+
+			var got []brainFloat
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// RowReader reads the rows of a 2-dimensional float64 array from a NumPy
+// data file, a chunk of rows at a time, without loading the whole array
+// in memory.
+type RowReader struct {
+	Header Header
+
+	r    *Reader
+	rows int // number of rows of the array
+	cols int // number of columns of the array
+	row  int // index of the next row to read
+
+	// Fortran-ordered arrays are either read with strided reads from
+	// ra, when the underlying reader supports it, or loaded in data.
+	ra   io.ReaderAt
+	base int64 // offset of the array data in ra
+	buf  []byte
+	data []float64
+}
+
+// NewRowReader creates a new RowReader reading from r.
+//
+// The header of the file is parsed by NewRowReader and exposed through the
+// Header field. Only arrays of float64 elements with up to 2 dimensions
+// are supported: 1-dimensional arrays are read as row vectors, unless
+// the WithVector option is provided.
+//
+// Fortran-ordered arrays are transposed on the fly. This needs random
+// access to their data: if r does not implement io.ReaderAt and io.Seeker,
+// as an *os.File does, the whole array data is loaded in memory instead.
+func NewRowReader(r io.Reader, opts ...ReadOption) (*RowReader, error) {
+	start := int64(-1)
+	ra, ok := r.(io.ReaderAt)
+	if sk, isSeeker := r.(io.Seeker); ok && isSeeker {
+		pos, err := sk.Seek(0, io.SeekCurrent)
+		if err == nil {
+			start = pos
+		}
+	}
+
+	rr, err := NewReader(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	dt, err := newDtype(rr.Header.Descr.Type)
+	if err != nil {
+		return nil, err
+	}
+	if dt.rt != float64Type {
+		return nil, fmt.Errorf("npy: can not read rows of array of dtype %q: %w", rr.Header.Descr.Type, ErrTypeMismatch)
+	}
+	rr.order = dt.order
+
+	rows, cols, err := dimsFromShape(rr.Header.Descr.Shape)
+	if err != nil {
+		return nil, fmt.Errorf("npy: can not read rows of array: %w", err)
+	}
+	if len(rr.Header.Descr.Shape) == 1 && rr.vector == RowVector {
+		rows, cols = cols, rows
+	}
+
+	rdr := &RowReader{
+		Header: rr.Header,
+		r:      rr,
+		rows:   rows,
+		cols:   cols,
+	}
+
+	if !rr.Header.Descr.Fortran || rows < 2 || cols < 2 {
+		return rdr, nil
+	}
+
+	if start >= 0 {
+		rdr.ra = ra
+		rdr.base = start + rr.data
+		return rdr, nil
+	}
+
+	err = rr.checkMem(dt.size)
+	if err != nil {
+		return nil, err
+	}
+	rdr.data = make([]float64, rows*cols)
+	err = rr.Read(&rdr.data)
+	if err != nil {
+		return nil, err
+	}
+	return rdr, nil
+}
+
+// Next reads the next rows of the array into dst, as many full rows as fit
+// in dst, stored one after the other.
+// Next returns the number of rows read, or io.EOF once all rows have been
+// read.
+// Next fails if dst can not hold a single row.
+func (r *RowReader) Next(dst []float64) (int, error) {
+	if r.row >= r.rows || r.cols == 0 {
+		return 0, io.EOF
+	}
+
+	n := min(len(dst)/r.cols, r.rows-r.row)
+	if n == 0 {
+		return 0, fmt.Errorf(
+			"npy: buffer of %d elements too small for a row of %d elements: %w",
+			len(dst), r.cols, errDims,
+		)
+	}
+	dst = dst[:n*r.cols]
+
+	switch {
+	case r.ra != nil:
+		const sz = 8
+		if len(r.buf) < n*sz {
+			r.buf = make([]byte, n*sz)
+		}
+		buf := r.buf[:n*sz]
+		for j := 0; j < r.cols; j++ {
+			off := r.base + int64(j*r.rows+r.row)*sz
+			nn, err := r.ra.ReadAt(buf, off)
+			if err != nil && !(err == io.EOF && nn == len(buf)) {
+				return 0, fmt.Errorf("npy: could not read column %d: %w", j, err)
+			}
+			for i := 0; i < n; i++ {
+				dst[i*r.cols+j] = math.Float64frombits(r.r.order.Uint64(buf[i*sz:]))
+			}
+		}
+
+	case r.data != nil:
+		for i := 0; i < n; i++ {
+			for j := 0; j < r.cols; j++ {
+				dst[i*r.cols+j] = r.data[j*r.rows+r.row+i]
+			}
+		}
+
+	default:
+		err := r.r.Read(&dst)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	r.row += n
+	return n, nil
+}
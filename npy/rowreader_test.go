@@ -0,0 +1,180 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestRowReader(t *testing.T) {
+	// a 5x4 matrix with m[i,j] = 10*i + j.
+	const rows, cols = 5, 4
+	want := make([]float64, 0, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			want = append(want, float64(10*i+j))
+		}
+	}
+
+	mk := func(t *testing.T, fortran bool) []byte {
+		hdr := newHeader()
+		hdr.Descr.Type = "<f8"
+		hdr.Descr.Fortran = fortran
+		hdr.Descr.Shape = []int{rows, cols}
+		dt, err := newDtype(hdr.Descr.Type)
+		if err != nil {
+			t.Fatalf("could not create dtype: %+v", err)
+		}
+
+		buf := new(bytes.Buffer)
+		err = writeHeader(buf, hdr, dt)
+		if err != nil {
+			t.Fatalf("could not write header: %+v", err)
+		}
+		data := want
+		if fortran {
+			data = make([]float64, 0, len(want))
+			for j := 0; j < cols; j++ {
+				for i := 0; i < rows; i++ {
+					data = append(data, want[i*cols+j])
+				}
+			}
+		}
+		err = writeData(buf, reflect.ValueOf(data), dt)
+		if err != nil {
+			t.Fatalf("could not write data: %+v", err)
+		}
+		return buf.Bytes()
+	}
+
+	for _, tc := range []struct {
+		name    string
+		fortran bool
+		stream  bool // whether to hide the io.ReaderAt interface
+		chunk   int  // number of rows per call to Next
+	}{
+		{name: "c-order-1", chunk: 1},
+		{name: "c-order-2", chunk: 2},
+		{name: "c-order-all", chunk: rows + 1},
+		{name: "f-order-strided-1", fortran: true, chunk: 1},
+		{name: "f-order-strided-2", fortran: true, chunk: 2},
+		{name: "f-order-memory-1", fortran: true, stream: true, chunk: 1},
+		{name: "f-order-memory-3", fortran: true, stream: true, chunk: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var r io.Reader = bytes.NewReader(mk(t, tc.fortran))
+			if tc.stream {
+				r = io.MultiReader(r)
+			}
+
+			rr, err := NewRowReader(r)
+			if err != nil {
+				t.Fatalf("could not create row reader: %+v", err)
+			}
+			if got, want := rr.Header.Descr.Shape, []int{rows, cols}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+
+			var (
+				got []float64
+				buf = make([]float64, tc.chunk*cols+1)
+			)
+			for {
+				n, err := rr.Next(buf)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("could not read rows: %+v", err)
+				}
+				if n > tc.chunk {
+					t.Fatalf("too many rows: got=%d, want<=%d", n, tc.chunk)
+				}
+				got = append(got, buf[:n*cols]...)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+
+	t.Run("small-buffer", func(t *testing.T) {
+		rr, err := NewRowReader(bytes.NewReader(mk(t, false)))
+		if err != nil {
+			t.Fatalf("could not create row reader: %+v", err)
+		}
+		_, err = rr.Next(make([]float64, cols-1))
+		if !errors.Is(err, errDims) {
+			t.Fatalf("invalid error: got=%v, want=%v", err, errDims)
+		}
+	})
+}
+
+func TestRowReaderFile(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		want  [][]float64
+	}{
+		{
+			fname: "../testdata/data_float64_2x3_corder.npy",
+			want:  [][]float64{{0, 1, 2}, {3, 4, 5}},
+		},
+		{
+			fname: "../testdata/data_float64_2x3_forder.npy",
+			want:  [][]float64{{0, 2, 4}, {1, 3, 5}},
+		},
+		{
+			fname: "../testdata/data_float64_6x1_forder.npy",
+			want:  [][]float64{{0}, {1}, {2}, {3}, {4}, {5}},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			f, err := os.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			rr, err := NewRowReader(f)
+			if err != nil {
+				t.Fatalf("could not create row reader: %+v", err)
+			}
+
+			var got [][]float64
+			for {
+				row := make([]float64, len(tc.want[0]))
+				_, err := rr.Next(row)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("could not read row: %+v", err)
+				}
+				got = append(got, row)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRowReaderDtype(t *testing.T) {
+	f, err := os.Open("../testdata/data_float32_2x3_corder.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	_, err = NewRowReader(f)
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+	}
+}
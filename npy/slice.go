@@ -0,0 +1,50 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"io"
+	"reflect"
+)
+
+// ReadSlice reads the numpy-array data from r into a slice of T, in storage
+// order, and returns it together with the header of the array.
+//
+// ReadSlice returns an error if the on-disk data type and T don't match,
+// unless the WithConvert option allows the conversion, e.g. of '<i4' data
+// into a []int64.
+func ReadSlice[T Numeric](r io.Reader, opts ...ReadOption) ([]T, Header, error) {
+	rr, err := NewReader(r, opts...)
+	if err != nil {
+		return nil, Header{}, err
+	}
+
+	var data []T
+	err = rr.Read(&data)
+	if err != nil {
+		return nil, rr.Header, err
+	}
+
+	return data, rr.Header, nil
+}
+
+// WriteSlice writes data into w in the NumPy data format, as a C-ordered
+// array with the provided shape.
+// If no shape is provided, data is written out as a 1-dimensional array.
+//
+// WriteSlice returns an error if the number of elements of data does not
+// match the shape.
+func WriteSlice[T Numeric](w io.Writer, data []T, shape ...int) error {
+	if len(shape) == 0 {
+		shape = []int{len(data)}
+	}
+
+	descr, err := dtypeFrom(reflect.Value{}, reflect.TypeOf(data).Elem())
+	if err != nil {
+		return wrapErr("write", err)
+	}
+
+	return wrapErr("write", WriteWithDescr(w, data, descr, shape))
+}
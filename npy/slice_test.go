@@ -0,0 +1,88 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadSlice(t *testing.T) {
+	f, err := os.Open("../testdata/data_int32_2x3_corder.npy")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	_, _, err = ReadSlice[int64](f)
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+	}
+
+	_, err = f.Seek(0, 0)
+	if err != nil {
+		t.Fatalf("could not rewind file: %+v", err)
+	}
+
+	got, hdr, err := ReadSlice[int64](f, WithConvert())
+	if err != nil {
+		t.Fatalf("could not read slice: %+v", err)
+	}
+	if want := []int64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := hdr.Descr.Type, "<i4"; got != want {
+		t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+	}
+	if got, want := hdr.Descr.Shape, []int{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+}
+
+func TestWriteSlice(t *testing.T) {
+	data := []float32{0, 1, 2, 3, 4, 5}
+	for _, tc := range []struct {
+		name  string
+		shape []int
+		want  []int
+		err   error
+	}{
+		{name: "1d", want: []int{6}},
+		{name: "2x3", shape: []int{2, 3}, want: []int{2, 3}},
+		{name: "3x2", shape: []int{3, 2}, want: []int{3, 2}},
+		{name: "invalid", shape: []int{4, 2}, err: errDims},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteSlice(buf, data, tc.shape...)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not write slice: %+v", err)
+			}
+
+			got, hdr, err := ReadSlice[float32](buf)
+			if err != nil {
+				t.Fatalf("could not read slice: %+v", err)
+			}
+			if !reflect.DeepEqual(got, data) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, data)
+			}
+			if got, want := hdr.Descr.Type, "<f4"; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+			if got := hdr.Descr.Shape; !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
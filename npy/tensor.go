@@ -0,0 +1,131 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+)
+
+// Numeric is the set of Go types that can be read into a Tensor, or read
+// and written with ReadSlice and WriteSlice.
+type Numeric interface {
+	int8 | int16 | int32 | int64 |
+		uint8 | uint16 | uint32 | uint64 |
+		float32 | float64 |
+		complex64 | complex128
+}
+
+// Tensor is a lightweight n-dimensional array.
+//
+// Data holds the elements of the array as they were laid out on disk,
+// in C (row-major) order or, if Fortran is true, in Fortran
+// (column-major) order.
+type Tensor[T Numeric] struct {
+	Data    []T
+	Shape   []int
+	Fortran bool
+}
+
+// ReadTensor reads the numpy-array data from r into a Tensor.
+// ReadTensor returns an error if the on-disk data type and T don't match,
+// unless the WithConvert option allows the conversion.
+func ReadTensor[T Numeric](r io.Reader, opts ...ReadOption) (*Tensor[T], error) {
+	rr, err := NewReader(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []T
+	err = rr.Read(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tensor[T]{
+		Data:    data,
+		Shape:   append([]int(nil), rr.Header.Descr.Shape...),
+		Fortran: rr.Header.Descr.Fortran,
+	}, nil
+}
+
+// At returns the element at the provided indices.
+// At panics if the number of indices does not match the number of
+// dimensions of the tensor, or if an index is out of range.
+func (t *Tensor[T]) At(indices ...int) T {
+	if len(indices) != len(t.Shape) {
+		panic(fmt.Errorf(
+			"npy: invalid number of indices (got=%d, want=%d)",
+			len(indices), len(t.Shape),
+		))
+	}
+
+	var (
+		idx    = 0
+		stride = 1
+	)
+	for i := range indices {
+		dim := i
+		if !t.Fortran {
+			dim = len(indices) - 1 - i
+		}
+		v := indices[dim]
+		if v < 0 || v >= t.Shape[dim] {
+			panic(fmt.Errorf(
+				"npy: index %d out of range for axis %d (size=%d)",
+				v, dim, t.Shape[dim],
+			))
+		}
+		idx += v * stride
+		stride *= t.Shape[dim]
+	}
+	return t.Data[idx]
+}
+
+// Reshape returns a new tensor sharing the data of t, with the provided shape.
+// The memory order of t is preserved.
+// One of the dimensions may be -1, in which case it is inferred from the
+// number of elements and the remaining dimensions.
+func (t *Tensor[T]) Reshape(shape ...int) (*Tensor[T], error) {
+	var (
+		n     = 1
+		infer = -1
+	)
+	shape = append([]int(nil), shape...)
+	for i, v := range shape {
+		switch {
+		case v == -1 && infer < 0:
+			infer = i
+		case v < 0:
+			return nil, fmt.Errorf("npy: invalid shape %v: %w", shape, errDims)
+		default:
+			n *= v
+		}
+	}
+
+	if infer >= 0 {
+		if n == 0 || len(t.Data)%n != 0 {
+			return nil, fmt.Errorf(
+				"npy: can not reshape array of size %d into shape %v: %w",
+				len(t.Data), shape, errDims,
+			)
+		}
+		shape[infer] = len(t.Data) / n
+		n = len(t.Data)
+	}
+
+	if n != len(t.Data) {
+		return nil, fmt.Errorf(
+			"npy: can not reshape array of size %d into shape %v: %w",
+			len(t.Data), shape, errDims,
+		)
+	}
+
+	return &Tensor[T]{
+		Data:    t.Data,
+		Shape:   shape,
+		Fortran: t.Fortran,
+	}, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadTensor(t *testing.T) {
+	for _, fname := range []string{
+		"../testdata/data_float64_2x3_corder.npy",
+		"../testdata/data_float64_2x3_forder.npy",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			f, err := os.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", fname, err)
+			}
+			defer f.Close()
+
+			tsr, err := ReadTensor[float64](f)
+			if err != nil {
+				t.Fatalf("could not read tensor: %+v", err)
+			}
+
+			if got, want := tsr.Shape, []int{2, 3}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+
+			for i := 0; i < 2; i++ {
+				for j := 0; j < 3; j++ {
+					want := float64(3*i + j)
+					if tsr.Fortran {
+						want = float64(i + 2*j)
+					}
+					if got := tsr.At(i, j); got != want {
+						t.Fatalf("invalid value at (%d,%d): got=%v, want=%v", i, j, got, want)
+					}
+				}
+			}
+
+			flat, err := tsr.Reshape(-1)
+			if err != nil {
+				t.Fatalf("could not reshape: %+v", err)
+			}
+			if got, want := flat.Shape, []int{6}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+
+			_, err = tsr.Reshape(4, -1)
+			if !errors.Is(err, errDims) {
+				t.Fatalf("invalid reshape error: %+v", err)
+			}
+		})
+	}
+}
+
+func TestTensor(t *testing.T) {
+	tsr := &Tensor[int32]{
+		Data:  []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+		Shape: []int{2, 3, 4},
+	}
+	if got, want := tsr.At(1, 2, 3), int32(23); got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+	if got, want := tsr.At(1, 0, 2), int32(14); got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+
+	m, err := tsr.Reshape(6, -1)
+	if err != nil {
+		t.Fatalf("could not reshape: %+v", err)
+	}
+	if got, want := m.At(5, 3), int32(23); got != want {
+		t.Fatalf("invalid value: got=%v, want=%v", got, want)
+	}
+
+	for _, idx := range [][]int{{0, 0}, {2, 0, 0}, {0, -1, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic for indices %v", idx)
+				}
+			}()
+			_ = tsr.At(idx...)
+		}()
+	}
+}
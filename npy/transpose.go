@@ -0,0 +1,55 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"fmt"
+	"io"
+)
+
+// Transpose2D writes to dst the transpose of the 2-dimensional numeric
+// array described by hdr, whose data is read from src.
+//
+// src holds the array data only, starting at offset 0, e.g. an
+// io.SectionReader starting at the data offset returned by ReadHeaderAt.
+//
+// The transpose of a C-ordered array of shape (m, n) holds the same data
+// as a Fortran-ordered array of shape (n, m), and vice versa: Transpose2D
+// writes the array data as is, with the shape flipped and the memory
+// order toggled. The data is streamed from src to dst, so that only a
+// small, fixed amount of memory is used, whatever the size of the array.
+func Transpose2D(dst io.Writer, src io.ReaderAt, hdr Header) error {
+	shape := hdr.Descr.Shape
+	if len(shape) != 2 {
+		return fmt.Errorf("npy: can not transpose array of shape %v: %w", shape, errDims)
+	}
+	dt, err := newDtype(hdr.Descr.Type)
+	if err != nil {
+		return err
+	}
+	if dt.rt == stringType {
+		return fmt.Errorf("npy: can not transpose array of dtype %q: %w", hdr.Descr.Type, ErrInvalidType)
+	}
+
+	out := newHeader()
+	out.Descr.Type = hdr.Descr.Type
+	out.Descr.Fortran = !hdr.Descr.Fortran
+	out.Descr.Shape = []int{shape[1], shape[0]}
+
+	err = writeHeader(dst, out, dt)
+	if err != nil {
+		return err
+	}
+
+	size := int64(shape[0]) * int64(shape[1]) * int64(dt.size)
+	_, err = io.CopyN(dst, io.NewSectionReader(src, 0, size), size)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("npy: could not copy array data: %w", err)
+	}
+	return nil
+}
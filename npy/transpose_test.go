@@ -0,0 +1,103 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestTranspose2D(t *testing.T) {
+	big := mat.NewDense(300, 1000, nil)
+	for i := 0; i < 300; i++ {
+		for j := 0; j < 1000; j++ {
+			big.Set(i, j, float64(i*1000+j))
+		}
+	}
+
+	raw := func(m *mat.Dense) []byte {
+		buf := new(bytes.Buffer)
+		err := Write(buf, m)
+		if err != nil {
+			t.Fatalf("could not write matrix: %+v", err)
+		}
+		return buf.Bytes()
+	}
+
+	file := func(fname string) []byte {
+		raw, err := os.ReadFile(fname)
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", fname, err)
+		}
+		return raw
+	}
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+	}{
+		{"corder", file("../testdata/data_float64_2x3_corder.npy")},
+		{"forder", file("../testdata/data_float64_2x3_forder.npy")},
+		{"6x1", file("../testdata/data_float64_6x1_corder.npy")},
+		{"big", raw(big)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var want mat.Dense
+			err := Read(bytes.NewReader(tc.raw), &want)
+			if err != nil {
+				t.Fatalf("could not read matrix: %+v", err)
+			}
+
+			src := bytes.NewReader(tc.raw)
+			hdr, off, err := ReadHeaderAt(src, 0)
+			if err != nil {
+				t.Fatalf("could not read header: %+v", err)
+			}
+
+			buf := new(bytes.Buffer)
+			err = Transpose2D(buf, io.NewSectionReader(src, off, math.MaxInt64-off), hdr)
+			if err != nil {
+				t.Fatalf("could not transpose: %+v", err)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Fortran, !hdr.Descr.Fortran; got != want {
+				t.Fatalf("invalid order: got=%v, want=%v", got, want)
+			}
+
+			var got mat.Dense
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read transposed matrix: %+v", err)
+			}
+			if !mat.Equal(&got, want.T()) {
+				t.Fatalf("invalid transpose:\ngot= %v\nwant=%v", mat.Formatted(&got), mat.Formatted(want.T()))
+			}
+		})
+	}
+
+	var hdr Header
+	hdr.Descr.Type = "<f8"
+	hdr.Descr.Shape = []int{2, 3, 4}
+	err := Transpose2D(io.Discard, bytes.NewReader(nil), hdr)
+	if !errors.Is(err, errDims) {
+		t.Fatalf("invalid error: %+v", err)
+	}
+
+	hdr.Descr.Shape = []int{2, 3}
+	err = Transpose2D(io.Discard, bytes.NewReader(make([]byte, 5*8)), hdr)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -25,15 +25,154 @@ var (
 // Write writes 'val' into 'w' in the NumPy data format.
 //
 //   - if val is a scalar, it must be of a supported type (bools, (u)ints, floats and complexes)
+//     it is written out as a 0-dimensional array, of shape (), as np.save does.
 //   - if val is a slice or array, it must be a slice/array of a supported type.
 //     the shape (len,) will be written out.
 //   - if val is a mat.Dense, the correct shape will be transmitted. (ie: (nrows, ncols))
+//     Other mat.Matrix values (mat.SymDense, mat.TriDense, ...) are written
+//     out as the equivalent mat.Dense.
+//   - time.Time and time.Duration values are written as datetime64[ns] and
+//     timedelta64[ns] data. The zero time.Time is written as NaT.
+//     Use WriteWith and the WithTimeUnit option to write them in another
+//     unit.
+//   - if val is a struct, or a slice/array of structs, it is written as a
+//     structured array, as described by StructHeader.
 //
 // The data-array will always be written out in C-order (row-major).
+// Use WriteWith and the WithFortranOrder option to write it out in
+// Fortran-order (column-major).
+//
+// As with NumPy, the header is written with the smallest version of the
+// file format able to encode it: 1.0, or 2.0 for headers longer than
+// 65535 bytes, or 3.0 for headers with non-ASCII characters.
 func Write(w io.Writer, val interface{}) error {
+	return WriteWith(w, val)
+}
+
+// WriteOption configures how values are written.
+type WriteOption func(cfg *writeConfig)
+
+type writeConfig struct {
+	cast       CastMode
+	contiguous bool   // whether values must be C-contiguous in memory
+	fortran    bool   // whether to write the data in Fortran-order
+	split      bool   // whether to write complex data as (real, imag) records
+	big        bool   // whether to write big-endian data types
+	bytes      bool   // whether to write strings as '|S' byte strings
+	width      int    // item size of byte strings, or 0 to infer it
+	unit       string // unit of datetime64 data
+}
+
+func newWriteConfig(opts []WriteOption) writeConfig {
+	cfg := writeConfig{cast: CastStrict}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithContiguous configures WriteWith to fail with ErrNotContiguous when
+// the elements of the value to write are not laid out contiguously in
+// C-order in memory, e.g. for a mat.Dense view into a larger matrix or
+// for a slice of slices whose rows do not share the same backing array.
+// Callers can then copy the value into a contiguous one.
+//
+// Contiguous values are always written in a single pass over their memory.
+func WithContiguous() WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.contiguous = true
+	}
+}
+
+// WithFortranOrder configures whether WriteWith writes the data-array out
+// in Fortran-order (column-major) instead of C-order (row-major).
+// As with NumPy, arrays with less than 2 dimensions are always written out
+// with a C-order header, both layouts being identical.
+func WithFortranOrder(v bool) WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.fortran = v
+	}
+}
+
+// WithSplitComplex configures whether WriteWith writes complex data as a
+// structured array with a 'real' and an 'imag' floating-point field, e.g.
+// "[('real', '<f8'), ('imag', '<f8')]" instead of '<c16', for libraries
+// that do not support complex data types.
+// Both layouts share the same memory representation.
+func WithSplitComplex(v bool) WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.split = v
+	}
+}
+
+// WithByteOrder configures the byte order in which WriteWith writes
+// multi-byte data, e.g. '>i4' and '>f8' arrays for binary.BigEndian, for
+// tools that expect the data in the byte order of big-endian machines.
+// The default is binary.LittleEndian, as NumPy does on most machines.
+//
+// Single-byte data types (e.g. '|u1' and '|b1'), structured arrays and
+// bfloat16.Num values are not affected, while the types registered with
+// RegisterType can only be written in the byte order of their data type.
+func WithByteOrder(order binary.ByteOrder) WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.big = order == binary.BigEndian
+	}
+}
+
+// WithTimeUnit configures the unit of the datetime64 and timedelta64 data
+// WriteWith writes for time.Time and time.Duration values, e.g. "s" for
+// '<M8[s]' and '<m8[s]' or "D" for '<M8[D]' and '<m8[D]' arrays, as used
+// by pandas and NumPy for time series.
+// The supported units are "Y", "M", "W", "D", "h", "m", "s", "ms", "us"
+// and "ns", the default. Durations can not be written in years ("Y") or
+// months ("M"), whose length is not fixed.
+//
+// Values are rounded down to a whole number of units, as NumPy does when
+// converting to a coarser unit: e.g. times are truncated to their date,
+// in UTC, with "D".
+func WithTimeUnit(unit string) WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.unit = unit
+	}
+}
+
+// WriteWith writes 'val' into 'w' in the NumPy data format, as Write does,
+// configured with the provided options.
+func WriteWith(w io.Writer, val interface{}, opts ...WriteOption) error {
+	return wrapErr("write", writeWith(w, val, opts...))
+}
+
+func writeWith(w io.Writer, val interface{}, opts ...WriteOption) error {
+	cfg := newWriteConfig(opts)
+
 	hdr := newHeader()
-	rv := reflect.Indirect(reflect.ValueOf(val))
-	dt, err := dtypeFrom(rv, rv.Type())
+	rv := reflect.Indirect(reflect.ValueOf(denseFrom(val)))
+	if ext, ok := lookupElem(rv.Type()); ok {
+		return writeExt(w, rv, ext, cfg)
+	}
+	if elemType(rv.Type()) == bfloat16Type {
+		return writeBFloat16(w, rv)
+	}
+	if parts, ok := rv.Interface().(ComplexParts); ok {
+		vs, err := interleave(parts)
+		if err != nil {
+			return err
+		}
+		rv = reflect.ValueOf(vs)
+	}
+	if isRecords(rv.Type()) {
+		return writeRecords(w, rv)
+	}
+	var (
+		dt  string
+		err error
+	)
+	switch {
+	case cfg.bytes && isByteStrings(rv.Type()):
+		rv, dt, err = byteStrings(rv, cfg.width)
+	default:
+		dt, err = dtypeFrom(rv, rv.Type())
+	}
 	if err != nil {
 		return err
 	}
@@ -41,105 +180,534 @@ func Write(w io.Writer, val interface{}) error {
 	if err != nil {
 		return err
 	}
+	if cfg.contiguous && !isContiguous(rv) {
+		return fmt.Errorf("npy: value of type %v is not C-contiguous: %w", rv.Type(), ErrNotContiguous)
+	}
+	if cfg.unit != "" {
+		switch elemType(rv.Type()) {
+		case timeType:
+			if !isTimeUnit(cfg.unit) {
+				return fmt.Errorf("npy: datetime64 unit %q not supported: %w", cfg.unit, ErrInvalidType)
+			}
+			dt = "<M8[" + cfg.unit + "]"
+		case durationType:
+			if _, ok := timeUnits[cfg.unit]; !ok {
+				return fmt.Errorf("npy: timedelta64 unit %q can not be represented as a time.Duration: %w", cfg.unit, ErrInvalidType)
+			}
+			dt = "<m8[" + cfg.unit + "]"
+		}
+	}
+	if cfg.big && dt[0] == '<' {
+		dt = ">" + dt[1:]
+	}
 	hdr.Descr.Type = dt
 	hdr.Descr.Shape = shape
+	hdr.Descr.Fortran = cfg.fortran && len(shape) > 1
 
 	rdt, err := newDtype(hdr.Descr.Type)
 	if err != nil {
 		return err
 	}
+	if cfg.split && (rdt.rt == complex64Type || rdt.rt == complex128Type) {
+		hdr.Descr.Type = splitComplexDescr(rdt)
+	}
 
 	err = writeHeader(w, hdr, rdt)
 	if err != nil {
 		return err
 	}
 
-	return writeData(w, rv, rdt)
-}
+	if !hdr.Descr.Fortran {
+		return writeData(w, rv, rdt)
+	}
 
-func writeHeader(w io.Writer, hdr Header, dt dType) error {
-	err := binary.Write(w, dt.order, Magic[:])
+	buf := new(bytes.Buffer)
+	err = writeData(buf, rv, rdt)
 	if err != nil {
 		return err
 	}
-	err = binary.Write(w, dt.order, hdr.Major)
+	_, err = w.Write(fortranOrder(buf.Bytes(), shape, rdt.size))
+	return err
+}
+
+// denseFrom returns val, or the mat.Dense equivalent to val if val is a
+// mat.Matrix other than a mat.Dense.
+// The elements of mat.RawMatrixer values are shared, not copied.
+func denseFrom(val interface{}) interface{} {
+	m, ok := val.(mat.Matrix)
+	if !ok {
+		return val
+	}
+	switch m := m.(type) {
+	case *mat.Dense:
+		return m
+	case mat.RawMatrixer:
+		var d mat.Dense
+		d.SetRawMatrix(m.RawMatrix())
+		return &d
+	}
+	r, c := m.Dims()
+	if r == 0 || c == 0 {
+		return new(mat.Dense)
+	}
+	data := make([]float64, r*c)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			data[i*c+j] = m.At(i, j)
+		}
+	}
+	return mat.NewDense(r, c, data)
+}
+
+// fortranOrder returns the elements of size bytes of the C-ordered array
+// src with the provided shape, laid out in Fortran-order.
+func fortranOrder(src []byte, shape []int, size int) []byte {
+	var (
+		dst     = make([]byte, len(src))
+		idx     = make([]int, len(shape))
+		strides = make([]int, len(shape))
+		stride  = size
+	)
+	for i := range shape {
+		strides[i] = stride
+		stride *= shape[i]
+	}
+
+	off := 0
+	for beg := 0; beg < len(src); beg += size {
+		copy(dst[off:off+size], src[beg:])
+		// increment the C-order index, last axis first.
+		for i := len(idx) - 1; i >= 0; i-- {
+			idx[i]++
+			off += strides[i]
+			if idx[i] < shape[i] {
+				break
+			}
+			off -= idx[i] * strides[i]
+			idx[i] = 0
+		}
+	}
+	return dst
+}
+
+// MustWrite is like Write but panics if the data can not be written.
+// It is intended for tests and scripts where errors are fatal.
+func MustWrite(w io.Writer, val interface{}) {
+	err := Write(w, val)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// WriteWithDescr writes 'val' into 'w' in the NumPy data format, using the
+// provided NumPy data type descriptor and shape verbatim.
+//
+// val must be a scalar, a slice or an array of a supported type, whose
+// elements are laid out in C-order.
+// WriteWithDescr checks that the size of the Go elements matches the item
+// size of descr and that the number of elements matches the shape.
+func WriteWithDescr(w io.Writer, val interface{}, descr string, shape []int) error {
+	rv := reflect.Indirect(reflect.ValueOf(val))
+	var (
+		rt = rv.Type()
+		n  = 1
+	)
+	switch rt.Kind() {
+	case reflect.Slice, reflect.Array:
+		rt = rt.Elem()
+		n = rv.Len()
+	}
+	switch rt.Kind() {
+	case reflect.Int, reflect.Uint, reflect.String,
+		reflect.Slice, reflect.Array,
+		reflect.Map, reflect.Chan, reflect.Interface, reflect.Struct:
+		return fmt.Errorf("npy: type %v not supported: %w", rv.Type(), ErrInvalidType)
+	}
+
+	size, err := itemsizeFrom(descr)
 	if err != nil {
 		return err
 	}
-	err = binary.Write(w, dt.order, hdr.Minor)
+	if int(rt.Size()) != size {
+		return fmt.Errorf(
+			"npy: item size of dtype %q (%d) does not match size of %v (%d): %w",
+			descr, size, rt, rt.Size(), ErrTypeMismatch,
+		)
+	}
+	if numElems(shape) != n {
+		return fmt.Errorf(
+			"npy: shape %v does not match number of elements (%d): %w",
+			shape, n, errDims,
+		)
+	}
+
+	hdr := newHeader()
+	hdr.Descr.Type = descr
+	hdr.Descr.Shape = shape
+
+	dt := dType{str: descr, size: size, order: orderFrom(descr), rt: rt}
+	err = writeHeader(w, hdr, dt)
 	if err != nil {
 		return err
 	}
 
-	buf := new(bytes.Buffer)
-	fmt.Fprintf(buf, "{'descr': '%s', 'fortran_order': False, 'shape': %s, }",
-		hdr.Descr.Type,
-		shapeString(hdr.Descr.Shape),
+	return writeData(w, rv, dt)
+}
+
+// Writer writes the data of a NumPy array described by a user-provided
+// header.
+type Writer struct {
+	Header Header
+
+	w  io.Writer
+	dt dType
+	n  int64 // number of elements left to write
+}
+
+// NewWriter writes the header h to w and returns a Writer for the array
+// data.
+//
+// h.Major selects the version of the file format: 1.0 encodes the header
+// length on 2 bytes, 2.0 on 4 bytes, and 3.0 also allows UTF-8 headers.
+// NewWriter returns an error if the header can not be encoded with the
+// chosen version.
+// As NumPy requires, the header is padded so that the array data starts at
+// a multiple of 64 bytes.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	ww, err := newWriter(w, h)
+	return ww, wrapErr("header", err)
+}
+
+func newWriter(w io.Writer, h Header) (*Writer, error) {
+	if h.Major < 1 || h.Major > 3 || h.Minor != 0 {
+		return nil, fmt.Errorf("npy: unsupported version number (%d.%d): %w", h.Major, h.Minor, ErrInvalidNumPyFormat)
+	}
+	dt, err := newDtype(h.Descr.Type)
+	if err != nil {
+		return nil, fmt.Errorf("npy: invalid dtype %q: %w", h.Descr.Type, ErrInvalidType)
+	}
+	for _, dim := range h.Descr.Shape {
+		if dim < 0 {
+			return nil, fmt.Errorf("npy: invalid shape %v: %w", h.Descr.Shape, errDims)
+		}
+	}
+	n, ok := numElems64(h.Descr.Shape)
+	if !ok {
+		return nil, fmt.Errorf("npy: invalid shape %v: %w", h.Descr.Shape, errDims)
+	}
+
+	err = writeVersionedHeader(w, h, headerDict(h))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{Header: h, w: w, dt: dt, n: n}, nil
+}
+
+// WriteData writes v, a scalar, a slice or an array of the Go type of the
+// header data type, or a mat.Dense for '<f8' data, to the array data.
+// Elements are written as they are laid out in v: they must already be in
+// the memory order of the header.
+//
+// WriteData may be called several times to stream the array data, but
+// returns an error if more elements than announced by the header shape are
+// written.
+func (w *Writer) WriteData(v interface{}) error {
+	return wrapErr("write", w.writeData(v))
+}
+
+func (w *Writer) writeData(v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	var (
+		rt = rv.Type()
+		n  = int64(1)
 	)
-	var hdrSize int
-	switch hdr.Major {
-	case 1:
-		hdrSize = 4 + len(Magic)
-	case 2:
-		hdrSize = 6 + len(Magic)
-	default:
-		return fmt.Errorf("npy: imvalid major version number (%d)", hdr.Major)
+	switch {
+	case rt == rtDense:
+		m := rv.Interface().(mat.Dense)
+		r, c := m.Dims()
+		rt = float64Type
+		n = int64(r * c)
+	case rt.Kind() == reflect.Slice, rt.Kind() == reflect.Array:
+		rt = rt.Elem()
+		n = int64(rv.Len())
+	}
+	if rt != w.dt.rt {
+		return fmt.Errorf(
+			"npy: can not write %v values as dtype %q: %w",
+			rt, w.Header.Descr.Type, ErrTypeMismatch,
+		)
+	}
+	if n > w.n {
+		return fmt.Errorf(
+			"npy: too many elements (%d) for shape %v, %d left: %w",
+			n, w.Header.Descr.Shape, w.n, errDims,
+		)
 	}
 
-	padding := (hdrSize + buf.Len() + 1) % 16
-	_, err = buf.Write(bytes.Repeat([]byte{'\x20'}, padding))
+	err := writeData(w.w, rv, w.dt)
 	if err != nil {
 		return err
 	}
-	_, err = buf.Write([]byte{'\n'})
+	w.n -= n
+	return nil
+}
+
+// WriteStream writes the header h to w, as NewWriter does, followed by the
+// raw array data read from src.
+// The data must already be encoded as described by h: in the byte order of
+// its data type and in its memory order.
+//
+// WriteStream copies the data without holding it in memory, and fails if
+// src does not hold exactly the number of bytes announced by h, i.e. the
+// number of elements of the array times the item size of its data type:
+// with io.ErrUnexpectedEOF if src is shorter, and with an error wrapping
+// ErrInvalidNumPyFormat if src is longer.
+// In both cases, w has already received the data read from src.
+func WriteStream(w io.Writer, h Header, src io.Reader) error {
+	return wrapErr("write", writeStream(w, h, src))
+}
+
+func writeStream(w io.Writer, h Header, src io.Reader) error {
+	_, err := newWriter(w, h)
 	if err != nil {
 		return err
 	}
+	size, err := dataSize(h)
+	if err != nil {
+		return err
+	}
+
+	n, err := io.CopyN(w, src, size)
+	switch {
+	case err == io.EOF:
+		return fmt.Errorf("npy: stream holds %d bytes of array data, want %d: %w", n, size, io.ErrUnexpectedEOF)
+	case err != nil:
+		return err
+	}
+
+	var extra [1]byte
+	nn, err := io.ReadFull(src, extra[:])
+	switch {
+	case nn > 0:
+		return fmt.Errorf("npy: stream holds more than %d bytes of array data: %w", size, ErrInvalidNumPyFormat)
+	case err != io.EOF:
+		return err
+	}
+	return nil
+}
+
+// headerAlign is the alignment, in bytes, of the array data in NumPy data
+// files: the header is padded so that the data starts at a multiple of it.
+const headerAlign = 64
+
+// growthDigits is the number of digits NumPy reserves in the header for
+// the length of the array along its growth axis, i.e. the axis whose
+// length changes when data is appended to the array: the first axis of
+// C-ordered arrays, and the last one of Fortran-ordered arrays.
+const growthDigits = 21
+
+// writeHeader writes hdr to w with the smallest version of the file format
+// able to encode it, as NumPy does: 1.0 if the header is ASCII and its
+// length fits in 2 bytes, 2.0 if it is ASCII, and 3.0 otherwise.
+// The version of hdr is ignored.
+func writeHeader(w io.Writer, hdr Header, dt dType) error {
+	dict := headerDict(hdr)
+	switch {
+	case !isASCII(dict):
+		hdr.Major = 3
+	case paddedLen(dict, 2) <= math.MaxUint16:
+		hdr.Major = 1
+	default:
+		hdr.Major = 2
+	}
+	hdr.Minor = 0
+	return writeVersionedHeader(w, hdr, dict)
+}
+
+// headerDict returns the dictionary literal describing hdr in NumPy data
+// files.
+// As with np.save, the literal is followed by spaces leaving room for
+// growthDigits digits for the length of the growth axis, so that the header
+// can be updated in place when data is appended to the array.
+func headerDict(hdr Header) string {
+	dict := fmt.Sprintf("{'descr': %s, 'fortran_order': %s, 'shape': %s, }",
+		descrString(hdr.Descr.Type),
+		repr(hdr.Descr.Fortran),
+		shapeString(hdr.Descr.Shape),
+	)
+	shape := hdr.Descr.Shape
+	if len(shape) == 0 {
+		return dict
+	}
+	axis := 0
+	if hdr.Descr.Fortran {
+		axis = len(shape) - 1
+	}
+	n := growthDigits - len(strconv.Itoa(shape[axis]))
+	return dict + strings.Repeat(" ", max(n, 0))
+}
+
+// paddedLen returns the length of the header holding dict, once padded with
+// spaces and a final newline so that the array data is aligned, when its
+// length is encoded on lenSize bytes.
+// As NumPy does, at least one space and at most headerAlign spaces are
+// added.
+func paddedLen(dict string, lenSize int) int64 {
+	n := int64(len(Magic) + 2 + lenSize + len(dict) + 1)
+	return int64(len(dict)+1) + headerAlign - n%headerAlign
+}
 
-	buflen := int64(buf.Len())
+// writeVersionedHeader writes the header holding dict to w, with the
+// version of the file format of hdr.
+func writeVersionedHeader(w io.Writer, hdr Header, dict string) error {
+	var lenSize int
 	switch hdr.Major {
 	case 1:
-		err = binary.Write(w, dt.order, uint16(buflen))
-	case 2:
-		err = binary.Write(w, dt.order, uint32(buflen))
+		lenSize = 2
+	case 2, 3:
+		lenSize = 4
 	default:
 		return fmt.Errorf("npy: invalid major version number (%d)", hdr.Major)
 	}
 
-	if err != nil {
-		return err
+	if hdr.Major < 3 && !isASCII(dict) {
+		return fmt.Errorf("npy: header of version %d.%d must be ASCII: %w", hdr.Major, hdr.Minor, ErrInvalidNumPyFormat)
 	}
 
-	n, err := io.Copy(w, buf)
-	if err != nil {
-		return err
+	hlen := paddedLen(dict, lenSize)
+	if (lenSize == 2 && hlen > math.MaxUint16) || hlen > math.MaxUint32 {
+		return fmt.Errorf(
+			"npy: header too long (%d bytes) for version %d.%d: %w",
+			hlen, hdr.Major, hdr.Minor, ErrInvalidNumPyFormat,
+		)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, len(Magic)+2+lenSize+int(hlen)))
+	buf.Write(Magic[:])
+	buf.WriteByte(hdr.Major)
+	buf.WriteByte(hdr.Minor)
+	switch lenSize {
+	case 2:
+		binary.Write(buf, binary.LittleEndian, uint16(hlen))
+	default:
+		binary.Write(buf, binary.LittleEndian, uint32(hlen))
+	}
+	buf.WriteString(dict)
+	buf.Write(bytes.Repeat([]byte{'\x20'}, int(hlen)-len(dict)-1))
+	buf.WriteByte('\n')
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// unicodeDescr returns the Unicode data type descriptor able to hold the
+// string, or the (nested) slice or array of strings, rv.
+// As with NumPy, the descriptor holds at least one character.
+func unicodeDescr(rv reflect.Value) string {
+	return fmt.Sprintf("<U%d", max(runeCount(rv), 1))
+}
+
+// runeCount returns the number of code points of the longest string of
+// the string, or the (nested) slice or array of strings, rv.
+func runeCount(rv reflect.Value) int {
+	if rv.Kind() == reflect.String {
+		return utf8.RuneCountInString(rv.String())
 	}
-	if n < buflen {
-		return io.ErrShortWrite
+	n := 0
+	for i := 0; i < rv.Len(); i++ {
+		n = max(n, runeCount(rv.Index(i)))
 	}
+	return n
+}
 
-	return nil
+// isStringElem returns whether the innermost element type of rt, looking
+// through slices and arrays, is a string type.
+func isStringElem(rt reflect.Type) bool {
+	for rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array {
+		rt = rt.Elem()
+	}
+	return rt.Kind() == reflect.String
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// descrString returns the representation of the data type descriptor in
+// the header dictionary: structured data types are lists of fields, other
+// data types are strings.
+func descrString(descr string) string {
+	if isRecDescr(descr) {
+		return descr
+	}
+	return "'" + descr + "'"
 }
 
 func writeData(w io.Writer, rv reflect.Value, dt dType) error {
 	rt := rv.Type()
+	if elemType(rt) == timeType {
+		return writeTimes(w, rv, dt)
+	}
+	if elemType(rt) == durationType {
+		return writeDurations(w, rv, dt)
+	}
 	if rt == rtDense {
 		m := rv.Interface().(mat.Dense)
-		nrows, ncols := m.Dims()
-		var buf [8]byte
-		for i := 0; i < nrows; i++ {
-			for j := 0; j < ncols; j++ {
-				dt.order.PutUint64(buf[:], math.Float64bits(m.At(i, j)))
-				_, err := w.Write(buf[:])
-				if err != nil {
-					return err
-				}
+		raw := m.RawMatrix()
+		if isContiguous(rv) {
+			data := raw.Data[:raw.Rows*raw.Cols]
+			if ok, err := writeNative(w, data, dt); ok {
+				return err
+			}
+			buf := make([]byte, 8*len(data))
+			for i, v := range data {
+				dt.order.PutUint64(buf[8*i:], math.Float64bits(v))
+			}
+			_, err := w.Write(buf)
+			return err
+		}
+
+		// the matrix is a view into a larger one:
+		// honour the stride of the underlying storage, row by row.
+		buf := make([]byte, 8*raw.Cols)
+		for i := 0; i < raw.Rows; i++ {
+			row := raw.Data[i*raw.Stride : i*raw.Stride+raw.Cols]
+			for j, v := range row {
+				dt.order.PutUint64(buf[8*j:], math.Float64bits(v))
+			}
+			_, err := w.Write(buf)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Slice {
+		if isContiguous(rv) && rv.Len() > 0 {
+			// all rows share the same backing array: write it at once.
+			n := rv.Len() * rv.Index(0).Len()
+			return writeData(w, rv.Index(0).Slice(0, n), dt)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			err := writeData(w, rv.Index(i), dt)
+			if err != nil {
+				return err
 			}
 		}
 		return nil
 	}
 
 	v := rv.Interface()
+	if ok, err := writeNative(w, v, dt); ok {
+		return err
+	}
 	switch v := v.(type) {
 	case bool:
 		switch v {
@@ -371,24 +939,24 @@ func writeData(w io.Writer, rv reflect.Value, dt dType) error {
 		return nil
 
 	case string:
-		o := []byte(v)
-		o = append(o, 0)
-		_, err := w.Write(o)
-		if err != nil {
-			return err
-		}
-		return nil
+		return writeData(w, reflect.ValueOf([]string{v}), dt)
 
 	case []string:
 		n := dt.size
 		switch {
 		case dt.utf:
+			o := make([]byte, n)
 			for _, str := range v {
-				o := make([]byte, n*utf8.UTFMax)
+				for i := range o {
+					o[i] = 0
+				}
 				i := 0
 				for _, v := range str {
-					dt.order.PutUint32(o[i:i+utf8.UTFMax], uint32(v))
-					i += utf8.UTFMax
+					if i+4 > n {
+						break
+					}
+					dt.order.PutUint32(o[i:], uint32(v))
+					i += 4
 				}
 				_, err := w.Write(o)
 				if err != nil {
@@ -399,7 +967,7 @@ func writeData(w io.Writer, rv reflect.Value, dt dType) error {
 		case !dt.utf:
 			o := make([]byte, len(v)*n)
 			for i, v := range v {
-				copy(o[i:i+n], []byte(v))
+				copy(o[i*n:(i+1)*n], []byte(v))
 			}
 			_, err := w.Write(o)
 			if err != nil {
@@ -411,8 +979,13 @@ func writeData(w io.Writer, rv reflect.Value, dt dType) error {
 
 	switch rt.Kind() {
 	case reflect.Array:
-		switch rt.Elem().Kind() {
-		case reflect.Bool, reflect.Int, reflect.Uint:
+		elem := rt.Elem().Kind()
+		if isStringElem(rt) {
+			// arrays of arrays of strings are written string by string.
+			elem = reflect.String
+		}
+		switch elem {
+		case reflect.Bool, reflect.Int, reflect.Uint, reflect.String, reflect.Slice:
 			n := rv.Len()
 			for i := 0; i < n; i++ {
 				elem := rv.Index(i)
@@ -434,8 +1007,19 @@ func writeData(w io.Writer, rv reflect.Value, dt dType) error {
 }
 
 func dtypeFrom(rv reflect.Value, rt reflect.Type) (string, error) {
-	if rt == rtDense {
+	switch rt {
+	case rtDense:
 		return "<f8", nil
+	case float16Type:
+		return "<f2", nil
+	case timeType:
+		return "<M8[ns]", nil
+	case durationType:
+		return "<m8[ns]", nil
+	}
+
+	if isStringElem(rt) {
+		return unicodeDescr(rv), nil
 	}
 
 	switch rt.Kind() {
@@ -466,40 +1050,8 @@ func dtypeFrom(rv reflect.Value, rt reflect.Type) (string, error) {
 	case reflect.Complex128:
 		return "<c16", nil
 
-	case reflect.Array:
-		et := rt.Elem()
-		switch et.Kind() {
-		default:
-			return dtypeFrom(reflect.Value{}, et)
-		case reflect.String:
-			slice := rv.Slice(0, rt.Len()).Interface().([]string)
-			n := 0
-			for _, str := range slice {
-				if len(str) > n {
-					n = len(str)
-				}
-			}
-			return fmt.Sprintf("<U%d", n), nil
-		}
-
-	case reflect.Slice:
-		rt = rt.Elem()
-		switch rt.Kind() {
-		default:
-			return dtypeFrom(reflect.Value{}, rt)
-		case reflect.String:
-			slice := rv.Interface().([]string)
-			n := 0
-			for _, str := range slice {
-				if len(str) > n {
-					n = len(str)
-				}
-			}
-			return fmt.Sprintf("<U%d", n), nil
-		}
-
-	case reflect.String:
-		return fmt.Sprintf("<U%d", len(rv.Interface().(string))), nil
+	case reflect.Array, reflect.Slice:
+		return dtypeFrom(reflect.Value{}, rt.Elem())
 
 	case reflect.Map, reflect.Chan, reflect.Interface, reflect.Struct:
 		return "", fmt.Errorf("npy: type %v not supported", rt)
@@ -508,6 +1060,19 @@ func dtypeFrom(rv reflect.Value, rt reflect.Type) (string, error) {
 	return "", fmt.Errorf("npy: type %v not supported", rt)
 }
 
+// equalShapes returns whether the shapes a and b are identical.
+func equalShapes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func shapeFrom(rv reflect.Value) ([]int, error) {
 	if m, ok := rv.Interface().(mat.Dense); ok {
 		nrows, ncols := m.Dims()
@@ -515,6 +1080,9 @@ func shapeFrom(rv reflect.Value) ([]int, error) {
 	}
 
 	rt := rv.Type()
+	if rt == timeType {
+		return nil, nil
+	}
 	switch rt.Kind() {
 	case reflect.Array, reflect.Slice:
 		if rv.Len() == 0 {
@@ -524,6 +1092,21 @@ func shapeFrom(rv reflect.Value) ([]int, error) {
 		if err != nil {
 			return nil, err
 		}
+		if rt.Elem().Kind() == reflect.Slice {
+			// slices of slices must not be ragged, at any depth.
+			for i := 1; i < rv.Len(); i++ {
+				shape, err := shapeFrom(rv.Index(i))
+				if err != nil {
+					return nil, err
+				}
+				if !equalShapes(shape, eshape) {
+					return nil, fmt.Errorf(
+						"npy: ragged slice of type %v: element #%d has shape %v, want %v: %w",
+						rt, i, shape, eshape, errDims,
+					)
+				}
+			}
+		}
 		return append([]int{rv.Len()}, eshape...), nil
 
 	case reflect.String:
@@ -537,6 +1120,44 @@ func shapeFrom(rv reflect.Value) ([]int, error) {
 	return nil, nil
 }
 
+// isContiguous returns whether the elements of rv are laid out
+// contiguously in C-order in memory.
+func isContiguous(rv reflect.Value) bool {
+	rt := rv.Type()
+	if rt == rtDense {
+		m := rv.Interface().(mat.Dense)
+		raw := m.RawMatrix()
+		return raw.Rows <= 1 || raw.Stride == raw.Cols
+	}
+
+	if rt.Kind() != reflect.Slice || rt.Elem().Kind() != reflect.Slice {
+		return true
+	}
+	if rt.Elem().Elem().Kind() == reflect.Slice {
+		// only slices of slices of scalars or arrays are inspected.
+		return false
+	}
+	if rv.Len() == 0 {
+		return true
+	}
+
+	var (
+		row0 = rv.Index(0)
+		n    = row0.Len()
+		sz   = uintptr(n) * rt.Elem().Elem().Size()
+	)
+	if row0.Cap() < rv.Len()*n {
+		return false
+	}
+	for i := 1; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		if row.Len() != n || row.Pointer() != row0.Pointer()+uintptr(i)*sz {
+			return false
+		}
+	}
+	return true
+}
+
 func shapeString(shape []int) string {
 	switch len(shape) {
 	case 0:
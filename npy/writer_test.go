@@ -6,10 +6,16 @@ package npy
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
 	"math"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -224,3 +230,910 @@ func TestShapeFrom(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteWithDescr(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		descr string
+		shape []int
+		want  interface{}
+		err   error
+	}{
+		{
+			name:  "big-endian",
+			val:   []float64{0, 1, 2, 3, 4, 5},
+			descr: ">f8",
+			shape: []int{2, 3},
+			want:  []float64{0, 1, 2, 3, 4, 5},
+		},
+		{
+			name:  "array",
+			val:   [3]int16{-1, 0, 1},
+			descr: "<i2",
+			shape: []int{3},
+			want:  []int16{-1, 0, 1},
+		},
+		{
+			name:  "scalar",
+			val:   uint32(42),
+			descr: "<u4",
+			shape: nil,
+			want:  uint32(42),
+		},
+		{
+			name:  "exotic",
+			val:   []int64{1, 2},
+			descr: "<M8[ns]",
+			shape: []int{2},
+		},
+		{
+			name:  "itemsize-mismatch",
+			val:   []float32{0, 1},
+			descr: "<f8",
+			shape: []int{2},
+			err:   ErrTypeMismatch,
+		},
+		{
+			name:  "shape-mismatch",
+			val:   []float64{0, 1},
+			descr: "<f8",
+			shape: []int{3},
+			err:   errDims,
+		},
+		{
+			name:  "invalid-type",
+			val:   []int{0, 1},
+			descr: "<i8",
+			shape: []int{2},
+			err:   ErrInvalidType,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWithDescr(buf, tc.val, tc.descr, tc.shape)
+			switch {
+			case tc.err != nil:
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not read header: %+v", err)
+			}
+			if got, want := r.Header.Descr.Type, tc.descr; got != want {
+				t.Fatalf("invalid descr: got=%q, want=%q", got, want)
+			}
+			if got, want := r.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+			if tc.want == nil {
+				return
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got, want := got.Elem().Interface(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestMustReadWrite(t *testing.T) {
+	buf := new(bytes.Buffer)
+	MustWrite(buf, []int32{1, 2, 3})
+
+	var got []int32
+	MustRead(buf, &got)
+	if want := []int32{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		fct  func()
+	}{
+		{
+			name: "read",
+			fct:  func() { MustRead(bytes.NewReader([]byte("not a npy file")), &got) },
+		},
+		{
+			name: "write",
+			fct:  func() { MustWrite(io.Discard, map[string]int{}) },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if e := recover(); e == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			tc.fct()
+		})
+	}
+}
+
+func TestWriterDenseView(t *testing.T) {
+	m := mat.NewDense(4, 5, []float64{
+		0, 1, 2, 3, 4,
+		5, 6, 7, 8, 9,
+		10, 11, 12, 13, 14,
+		15, 16, 17, 18, 19,
+	})
+
+	for _, tc := range []struct {
+		name string
+		view mat.Matrix
+		want *mat.Dense
+	}{
+		{
+			name: "full",
+			view: m.Slice(0, 4, 0, 5),
+			want: m,
+		},
+		{
+			name: "inner",
+			view: m.Slice(1, 3, 1, 4),
+			want: mat.NewDense(2, 3, []float64{6, 7, 8, 11, 12, 13}),
+		},
+		{
+			name: "col",
+			view: m.Slice(0, 4, 2, 3),
+			want: mat.NewDense(4, 1, []float64{2, 7, 12, 17}),
+		},
+		{
+			name: "row",
+			view: m.Slice(3, 4, 0, 5),
+			want: mat.NewDense(1, 5, []float64{15, 16, 17, 18, 19}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.view)
+			if err != nil {
+				t.Fatalf("could not write view: %+v", err)
+			}
+
+			var got mat.Dense
+			err = Read(buf, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+
+			if !mat.Equal(&got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", mat.Formatted(&got), mat.Formatted(tc.want))
+			}
+		})
+	}
+}
+
+// rawMatrix is a mat.RawMatrixer that is not a *mat.Dense.
+type rawMatrix struct {
+	*mat.Dense
+}
+
+func TestWriterMatrix(t *testing.T) {
+	m := mat.NewDense(3, 4, []float64{
+		0, 1, 2, 3,
+		4, 5, 6, 7,
+		8, 9, 10, 11,
+	})
+
+	for _, tc := range []struct {
+		name string
+		val  mat.Matrix
+		want *mat.Dense
+	}{
+		{
+			name: "sym",
+			val:  mat.NewSymDense(2, []float64{1, 2, 2, 3}),
+			want: mat.NewDense(2, 2, []float64{1, 2, 2, 3}),
+		},
+		{
+			name: "tri",
+			val:  mat.NewTriDense(3, mat.Upper, []float64{1, 2, 3, 0, 4, 5, 0, 0, 6}),
+			want: mat.NewDense(3, 3, []float64{1, 2, 3, 0, 4, 5, 0, 0, 6}),
+		},
+		{
+			name: "transpose",
+			val:  m.T(),
+			want: mat.NewDense(4, 3, []float64{0, 4, 8, 1, 5, 9, 2, 6, 10, 3, 7, 11}),
+		},
+		{
+			name: "vec",
+			val:  mat.NewVecDense(3, []float64{1, 2, 3}),
+			want: mat.NewDense(3, 1, []float64{1, 2, 3}),
+		},
+		{
+			name: "raw-view",
+			val:  rawMatrix{m.Slice(1, 3, 1, 3).(*mat.Dense)},
+			want: mat.NewDense(2, 2, []float64{5, 6, 9, 10}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write matrix: %+v", err)
+			}
+
+			var got mat.Dense
+			err = Read(buf, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !mat.Equal(&got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", mat.Formatted(&got), mat.Formatted(tc.want))
+			}
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	err := WriteAs(buf, mat.NewSymDense(2, []float64{1, 2, 2, 3}), "<f4")
+	if err != nil {
+		t.Fatalf("could not write matrix as float32: %+v", err)
+	}
+	var got []float32
+	err = Read(buf, &got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if want := []float32{1, 2, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data: got=%v, want=%v", got, want)
+	}
+}
+
+func TestWriteContiguous(t *testing.T) {
+	m := mat.NewDense(3, 3, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8})
+	backing := []float64{0, 1, 2, 3, 4, 5}
+
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		want  []float64
+		shape []int
+		err   error // error with WithContiguous
+	}{
+		{
+			name:  "dense",
+			val:   m,
+			want:  []float64{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			shape: []int{3, 3},
+		},
+		{
+			name:  "dense-rows",
+			val:   m.Slice(1, 3, 0, 3),
+			want:  []float64{3, 4, 5, 6, 7, 8},
+			shape: []int{2, 3},
+		},
+		{
+			name:  "dense-view",
+			val:   m.Slice(0, 2, 1, 3),
+			want:  []float64{1, 2, 4, 5},
+			shape: []int{2, 2},
+			err:   ErrNotContiguous,
+		},
+		{
+			name:  "slices-shared",
+			val:   [][]float64{backing[0:3], backing[3:6]},
+			want:  []float64{0, 1, 2, 3, 4, 5},
+			shape: []int{2, 3},
+		},
+		{
+			name:  "slices-reversed",
+			val:   [][]float64{backing[3:6], backing[0:3]},
+			want:  []float64{3, 4, 5, 0, 1, 2},
+			shape: []int{2, 3},
+			err:   ErrNotContiguous,
+		},
+		{
+			name:  "slices",
+			val:   [][]float64{{0, 1}, {2, 3}},
+			want:  []float64{0, 1, 2, 3},
+			shape: []int{2, 2},
+			err:   ErrNotContiguous,
+		},
+		{
+			name:  "arrays",
+			val:   [][2]float64{{0, 1}, {2, 3}},
+			want:  []float64{0, 1, 2, 3},
+			shape: []int{2, 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, opts := range [][]WriteOption{nil, {WithContiguous()}} {
+				buf := new(bytes.Buffer)
+				err := WriteWith(buf, tc.val, opts...)
+				if opts != nil && tc.err != nil {
+					if !errors.Is(err, tc.err) {
+						t.Fatalf("invalid error: got=%+v, want=%+v", err, tc.err)
+					}
+					if buf.Len() != 0 {
+						t.Fatalf("data written despite error")
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("could not write value: %+v", err)
+				}
+
+				r, err := NewReader(buf)
+				if err != nil {
+					t.Fatalf("could not create reader: %+v", err)
+				}
+				if got, want := r.Header.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+				}
+				var got []float64
+				err = r.Read(&got)
+				if err != nil {
+					t.Fatalf("could not read data: %+v", err)
+				}
+				if !reflect.DeepEqual(got, tc.want) {
+					t.Fatalf("invalid data: got=%v, want=%v", got, tc.want)
+				}
+			}
+		})
+	}
+
+	for _, val := range []interface{}{
+		[][]float64{{0, 1}, {2}},
+		[][]float64{{0, 1}, {2, 3}, {}},
+		[][][]float64{{{0, 1}, {2, 3}}, {{4}, {5}}},
+		[][][]float64{{{0, 1}, {2, 3}}, {{4, 5}}},
+		[2][]float64{{0, 1}, {2}},
+	} {
+		err := Write(io.Discard, val)
+		if !errors.Is(err, errDims) {
+			t.Fatalf("invalid error for ragged slices %v: %+v", val, err)
+		}
+	}
+}
+
+func TestWriteNestedShape(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		shape []int
+		want  []int16
+	}{
+		{"2d", [][]int16{{0, 1, 2}, {3, 4, 5}}, []int{2, 3}, []int16{0, 1, 2, 3, 4, 5}},
+		{
+			"3d",
+			[][][]int16{{{0, 1, 2}, {3, 4, 5}}, {{6, 7, 8}, {9, 10, 11}}},
+			[]int{2, 2, 3},
+			[]int16{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		{"array-of-slices", [2][]int16{{0, 1}, {2, 3}}, []int{2, 2}, []int16{0, 1, 2, 3}},
+		{"empty-rows", [][]int16{{}, {}}, []int{2, 0}, []int16{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write value: %+v", err)
+			}
+
+			var got []int16
+			hdr, err := ReadWithHeader(buf, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got, want := hdr.Descr.Shape, tc.shape; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+			}
+			if hdr.Descr.Fortran {
+				t.Fatalf("invalid memory order: got Fortran, want C")
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteFortranOrder(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		val     interface{}
+		want    []float64 // data in storage order
+		fortran bool
+	}{
+		{
+			name:    "dense",
+			val:     mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5}),
+			want:    []float64{0, 3, 1, 4, 2, 5},
+			fortran: true,
+		},
+		{
+			name:    "dense-view",
+			val:     mat.NewDense(3, 3, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}).Slice(0, 2, 1, 3),
+			want:    []float64{1, 4, 2, 5},
+			fortran: true,
+		},
+		{
+			name: "slices-3d",
+			val: [][][]float64{
+				{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9, 10, 11}},
+				{{12, 13, 14, 15}, {16, 17, 18, 19}, {20, 21, 22, 23}},
+			},
+			want: []float64{
+				0, 12, 4, 16, 8, 20,
+				1, 13, 5, 17, 9, 21,
+				2, 14, 6, 18, 10, 22,
+				3, 15, 7, 19, 11, 23,
+			},
+			fortran: true,
+		},
+		{
+			name: "slice-1d",
+			val:  []float64{0, 1, 2},
+			want: []float64{0, 1, 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, tc.val, WithFortranOrder(true))
+			if err != nil {
+				t.Fatalf("could not write value: %+v", err)
+			}
+			raw := buf.Bytes()
+
+			r, err := NewReader(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Descr.Fortran, tc.fortran; got != want {
+				t.Fatalf("invalid fortran order: got=%v, want=%v", got, want)
+			}
+
+			var got []float64
+			err = r.Read(&got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+
+			m, ok := tc.val.(*mat.Dense)
+			if !ok {
+				return
+			}
+			var dense mat.Dense
+			err = Read(bytes.NewReader(raw), &dense)
+			if err != nil {
+				t.Fatalf("could not read matrix: %+v", err)
+			}
+			if !mat.Equal(&dense, m) {
+				t.Fatalf("invalid matrix:\ngot= %v\nwant=%v", mat.Formatted(&dense), mat.Formatted(m))
+			}
+		})
+	}
+
+	t.Run("fixture", func(t *testing.T) {
+		want, err := os.ReadFile("../testdata/data_float64_2x3_forder.npy")
+		if err != nil {
+			t.Fatalf("could not read fixture: %+v", err)
+		}
+		_, off, err := ReadHeaderAt(bytes.NewReader(want), 0)
+		if err != nil {
+			t.Fatalf("could not read fixture header: %+v", err)
+		}
+
+		// the fixture holds the matrix [[0, 2, 4], [1, 3, 5]].
+		buf := new(bytes.Buffer)
+		err = WriteWith(buf, mat.NewDense(2, 3, []float64{0, 2, 4, 1, 3, 5}), WithFortranOrder(true))
+		if err != nil {
+			t.Fatalf("could not write value: %+v", err)
+		}
+		got := buf.Bytes()
+		_, data, err := ReadHeaderAt(bytes.NewReader(got), 0)
+		if err != nil {
+			t.Fatalf("could not read header: %+v", err)
+		}
+		if !bytes.Equal(got[data:], want[off:]) {
+			t.Fatalf("invalid data:\ngot= %v\nwant=%v", got[data:], want[off:])
+		}
+	})
+}
+
+func TestNewWriter(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		major byte
+		descr string
+		shape []int
+		data  []interface{}
+		want  interface{}
+	}{
+		{
+			name:  "v1",
+			major: 1,
+			descr: "<f8",
+			shape: []int{2, 3},
+			data:  []interface{}{[]float64{0, 1, 2}, []float64{3, 4, 5}},
+			want:  []float64{0, 1, 2, 3, 4, 5},
+		},
+		{
+			name:  "v2",
+			major: 2,
+			descr: ">i4",
+			shape: []int{4},
+			data:  []interface{}{[2]int32{0, -1}, int32(2), []int32{3}},
+			want:  []int32{0, -1, 2, 3},
+		},
+		{
+			name:  "v3",
+			major: 3,
+			descr: "<f8",
+			shape: []int{2, 2},
+			data:  []interface{}{mat.NewDense(2, 2, []float64{0, 1, 2, 3})},
+			want:  []float64{0, 1, 2, 3},
+		},
+		{
+			name:  "scalar",
+			major: 1,
+			descr: "|u1",
+			data:  []interface{}{uint8(42)},
+			want:  uint8(42),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var hdr Header
+			hdr.Major = tc.major
+			hdr.Descr.Type = tc.descr
+			hdr.Descr.Shape = tc.shape
+
+			buf := new(bytes.Buffer)
+			w, err := NewWriter(buf, hdr)
+			if err != nil {
+				t.Fatalf("could not create writer: %+v", err)
+			}
+			if got := buf.Len(); got%64 != 0 {
+				t.Fatalf("header not aligned: %d bytes", got)
+			}
+			for _, v := range tc.data {
+				err = w.WriteData(v)
+				if err != nil {
+					t.Fatalf("could not write data: %+v", err)
+				}
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := r.Header.Major, tc.major; got != want {
+				t.Fatalf("invalid major version: got=%d, want=%d", got, want)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want))
+			err = r.Read(got.Interface())
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if got := got.Elem().Interface(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewWriterInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		major byte
+		minor byte
+		descr string
+		shape []int
+		err   error
+	}{
+		{"version-0", 0, 0, "<f8", nil, ErrInvalidNumPyFormat},
+		{"version-4", 4, 0, "<f8", nil, ErrInvalidNumPyFormat},
+		{"version-1.1", 1, 1, "<f8", nil, ErrInvalidNumPyFormat},
+		{"dtype", 1, 0, "<x3", nil, ErrInvalidType},
+		{"shape", 1, 0, "<f8", []int{-1}, errDims},
+		{"too-long", 1, 0, "<f8", make([]int, 1<<15), ErrInvalidNumPyFormat},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var hdr Header
+			hdr.Major = tc.major
+			hdr.Minor = tc.minor
+			hdr.Descr.Type = tc.descr
+			hdr.Descr.Shape = tc.shape
+
+			_, err := NewWriter(new(bytes.Buffer), hdr)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+
+	var hdr Header
+	hdr.Major = 1
+	hdr.Descr.Type = "<f4"
+	hdr.Descr.Shape = []int{2}
+	w, err := NewWriter(new(bytes.Buffer), hdr)
+	if err != nil {
+		t.Fatalf("could not create writer: %+v", err)
+	}
+	err = w.WriteData([]float64{1, 2})
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, ErrTypeMismatch)
+	}
+	err = w.WriteData([]float32{1, 2, 3})
+	if !errors.Is(err, errDims) {
+		t.Fatalf("invalid error: got=%v, want=%v", err, errDims)
+	}
+}
+
+func TestWriteStream(t *testing.T) {
+	want := []float64{0, 1, 2, 3, 4, 5}
+	payload := new(bytes.Buffer)
+	for _, v := range want {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		payload.Write(buf[:])
+	}
+
+	for _, tc := range []struct {
+		name    string
+		fortran bool
+		want    *mat.Dense
+	}{
+		{"c-order", false, mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5})},
+		{"f-order", true, mat.NewDense(2, 3, []float64{0, 2, 4, 1, 3, 5})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var hdr Header
+			hdr.Major = 1
+			hdr.Descr.Type = "<f8"
+			hdr.Descr.Fortran = tc.fortran
+			hdr.Descr.Shape = []int{2, 3}
+
+			buf := new(bytes.Buffer)
+			err := WriteStream(buf, hdr, bytes.NewReader(payload.Bytes()))
+			if err != nil {
+				t.Fatalf("could not write stream: %+v", err)
+			}
+
+			var got mat.Dense
+			err = Read(buf, &got)
+			if err != nil {
+				t.Fatalf("could not read data: %+v", err)
+			}
+			if !mat.Equal(&got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", mat.Formatted(&got), mat.Formatted(tc.want))
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		shape []int
+		err   error
+	}{
+		{"short", []int{7}, io.ErrUnexpectedEOF},
+		{"long", []int{5}, ErrInvalidNumPyFormat},
+		{"shape", []int{-1}, errDims},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var hdr Header
+			hdr.Major = 1
+			hdr.Descr.Type = "<f8"
+			hdr.Descr.Shape = tc.shape
+
+			err := WriteStream(io.Discard, hdr, bytes.NewReader(payload.Bytes()))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWriteHeaderVersion(t *testing.T) {
+	// header describing a structured array with a single field whose name
+	// is chosen so that the header dictionary is n bytes long.
+	header := func(n int) Header {
+		const base = "{'descr': [('', '<f8')], 'fortran_order': False, 'shape': (), }"
+		hdr := newHeader()
+		hdr.Descr.Type = "[('" + strings.Repeat("x", n-len(base)) + "', '<f8')]"
+		hdr.Descr.Shape = []int{}
+		return hdr
+	}
+
+	for _, tc := range []struct {
+		name  string
+		hdr   Header
+		major byte
+		size  int // size of the header, magic string included
+	}{
+		{"small", header(100), 1, 128},
+		// dictionary, newline and 1.0 prefix fit exactly in 64 bytes:
+		// NumPy still adds a full block of padding spaces.
+		{"aligned", header(117), 1, 192},
+		{"aligned-1", header(116), 1, 128},
+		{"v1-max", header(65524), 1, 65536},
+		{"v2-min", header(65525), 2, 65600},
+		{"utf8", func() Header {
+			hdr := newHeader()
+			hdr.Descr.Type = "[('é', '<f8')]"
+			return hdr
+		}(), 3, 128},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := writeHeader(buf, tc.hdr, dType{})
+			if err != nil {
+				t.Fatalf("could not write header: %+v", err)
+			}
+			if got, want := buf.Len(), tc.size; got != want {
+				t.Fatalf("invalid header size: got=%d, want=%d", got, want)
+			}
+			if got, want := buf.Bytes()[buf.Len()-1], byte('\n'); got != want {
+				t.Fatalf("invalid header terminator: got=%q, want=%q", got, want)
+			}
+
+			r, err := NewReader(buf)
+			if err != nil {
+				t.Fatalf("could not read header: %+v", err)
+			}
+			if got, want := r.Header.Major, tc.major; got != want {
+				t.Fatalf("invalid version: got=%d, want=%d", got, want)
+			}
+			if got, want := r.Header.Descr.Type, tc.hdr.Descr.Type; got != want {
+				t.Fatalf("invalid dtype: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestWriteNative(t *testing.T) {
+	f64s := []float64{0, 1, -2.5, math.Inf(1), 4, 5, 6, 7}
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+	}{
+		{"int8", []int8{-1, 0, 1, 127}},
+		{"int16", []int16{-1, 0, 1, 1 << 14}},
+		{"int32", []int32{-1, 0, 1, 1 << 30}},
+		{"int64", []int64{-1, 0, 1, 1 << 62}},
+		{"uint16", []uint16{0, 1, 1 << 15}},
+		{"uint32", []uint32{0, 1, 1 << 31}},
+		{"uint64", []uint64{0, 1, 1 << 63}},
+		{"float32", []float32{0, 1, -2.5, float32(math.NaN())}},
+		{"float64", f64s},
+		{"float64-subslice", f64s[2:5:6]},
+		{"float64-empty", f64s[:0]},
+		{"complex64", []complex64{1 + 2i, -3 - 4i}},
+		{"complex128", []complex128{1 + 2i, -3 - 4i}},
+		{"dense", *mat.NewDense(2, 2, []float64{1, 2, 3, 4})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := Write(buf, tc.val)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			want := new(bytes.Buffer)
+			switch v := tc.val.(type) {
+			case mat.Dense:
+				err = binary.Write(want, binary.LittleEndian, v.RawMatrix().Data)
+			default:
+				err = binary.Write(want, binary.LittleEndian, v)
+			}
+			if err != nil {
+				t.Fatalf("could not encode reference data: %+v", err)
+			}
+			if got := buf.Bytes()[buf.Len()-want.Len():]; !bytes.Equal(got, want.Bytes()) {
+				t.Fatalf("invalid payload:\ngot= %v\nwant=%v", got, want.Bytes())
+			}
+
+			r, err := NewReader(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			if got, want := int64(buf.Len())-r.data, int64(want.Len()); got != want {
+				t.Fatalf("invalid payload size: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestWriteByteOrder(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		val   interface{}
+		opts  []WriteOption
+	}{
+		{
+			name:  "int32",
+			fname: "../testdata/data_int32_2x3_bigendian.npy",
+			val:   [][]int32{{0, 1, -2}, {3, -4, 1 << 30}},
+		},
+		{
+			name:  "float64",
+			fname: "../testdata/data_float64_2x3_bigendian.npy",
+			val:   mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5}),
+		},
+		{
+			name:  "complex64",
+			fname: "../testdata/data_complex64_bigendian.npy",
+			val:   []complex64{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+		{
+			name:  "complex128",
+			fname: "../testdata/data_complex128_bigendian.npy",
+			val:   []complex128{0, 1 + 2i, -3.5 - 4.25i, 1024 + 0.5i},
+		},
+		{
+			name:  "complex64-split",
+			fname: "../testdata/data_complex64_split_bigendian.npy",
+			val:   []complex64{1 + 2i, -3.5, -1i},
+			opts:  []WriteOption{WithSplitComplex(true)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := os.ReadFile(tc.fname)
+			if err != nil {
+				t.Fatalf("could not read reference file: %+v", err)
+			}
+
+			buf := new(bytes.Buffer)
+			err = WriteWith(buf, tc.val, append(tc.opts, WithByteOrder(binary.BigEndian))...)
+			if err != nil {
+				t.Fatalf("could not write value: %+v", err)
+			}
+			if got := buf.Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("invalid file content:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		val   interface{}
+		descr string
+	}{
+		{"bool", []bool{true, false}, "|b1"},
+		{"uint8", []uint8{1, 2}, "|u1"},
+		{"uint16", []uint16{1, 0xff00}, ">u2"},
+		{"int64", [2]int64{-1, 1 << 40}, ">i8"},
+		{"float32", float32(-1.5), ">f4"},
+		{"unicode", []string{"hé", "ok"}, ">U2"},
+		{"timedelta", []time.Duration{time.Second, -time.Hour}, ">m8[ns]"},
+	} {
+		t.Run("roundtrip-"+tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteWith(buf, tc.val, WithByteOrder(binary.BigEndian))
+			if err != nil {
+				t.Fatalf("could not write value: %+v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.val))
+			hdr, err := ReadWithHeader(bytes.NewReader(buf.Bytes()), got.Interface())
+			if err != nil {
+				t.Fatalf("could not read value: %+v", err)
+			}
+			if got, want := hdr.Descr.Type, tc.descr; got != want {
+				t.Fatalf("invalid descr: got=%q, want=%q", got, want)
+			}
+
+			if got := got.Elem().Interface(); !reflect.DeepEqual(got, tc.val) {
+				t.Fatalf("invalid value:\ngot= %v\nwant=%v", got, tc.val)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package npyhttp provides read access to NumPy data files served over HTTP,
+// using HTTP range requests to only fetch the needed bytes.
+package npyhttp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sbinet/npyio/npy"
+)
+
+const (
+	hdrChunk  = 4 << 10 // initial size of the header range request
+	dataChunk = 1 << 20 // size of the data range requests
+)
+
+// ReaderAt implements io.ReaderAt over a remote HTTP resource,
+// using HTTP range requests.
+type ReaderAt struct {
+	ctx  context.Context
+	cli  *http.Client
+	url  string
+	size int64
+}
+
+// NewReaderAt returns a new ReaderAt reading from the resource at url.
+// The provided context is used for all the requests issued by ReaderAt.
+// If cli is nil, http.DefaultClient is used.
+func NewReaderAt(ctx context.Context, cli *http.Client, url string) (*ReaderAt, error) {
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("npyhttp: could not create request: %w", err)
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("npyhttp: could not stat %q: %w", url, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("npyhttp: could not stat %q: %s", url, resp.Status)
+	}
+	if resp.ContentLength < 0 {
+		return nil, fmt.Errorf("npyhttp: unknown size for %q", url)
+	}
+
+	return &ReaderAt{
+		ctx:  ctx,
+		cli:  cli,
+		url:  url,
+		size: resp.ContentLength,
+	}, nil
+}
+
+// Size returns the size in bytes of the remote resource.
+func (r *ReaderAt) Size() int64 {
+	return r.size
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *ReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("npyhttp: negative offset")
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	end := off + int64(len(p))
+	if end > r.size {
+		end = r.size
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("npyhttp: could not create request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, end-1))
+
+	resp, err := r.cli.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("npyhttp: could not fetch range [%d, %d) of %q: %w", off, end, r.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, fmt.Errorf("npyhttp: could not fetch range [%d, %d) of %q: %s", off, end, r.url, resp.Status)
+	}
+
+	n, err := io.ReadFull(resp.Body, p[:end-off])
+	if err != nil {
+		return n, fmt.Errorf("npyhttp: could not read range [%d, %d) of %q: %w", off, end, r.url, err)
+	}
+	if end-off < int64(len(p)) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// ReadURL reads the NumPy data file at url into the provided pointed at
+// value ptr, following the rules of npy.Read.
+//
+// ReadURL first fetches the header of the remote file, and then only the
+// part of the array data needed to fill ptr: reading into a pre-sized slice
+// only fetches the first elements of a large remote array.
+func ReadURL(ctx context.Context, url string, ptr interface{}) (npy.Header, error) {
+	ra, err := NewReaderAt(ctx, nil, url)
+	if err != nil {
+		return npy.Header{}, err
+	}
+
+	hdr, err := fetchHeader(ra)
+	if err != nil {
+		return npy.Header{}, err
+	}
+
+	data := io.NewSectionReader(ra, int64(len(hdr)), ra.Size()-int64(len(hdr)))
+	r, err := npy.NewReader(io.MultiReader(
+		bytes.NewReader(hdr),
+		bufio.NewReaderSize(data, dataChunk),
+	))
+	if err != nil {
+		return npy.Header{}, fmt.Errorf("npyhttp: could not read header of %q: %w", url, err)
+	}
+
+	err = r.Read(ptr)
+	if err != nil {
+		return r.Header, fmt.Errorf("npyhttp: could not read data of %q: %w", url, err)
+	}
+
+	return r.Header, nil
+}
+
+// fetchHeader returns the raw bytes of the NumPy header of the remote file.
+func fetchHeader(ra *ReaderAt) ([]byte, error) {
+	for n := int64(hdrChunk); ; n *= 2 {
+		if n > ra.Size() {
+			n = ra.Size()
+		}
+		buf := make([]byte, n)
+		_, err := ra.ReadAt(buf, 0)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		cr := &countReader{r: bytes.NewReader(buf)}
+		_, err = npy.NewReader(cr)
+		switch {
+		case err == nil:
+			return buf[:cr.n], nil
+		case n < ra.Size() && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)):
+			// header larger than the current chunk: fetch more.
+			continue
+		default:
+			return nil, fmt.Errorf("npyhttp: could not read header of %q: %w", ra.url, err)
+		}
+	}
+}
+
+type countReader struct {
+	r io.Reader
+	n int
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += n
+	return n, err
+}
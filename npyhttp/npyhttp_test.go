@@ -0,0 +1,181 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npyhttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sbinet/npyio/npy"
+)
+
+type server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func newServer(t *testing.T, data []byte) *server {
+	srv := &server{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.mu.Lock()
+		if rng := r.Header.Get("Range"); rng != "" {
+			srv.ranges = append(srv.ranges, rng)
+		}
+		srv.mu.Unlock()
+		http.ServeContent(w, r, "data.npy", time.Time{}, bytes.NewReader(data))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// fetched returns the number of bytes requested through range requests.
+func (srv *server) fetched() int {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	n := 0
+	for _, rng := range srv.ranges {
+		toks := strings.Split(strings.TrimPrefix(rng, "bytes="), "-")
+		beg, _ := strconv.Atoi(toks[0])
+		end, _ := strconv.Atoi(toks[1])
+		n += end - beg + 1
+	}
+	return n
+}
+
+func TestReadURL(t *testing.T) {
+	want := make([]float64, 1<<18)
+	for i := range want {
+		want[i] = float64(i)
+	}
+	buf := new(bytes.Buffer)
+	err := npy.Write(buf, want)
+	if err != nil {
+		t.Fatalf("could not write data: %+v", err)
+	}
+	size := buf.Len()
+
+	t.Run("full", func(t *testing.T) {
+		srv := newServer(t, buf.Bytes())
+
+		var got []float64
+		hdr, err := ReadURL(context.Background(), srv.URL, &got)
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if got, want := hdr.Descr.Shape, []int{len(want)}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid data")
+		}
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		srv := newServer(t, buf.Bytes())
+
+		got := make([]float64, 10)
+		_, err := ReadURL(context.Background(), srv.URL, &got)
+		if err != nil {
+			t.Fatalf("could not read data: %+v", err)
+		}
+		if !reflect.DeepEqual(got, want[:10]) {
+			t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want[:10])
+		}
+		if n := srv.fetched(); n >= size {
+			t.Fatalf("fetched the whole file (%d bytes)", n)
+		}
+	})
+
+	t.Run("not-npy", func(t *testing.T) {
+		srv := newServer(t, []byte("not a npy file"))
+
+		var got []float64
+		_, err := ReadURL(context.Background(), srv.URL, &got)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestReadURLLargeHeader(t *testing.T) {
+	// a header padded past the initial header chunk, as left by writers
+	// reserving room for growing arrays.
+	const hlen = 9<<10 - 10
+	dict := "{'descr': '<f8', 'fortran_order': False, 'shape': (4,), }"
+	buf := new(bytes.Buffer)
+	buf.Write(npy.Magic[:])
+	buf.Write([]byte{1, 0})
+	_ = binary.Write(buf, binary.LittleEndian, uint16(hlen))
+	buf.WriteString(dict)
+	buf.WriteString(strings.Repeat(" ", hlen-len(dict)-1))
+	buf.WriteString("\n")
+	want := []float64{1, 2, 3, 4}
+	_ = binary.Write(buf, binary.LittleEndian, want)
+
+	srv := newServer(t, buf.Bytes())
+
+	var got []float64
+	_, err := ReadURL(context.Background(), srv.URL, &got)
+	if err != nil {
+		t.Fatalf("could not read data: %+v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, want)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	var hdrs []string
+	for _, rng := range srv.ranges {
+		if strings.HasPrefix(rng, "bytes=0-") {
+			hdrs = append(hdrs, rng)
+		}
+	}
+	// 4 KiB and 8 KiB are too short, the third request gets the whole file.
+	wantHdrs := []string{
+		"bytes=0-" + strconv.Itoa(hdrChunk-1),
+		"bytes=0-" + strconv.Itoa(2*hdrChunk-1),
+		"bytes=0-" + strconv.Itoa(buf.Len()-1),
+	}
+	if !reflect.DeepEqual(hdrs, wantHdrs) {
+		t.Fatalf("invalid header range requests:\ngot= %q\nwant=%q", hdrs, wantHdrs)
+	}
+	if got, want := len(srv.ranges), len(wantHdrs)+1; got != want {
+		t.Fatalf("invalid number of range requests: got=%d, want=%d (%q)", got, want, srv.ranges)
+	}
+}
+
+func TestReaderAtNoRange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "4")
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer srv.Close()
+
+	ra, err := NewReaderAt(context.Background(), nil, srv.URL)
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	if got, want := ra.Size(), int64(4); got != want {
+		t.Fatalf("invalid size: got=%d, want=%d", got, want)
+	}
+
+	_, err = ra.ReadAt(make([]byte, 2), 1)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -58,10 +58,13 @@
 package npyio
 
 import (
+	"context"
 	"io"
+	"os"
 	"reflect"
 
 	"github.com/sbinet/npyio/npy"
+	"github.com/sbinet/npyio/npz"
 )
 
 var (
@@ -79,20 +82,92 @@ var (
 	// reliably (de)serialized.
 	ErrInvalidType = npy.ErrInvalidType
 
+	// ErrTooLargeForMemory is the error returned by Reader when loading
+	// the whole array data would exceed the limit set with npy.WithMaxBytes.
+	ErrTooLargeForMemory = npy.ErrTooLargeForMemory
+
+	// ErrOutOfRange is the error returned by WriteAs when a value can
+	// not be represented in the requested data type.
+	ErrOutOfRange = npy.ErrOutOfRange
+
+	// ErrNotContiguous is the error returned by WriteWith when the
+	// npy.WithContiguous option is set and the value to write is not laid
+	// out contiguously in C-order in memory.
+	ErrNotContiguous = npy.ErrNotContiguous
+
 	// Magic header present at the start of a NumPy data file format.
 	// See https://numpy.org/neps/nep-0001-npy-format.html
 	Magic = npy.Magic
 )
 
+// Error is the type of the errors returned by NewReader, Reader.Read,
+// Write and WriteAs.
+type Error = npy.Error
+
+// ErrorKind classifies errors.
+// See npy.ErrorKind for the list of kinds.
+type ErrorKind = npy.ErrorKind
+
+// HeaderError describes a malformed NumPy header.
+type HeaderError = npy.HeaderError
+
+// MismatchError describes an array whose on-disk data type can not be
+// read into the Go destination.
+type MismatchError = npy.MismatchError
+
 // Header describes the data content of a NumPy data file.
 type Header = npy.Header
 
+// Kind is the kind of the elements of a NumPy array.
+// See npy.Kind for the list of kinds.
+type Kind = npy.Kind
+
 // Reader reads data from a NumPy data file.
 type Reader = npy.Reader
 
+// RowReader reads the rows of a 2-dimensional float64 array from a NumPy
+// data file, a chunk of rows at a time.
+type RowReader = npy.RowReader
+
+// NewRowReader creates a new RowReader reading from r.
+//
+// See npy.NewRowReader for documentation.
+func NewRowReader(r io.Reader, opts ...ReadOption) (*RowReader, error) {
+	return npy.NewRowReader(r, opts...)
+}
+
+// MappedArray is a NumPy array whose data is memory-mapped from a file.
+type MappedArray = npy.MappedArray
+
+// OpenMmap memory-maps the NumPy data file at path and parses its header.
+//
+// See npy.OpenMmap for documentation.
+func OpenMmap(path string) (*MappedArray, error) {
+	return npy.OpenMmap(path)
+}
+
+// ReadOption configures a Reader.
+type ReadOption = npy.ReadOption
+
 // NewReader creates a new NumPy data file format reader.
-func NewReader(r io.Reader) (*Reader, error) {
-	return npy.NewReader(r)
+func NewReader(r io.Reader, opts ...ReadOption) (*Reader, error) {
+	return npy.NewReader(r, opts...)
+}
+
+// ReadHeader reads the header of the NumPy data file from r, leaving r
+// positioned at the start of the array data.
+//
+// See npy.ReadHeader for documentation.
+func ReadHeader(r io.Reader) (Header, error) {
+	return npy.ReadHeader(r)
+}
+
+// ReadHeaderAt reads the header of a NumPy data file starting at offset off
+// in r.
+// ReadHeaderAt returns the header and the offset in r where the array data
+// starts.
+func ReadHeaderAt(r io.ReaderAt, off int64) (Header, int64, error) {
+	return npy.ReadHeaderAt(r, off)
 }
 
 // Read reads the data from the r NumPy data file io.Reader, into the
@@ -103,11 +178,110 @@ func NewReader(r io.Reader) (*Reader, error) {
 // If a *mat.Dense matrix is passed to Read, the numpy-array data is loaded
 // into the Dense matrix, honouring Fortran/C-order and dimensions/shape
 // parameters.
+// 1-dimensional numpy-arrays are loaded as row vectors, unless the
+// npy.WithVector option is provided.
+//
+// Arrays of any number of dimensions can be read into scalars, slices,
+// nested slices, structs, time values and registered types, as described
+// in npy.Read.
+func Read(r io.Reader, ptr interface{}, opts ...ReadOption) error {
+	return npy.Read(r, ptr, opts...)
+}
+
+// ReadWithHeader reads the data from the r NumPy data file io.Reader into
+// the provided pointed at value ptr, and returns the header of the file.
+//
+// See npy.ReadWithHeader for documentation.
+func ReadWithHeader(r io.Reader, ptr interface{}, opts ...ReadOption) (Header, error) {
+	return npy.ReadWithHeader(r, ptr, opts...)
+}
+
+// ReadCtx reads the data from the r NumPy data file io.Reader into the
+// provided pointed at value ptr, until ctx is done.
+//
+// See npy.ReadCtx for documentation.
+func ReadCtx(ctx context.Context, r io.Reader, ptr interface{}, opts ...ReadOption) error {
+	return npy.ReadCtx(ctx, r, ptr, opts...)
+}
+
+// Encoder encodes a value of a Go type registered with RegisterType.
+type Encoder = npy.Encoder
+
+// Decoder decodes an element of a data type registered with RegisterType.
+type Decoder = npy.Decoder
+
+// RegisterType registers the NumPy data type descr, as decoded into and
+// encoded from values of the Go type rt with dec and enc.
+//
+// See npy.RegisterType for documentation.
+func RegisterType(descr string, rt reflect.Type, enc Encoder, dec Decoder) {
+	npy.RegisterType(descr, rt, enc, dec)
+}
+
+// RegisterObjectCodec registers dec as the decoder of the data of object
+// arrays ('|O').
+//
+// See npy.RegisterObjectCodec for documentation.
+func RegisterObjectCodec(dec func(raw []byte) (interface{}, error)) {
+	npy.RegisterObjectCodec(dec)
+}
+
+// ReadRange reads the elements [lo, hi) of the NumPy array held in r, in
+// C-order, into the provided pointed at value dst.
+//
+// See npy.ReadRange for documentation.
+func ReadRange(r io.ReaderAt, lo, hi int, dst interface{}, opts ...ReadOption) error {
+	return npy.ReadRange(r, lo, hi, dst, opts...)
+}
+
+// ReadZipAll reads all the members of the npz archive r, which is assumed
+// to have the given size in bytes, and returns their arrays and headers
+// keyed by member name.
+//
+// See npz.ReadAll for documentation.
+func ReadZipAll(r io.ReaderAt, size int64) (map[string]interface{}, map[string]Header, error) {
+	return npz.ReadAll(r, size)
+}
+
+// WriteRagged writes rows, a ragged array of variable-length rows, to w as
+// an npz archive holding the offsets of the rows and their concatenated
+// values.
 //
-// Only numpy-arrays with up to 2 dimensions are supported.
-// Only numpy-arrays with elements convertible to float64 are supported.
-func Read(r io.Reader, ptr interface{}) error {
-	return npy.Read(r, ptr)
+// See npz.WriteRagged for documentation.
+func WriteRagged(w io.Writer, rows [][]float64) error {
+	return npz.WriteRagged(w, rows)
+}
+
+// ReadRagged reads the ragged array held in the npz archive r, which is
+// assumed to have the given size in bytes, as written by WriteRagged.
+//
+// See npz.ReadRagged for documentation.
+func ReadRagged(r io.ReaderAt, size int64) ([][]float64, error) {
+	return npz.ReadRagged(r, size)
+}
+
+// MustRead is like Read but panics if the data can not be read.
+// It is intended for tests and scripts where errors are fatal.
+func MustRead(r io.Reader, ptr interface{}, opts ...ReadOption) {
+	npy.MustRead(r, ptr, opts...)
+}
+
+// ComplexParts holds the real and the imaginary parts of the elements of a
+// complex array, in two parallel slices.
+// See npy.ComplexParts for documentation.
+type ComplexParts = npy.ComplexParts
+
+// ReadPolar reads a complex numpy-array ('c8' or 'c16') from r and stores
+// the magnitude and the phase of its elements into mag and phase, in the
+// order they are stored on disk, as Read does for slices.
+func ReadPolar(r io.Reader, mag, phase *[]float64) error {
+	return npy.ReadPolar(r, mag, phase)
+}
+
+// Float16ToFloat32 decodes the little-endian half-precision values of raw,
+// as stored in the data section of a '<f2' array, into float32 values.
+func Float16ToFloat32(raw []byte) []float32 {
+	return npy.Float16ToFloat32(raw)
 }
 
 // TypeFrom returns the reflect.Type corresponding to the numpy-dtype string, if any.
@@ -115,6 +289,15 @@ func TypeFrom(dtype string) reflect.Type {
 	return npy.TypeFrom(dtype)
 }
 
+// TypeOf returns the Go type of the elements Read decodes from arrays with
+// the provided NumPy data type descriptor (e.g. '<f8' gives float64).
+//
+// TypeOf returns an error wrapping ErrInvalidType if the descriptor is not
+// supported.
+func TypeOf(descr string) (reflect.Type, error) {
+	return npy.TypeOf(descr)
+}
+
 // Write writes 'val' into 'w' in the NumPy data format.
 //
 //   - if val is a scalar, it must be of a supported type (bools, (u)ints, floats and complexes)
@@ -126,3 +309,178 @@ func TypeFrom(dtype string) reflect.Type {
 func Write(w io.Writer, val interface{}) error {
 	return npy.Write(w, val)
 }
+
+// MustWrite is like Write but panics if the data can not be written.
+// It is intended for tests and scripts where errors are fatal.
+func MustWrite(w io.Writer, val interface{}) {
+	npy.MustWrite(w, val)
+}
+
+// WriteOption configures how values are written.
+type WriteOption = npy.WriteOption
+
+// Writer writes the data of a NumPy array described by a user-provided
+// header.
+type Writer = npy.Writer
+
+// NewWriter writes the header h to w and returns a Writer for the array
+// data.
+//
+// See npy.NewWriter for documentation.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	return npy.NewWriter(w, h)
+}
+
+// Appender appends elements to the array of a NumPy data file.
+type Appender = npy.Appender
+
+// OpenAppend reads the header of the NumPy data file f and returns an
+// Appender for its array.
+//
+// See npy.OpenAppend for documentation.
+func OpenAppend(f *os.File) (*Appender, error) {
+	return npy.OpenAppend(f)
+}
+
+// PayloadReader reads the raw bytes of the array data of a NumPy data file.
+type PayloadReader = npy.PayloadReader
+
+// NewPayloadReader reads the header of the NumPy data file from r and
+// returns a PayloadReader for its array data, together with the header.
+//
+// See npy.NewPayloadReader for documentation.
+func NewPayloadReader(r io.Reader) (*PayloadReader, Header, error) {
+	return npy.NewPayloadReader(r)
+}
+
+// WriteCtx writes the value val into the io.Writer w, until ctx is done.
+//
+// See npy.WriteCtx for documentation.
+func WriteCtx(ctx context.Context, w io.Writer, val interface{}, opts ...WriteOption) error {
+	return npy.WriteCtx(ctx, w, val, opts...)
+}
+
+// WriteStream writes the header h to w, followed by the raw array data
+// read from src.
+//
+// See npy.WriteStream for documentation.
+func WriteStream(w io.Writer, h Header, src io.Reader) error {
+	return npy.WriteStream(w, h, src)
+}
+
+// WriteWith writes 'val' into 'w' in the NumPy data format, as Write does,
+// configured with the provided options.
+func WriteWith(w io.Writer, val interface{}, opts ...WriteOption) error {
+	return npy.WriteWith(w, val, opts...)
+}
+
+// WriteAs writes 'val' into 'w' in the NumPy data format, converting its
+// elements to the provided NumPy data type (e.g. '<i4', '|u1', '<f4').
+//
+// Out-of-range values are handled according to the npy.WithCast option.
+func WriteAs(w io.Writer, val interface{}, dtype string, opts ...WriteOption) error {
+	return npy.WriteAs(w, val, dtype, opts...)
+}
+
+// WriteChecksummed writes 'val' into 'w' in the NumPy data format, followed
+// by a checksum footer.
+//
+// See npy.WriteChecksummed for documentation.
+func WriteChecksummed(w io.Writer, val interface{}) error {
+	return npy.WriteChecksummed(w, val)
+}
+
+// VerifyChecksum validates the checksum footer of the NumPy data file held
+// in r.
+//
+// See npy.VerifyChecksum for documentation.
+func VerifyChecksum(r io.ReaderAt, size int64) (bool, error) {
+	return npy.VerifyChecksum(r, size)
+}
+
+// Concat concatenates the NumPy arrays read from the provided readers
+// along their first axis, and writes the resulting array to w.
+//
+// See npy.ConcatAxis for documentation.
+func Concat(w io.Writer, readers ...io.Reader) error {
+	return npy.Concat(w, readers...)
+}
+
+// ConcatAxis concatenates the NumPy arrays read from the provided readers
+// along the provided axis, and writes the resulting array to w.
+//
+// See npy.ConcatAxis for documentation.
+func ConcatAxis(w io.Writer, axis int, readers ...io.Reader) error {
+	return npy.ConcatAxis(w, axis, readers...)
+}
+
+// Transpose2D writes to dst the transpose of the 2-dimensional numeric
+// array described by hdr, whose data is read from src.
+//
+// See npy.Transpose2D for documentation.
+func Transpose2D(dst io.Writer, src io.ReaderAt, hdr Header) error {
+	return npy.Transpose2D(dst, src, hdr)
+}
+
+// WriteWithDescr writes 'val' into 'w' in the NumPy data format, using the
+// provided NumPy data type descriptor and shape verbatim.
+//
+// val must be a scalar, a slice or an array of a supported type, whose
+// elements are laid out in C-order.
+// WriteWithDescr checks that the size of the Go elements matches the item
+// size of descr and that the number of elements matches the shape.
+func WriteWithDescr(w io.Writer, val interface{}, descr string, shape []int) error {
+	return npy.WriteWithDescr(w, val, descr, shape)
+}
+
+// Numeric is the set of Go types that can be read into a Tensor, or read
+// and written with ReadSlice and WriteSlice.
+type Numeric = npy.Numeric
+
+// ReadTensor reads the numpy-array data from r into a Tensor.
+// ReadTensor returns an error if the on-disk data type and T don't match,
+// unless the WithConvert option allows the conversion.
+func ReadTensor[T Numeric](r io.Reader, opts ...ReadOption) (*npy.Tensor[T], error) {
+	return npy.ReadTensor[T](r, opts...)
+}
+
+// ReadSlice reads the numpy-array data from r into a slice of T, in storage
+// order, and returns it together with the header of the array.
+//
+// See npy.ReadSlice for documentation.
+func ReadSlice[T Numeric](r io.Reader, opts ...ReadOption) ([]T, Header, error) {
+	return npy.ReadSlice[T](r, opts...)
+}
+
+// WriteSlice writes data into w in the NumPy data format, as a C-ordered
+// array with the provided shape.
+//
+// See npy.WriteSlice for documentation.
+func WriteSlice[T Numeric](w io.Writer, data []T, shape ...int) error {
+	return npy.WriteSlice(w, data, shape...)
+}
+
+// StructHeader returns the header describing a structured array whose
+// records are the values of the struct type of v.
+//
+// See npy.StructHeader for documentation.
+func StructHeader(v interface{}) (Header, error) {
+	return npy.StructHeader(v)
+}
+
+// EachRecord reads the records of a NumPy structured array from r, one at
+// a time, and calls fn with the index of each record.
+//
+// See npy.EachRecord for documentation.
+func EachRecord[T any](r io.Reader, fn func(i int, rec *T) error) error {
+	return npy.EachRecord(r, fn)
+}
+
+// ReadNullableRecords reads all the records of a NumPy structured array
+// from r into a slice of pointers to T, setting to nil the records for
+// which isNull reports true.
+//
+// See npy.ReadNullableRecords for documentation.
+func ReadNullableRecords[T any](r io.Reader, isNull func(rec *T) bool) ([]*T, error) {
+	return npy.ReadNullableRecords(r, isNull)
+}
@@ -7,11 +7,62 @@ package npyio
 import (
 	"bytes"
 	"io"
+	"os"
 	"testing"
 
+	"github.com/sbinet/npyio/npy"
 	"gonum.org/v1/gonum/mat"
 )
 
+func TestWriteGolden(t *testing.T) {
+	type record struct {
+		X float64 `npy:"x"`
+		Y int32   `npy:"y"`
+	}
+
+	i64 := make([]int64, 1000)
+	for i := range i64 {
+		i64[i] = int64(i)
+	}
+
+	for _, tc := range []struct {
+		name string
+		val  interface{}
+		opts []WriteOption
+	}{
+		{name: "float64_2x3", val: mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5})},
+		{name: "int16_5", val: []int16{0, 1, 2, 3, 4}},
+		{
+			name: "float32_2x3_forder",
+			val:  [][]float32{{0, 1, 2}, {3, 4, 5}},
+			opts: []WriteOption{npy.WithFortranOrder(true)},
+		},
+		{name: "bool_scalar", val: true},
+		{name: "uint8_empty", val: []uint8{}},
+		{name: "int64_1000", val: i64},
+		{name: "records", val: []record{{1.5, -1}, {2.5, 7}}},
+		{name: "unicode_U3", val: []string{"a", "bcd"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := "testdata/data_golden_" + tc.name + ".npy"
+			want, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("could not read reference file %q: %+v", fname, err)
+			}
+
+			got := new(bytes.Buffer)
+			err = WriteWith(got, tc.val, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not write data: %+v", err)
+			}
+
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Fatalf("output differs from np.save:\ngot= %q\nwant=%q", got.Bytes(), want)
+			}
+		})
+	}
+}
+
 func BenchmarkWriteDense(b *testing.B) {
 	data := make([]float64, 1000)
 	m := mat.NewDense(100, 10, data)
@@ -214,6 +265,20 @@ func BenchmarkReadFloat32Slice(b *testing.B) {
 	}
 }
 
+func BenchmarkReadFloat32SliceReuse(b *testing.B) {
+	buf := new(bytes.Buffer)
+	_ = Write(buf, make([]float32, 1000))
+	r := &reader{buf: buf.Bytes()}
+	data := make([]float32, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = Read(r, &data)
+		r.reset()
+	}
+}
+
 func BenchmarkReadFloat64Slice(b *testing.B) {
 	buf := new(bytes.Buffer)
 	_ = Write(buf, make([]float64, 1000))
@@ -331,6 +396,20 @@ func BenchmarkReadInt32Slice(b *testing.B) {
 	}
 }
 
+func BenchmarkReadInt32SliceReuse(b *testing.B) {
+	buf := new(bytes.Buffer)
+	_ = Write(buf, make([]int32, 1000))
+	r := &reader{buf: buf.Bytes()}
+	data := make([]int32, 1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = Read(r, &data)
+		r.reset()
+	}
+}
+
 func BenchmarkReadInt64Slice(b *testing.B) {
 	buf := new(bytes.Buffer)
 	_ = Write(buf, make([]int64, 1000))
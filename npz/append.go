@@ -0,0 +1,298 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npz
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	dirHeaderSig = 0x02014b50 // central directory file header
+	dirEndSig    = 0x06054b50 // end of central directory record
+	dir64EndSig  = 0x06064b50 // zip64 end of central directory record
+	dir64LocSig  = 0x07064b50 // zip64 end of central directory locator
+	dirHeaderLen = 46
+	dirEndLen    = 22
+	dir64EndLen  = 56
+	dir64LocLen  = 20
+	uint16max    = 1<<16 - 1
+	uint32max    = 1<<32 - 1
+)
+
+var errDirEnd = errors.New("npz: could not find zip end of central directory")
+
+// Append adds the named NumPy array data v to the npz archive f.
+// f must have been opened for reading and writing.
+//
+// The archive is updated in place: the new member is written over the zip
+// central directory, after the existing members, followed by a new central
+// directory. Existing members are neither moved nor rewritten, and the
+// original central directory of f is restored if Append fails.
+//
+// Append returns an error if the archive already holds a member with the
+// same name, unless the WithOverwrite option is set. The data of a
+// replaced member is then left unreferenced in the archive.
+func Append(f *os.File, name string, v interface{}, opts ...WriteOption) (err error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("npz: could not stat %q: %w", f.Name(), err)
+	}
+	size := stat.Size()
+
+	end, err := readDirEnd(f, size)
+	if err != nil {
+		return fmt.Errorf("npz: could not open zip file %q: %w", f.Name(), err)
+	}
+
+	if end.offset < 0 || end.size < 0 || end.offset+end.size > size {
+		return fmt.Errorf("npz: could not read zip central directory of %q: %w", f.Name(), zip.ErrFormat)
+	}
+
+	// the central directory and the end records, overwritten below.
+	tail := make([]byte, size-end.offset)
+	_, err = f.ReadAt(tail, end.offset)
+	if err != nil {
+		return fmt.Errorf("npz: could not read zip central directory of %q: %w", f.Name(), err)
+	}
+
+	ow := io.NewOffsetWriter(f, end.offset)
+	w := NewWriter(ow, opts...)
+	w.wz.SetOffset(end.offset)
+
+	name = memberName(name)
+	dir := append([]byte(nil), tail[:end.size]...)
+	dir, n, found, err := dropDirHeader(dir, name)
+	switch {
+	case err != nil:
+		return fmt.Errorf("npz: could not read zip central directory of %q: %w", f.Name(), err)
+	case found && !w.owrite:
+		return fmt.Errorf("npz: member %q already exists in %q", name, f.Name())
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		// only the original central directory has been overwritten.
+		_, e := f.WriteAt(tail, end.offset)
+		if e == nil {
+			e = f.Truncate(size)
+		}
+		if e != nil {
+			err = fmt.Errorf("%w (could not restore %q: %v)", err, f.Name(), e)
+		}
+	}()
+
+	err = w.Write(name, v)
+	if err != nil {
+		return err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	// the central directory written by w only holds the new member:
+	// keep its record and write the full directory in its place.
+	written, _ := ow.Seek(0, io.SeekCurrent)
+	last, err := readDirEnd(f, end.offset+written)
+	if err != nil {
+		return fmt.Errorf("npz: could not read zip central directory of %q: %w", f.Name(), err)
+	}
+	raw := make([]byte, last.size)
+	_, err = f.ReadAt(raw, last.offset)
+	if err != nil {
+		return fmt.Errorf("npz: could not read zip central directory of %q: %w", f.Name(), err)
+	}
+	rec, err := dirHeader(raw)
+	if err != nil {
+		return fmt.Errorf("npz: could not read zip central directory of %q: %w", f.Name(), err)
+	}
+
+	buf := bytes.NewBuffer(dir)
+	buf.Write(rec)
+	writeDirEnd(buf, dirEnd{
+		records: uint64(n + 1),
+		size:    int64(len(dir) + len(rec)),
+		offset:  last.offset,
+		comment: end.comment,
+	})
+
+	_, err = f.WriteAt(buf.Bytes(), last.offset)
+	if err != nil {
+		return fmt.Errorf("npz: could not write zip central directory of %q: %w", f.Name(), err)
+	}
+
+	err = f.Truncate(last.offset + int64(buf.Len()))
+	if err != nil {
+		return fmt.Errorf("npz: could not truncate %q: %w", f.Name(), err)
+	}
+
+	return nil
+}
+
+// dirEnd describes the central directory of a zip archive.
+type dirEnd struct {
+	records uint64 // number of central directory records
+	size    int64  // size of the central directory
+	offset  int64  // offset of the central directory
+	comment string // archive comment
+}
+
+// readDirEnd reads the end of central directory record of the zip archive
+// r of the provided size, as well as its zip64 counterpart, if any.
+func readDirEnd(r io.ReaderAt, size int64) (dirEnd, error) {
+	n := int64(dirEndLen + uint16max)
+	if n > size {
+		n = size
+	}
+	buf := make([]byte, n)
+	_, err := r.ReadAt(buf, size-n)
+	if err != nil && err != io.EOF {
+		return dirEnd{}, err
+	}
+
+	pos := -1
+	for i := len(buf) - dirEndLen; i >= 0; i-- {
+		if binary.LittleEndian.Uint32(buf[i:]) == dirEndSig {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return dirEnd{}, errDirEnd
+	}
+
+	b := buf[pos:]
+	end := dirEnd{
+		records: uint64(binary.LittleEndian.Uint16(b[10:])),
+		size:    int64(binary.LittleEndian.Uint32(b[12:])),
+		offset:  int64(binary.LittleEndian.Uint32(b[16:])),
+	}
+	if clen := int(binary.LittleEndian.Uint16(b[20:])); clen <= len(b)-dirEndLen {
+		end.comment = string(b[dirEndLen : dirEndLen+clen])
+	}
+
+	if end.records != uint16max && end.size != uint32max && end.offset != uint32max {
+		return end, nil
+	}
+
+	// zip64 archive.
+	loc := size - n + int64(pos) - dir64LocLen
+	if loc < 0 {
+		return dirEnd{}, errDirEnd
+	}
+	var lbuf [dir64LocLen]byte
+	_, err = r.ReadAt(lbuf[:], loc)
+	if err != nil {
+		return dirEnd{}, err
+	}
+	if binary.LittleEndian.Uint32(lbuf[:]) != dir64LocSig {
+		return dirEnd{}, errDirEnd
+	}
+
+	var ebuf [dir64EndLen]byte
+	_, err = r.ReadAt(ebuf[:], int64(binary.LittleEndian.Uint64(lbuf[8:])))
+	if err != nil {
+		return dirEnd{}, err
+	}
+	if binary.LittleEndian.Uint32(ebuf[:]) != dir64EndSig {
+		return dirEnd{}, errDirEnd
+	}
+	end.records = binary.LittleEndian.Uint64(ebuf[32:])
+	end.size = int64(binary.LittleEndian.Uint64(ebuf[40:]))
+	end.offset = int64(binary.LittleEndian.Uint64(ebuf[48:]))
+	return end, nil
+}
+
+// writeDirEnd writes the end of central directory record of end to w,
+// preceded by its zip64 counterpart when needed.
+func writeDirEnd(w *bytes.Buffer, end dirEnd) {
+	var (
+		le      = binary.LittleEndian
+		records = end.records
+		size    = uint64(end.size)
+		offset  = uint64(end.offset)
+	)
+
+	if records >= uint16max || size >= uint32max || offset >= uint32max {
+		var b [dir64EndLen + dir64LocLen]byte
+		le.PutUint32(b[0:], dir64EndSig)
+		le.PutUint64(b[4:], dir64EndLen-12) // size of the remaining record
+		le.PutUint16(b[12:], 45)            // version made by
+		le.PutUint16(b[14:], 45)            // version needed to extract
+		le.PutUint64(b[24:], records)       // records on this disk
+		le.PutUint64(b[32:], records)       // total number of records
+		le.PutUint64(b[40:], size)
+		le.PutUint64(b[48:], offset)
+
+		le.PutUint32(b[56:], dir64LocSig)
+		le.PutUint64(b[64:], offset+size) // offset of the zip64 record
+		le.PutUint32(b[72:], 1)           // total number of disks
+		w.Write(b[:])
+
+		records = uint16max
+		size = uint32max
+		offset = uint32max
+	}
+
+	var b [dirEndLen]byte
+	le.PutUint32(b[0:], dirEndSig)
+	le.PutUint16(b[8:], uint16(records))
+	le.PutUint16(b[10:], uint16(records))
+	le.PutUint32(b[12:], uint32(size))
+	le.PutUint32(b[16:], uint32(offset))
+	le.PutUint16(b[20:], uint16(len(end.comment)))
+	w.Write(b[:])
+	w.WriteString(end.comment)
+}
+
+// dirHeader returns the central directory record at the start of raw.
+func dirHeader(raw []byte) ([]byte, error) {
+	if len(raw) < dirHeaderLen || binary.LittleEndian.Uint32(raw) != dirHeaderSig {
+		return nil, zip.ErrFormat
+	}
+	n := dirHeaderLen +
+		int(binary.LittleEndian.Uint16(raw[28:])) +
+		int(binary.LittleEndian.Uint16(raw[30:])) +
+		int(binary.LittleEndian.Uint16(raw[32:]))
+	if n > len(raw) {
+		return nil, zip.ErrFormat
+	}
+	return raw[:n], nil
+}
+
+// dropDirHeader removes the record of the named member from the central
+// directory dir, and returns the number of records left and whether the
+// member was found.
+func dropDirHeader(dir []byte, name string) ([]byte, int, bool, error) {
+	var (
+		out   = dir[:0]
+		n     = 0
+		found = false
+	)
+	for len(dir) > 0 {
+		rec, err := dirHeader(dir)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		dir = dir[len(rec):]
+		nlen := int(binary.LittleEndian.Uint16(rec[28:]))
+		if string(rec[dirHeaderLen:dirHeaderLen+nlen]) == name {
+			found = true
+			continue
+		}
+		out = append(out, rec...)
+		n++
+	}
+	return out, n, found, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npz
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "data.npz")
+	err := Write(fname, map[string]interface{}{
+		"arr0.npy": []float64{1, 2, 3},
+		"arr1.npy": []int32{4, 5},
+	})
+	if err != nil {
+		t.Fatalf("could not create npz file: %+v", err)
+	}
+
+	f, err := os.OpenFile(fname, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("could not open npz file: %+v", err)
+	}
+	defer f.Close()
+
+	err = Append(f, "arr2.npy", []uint8{6, 7, 8, 9})
+	if err != nil {
+		t.Fatalf("could not append member: %+v", err)
+	}
+
+	err = Append(f, "arr1.npy", []int32{42})
+	if err == nil {
+		t.Fatalf("expected an error appending an existing member")
+	}
+
+	err = Append(f, "arr0.npy", []float64{10, 20}, WithOverwrite(true))
+	if err != nil {
+		t.Fatalf("could not overwrite member: %+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close npz file: %+v", err)
+	}
+
+	r, err := Open(fname)
+	if err != nil {
+		t.Fatalf("could not open npz file: %+v", err)
+	}
+	defer r.Close()
+
+	if got, want := r.Keys(), []string{"arr1.npy", "arr2.npy", "arr0.npy"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid keys: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		want interface{}
+	}{
+		{"arr0.npy", []float64{10, 20}},
+		{"arr1.npy", []int32{4, 5}},
+		{"arr2.npy", []uint8{6, 7, 8, 9}},
+	} {
+		got := reflect.New(reflect.TypeOf(tc.want))
+		err = r.Read(tc.name, got.Interface())
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", tc.name, err)
+		}
+		if !reflect.DeepEqual(got.Elem().Interface(), tc.want) {
+			t.Fatalf("invalid %q value: got=%v, want=%v", tc.name, got.Elem().Interface(), tc.want)
+		}
+	}
+}
+
+func TestAppendInPlace(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/data_comment.npz")
+	if err != nil {
+		t.Fatalf("could not read npz file: %+v", err)
+	}
+	fname := filepath.Join(t.TempDir(), "data.npz")
+	err = os.WriteFile(fname, raw, 0644)
+	if err != nil {
+		t.Fatalf("could not create npz file: %+v", err)
+	}
+
+	f, err := os.OpenFile(fname, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("could not open npz file: %+v", err)
+	}
+	defer f.Close()
+
+	t.Run("failure", func(t *testing.T) {
+		err := Append(f, "arr2.npy", make(chan int))
+		if err == nil {
+			t.Fatalf("expected an error appending an invalid value")
+		}
+
+		got, err := os.ReadFile(fname)
+		if err != nil {
+			t.Fatalf("could not read npz file: %+v", err)
+		}
+		if !bytes.Equal(got, raw) {
+			t.Fatalf("npz file modified by failed Append")
+		}
+	})
+
+	err = Append(f, "arr2.npy", []uint8{6, 7, 8, 9})
+	if err != nil {
+		t.Fatalf("could not append member: %+v", err)
+	}
+
+	got, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read npz file: %+v", err)
+	}
+	end, err := readDirEnd(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatalf("could not read zip central directory: %+v", err)
+	}
+	if !bytes.Equal(got[:end.offset], raw[:end.offset]) {
+		t.Fatalf("existing npz members modified by Append")
+	}
+	// the new member replaces the old central directory.
+	if got, want := binary.LittleEndian.Uint32(got[end.offset:]), uint32(0x04034b50); got != want {
+		t.Fatalf("invalid signature at offset %d: got=0x%x, want=0x%x", end.offset, got, want)
+	}
+	for _, tc := range []struct {
+		sig  uint32
+		want int
+	}{
+		{dirHeaderSig, 3},
+		{dirEndSig, 1},
+	} {
+		var sig [4]byte
+		binary.LittleEndian.PutUint32(sig[:], tc.sig)
+		if got := bytes.Count(got, sig[:]); got != tc.want {
+			t.Fatalf("invalid number of 0x%x records: got=%d, want=%d", tc.sig, got, tc.want)
+		}
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(got), int64(len(got)))
+	if err != nil {
+		t.Fatalf("could not open zip file: %+v", err)
+	}
+	if got, want := zr.Comment, "written by a numpy wrapper: torch-adjacent export v1.2"; got != want {
+		t.Fatalf("invalid comment: got=%q, want=%q", got, want)
+	}
+
+	r, err := NewReader(bytes.NewReader(got), int64(len(got)))
+	if err != nil {
+		t.Fatalf("could not open npz file: %+v", err)
+	}
+	if got, want := r.Keys(), []string{"arr0.npy", "arr1.npy", "arr2.npy"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid keys: got=%q, want=%q", got, want)
+	}
+	var arr2 []uint8
+	err = r.Read("arr2.npy", &arr2)
+	if err != nil {
+		t.Fatalf("could not read arr2: %+v", err)
+	}
+	if want := []uint8{6, 7, 8, 9}; !bytes.Equal(arr2, want) {
+		t.Fatalf("invalid arr2: got=%v, want=%v", arr2, want)
+	}
+}
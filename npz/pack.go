@@ -0,0 +1,162 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npz
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sbinet/npyio/npy"
+)
+
+// PackDir writes the NumPy data files paths to w, as the members of an npz
+// archive.
+//
+// The members are named after names, or after the base names of paths if
+// names is empty. As with Writer.Write, the ".npy" suffix is appended to
+// names when missing. PackDir returns an error if two files would end up
+// with the same member name.
+//
+// The files are copied verbatim, once checked to start with a valid NumPy
+// header. The underlying writer w is not closed.
+func PackDir(w io.Writer, paths []string, names []string, opts ...WriteOption) error {
+	if len(names) == 0 {
+		names = make([]string, len(paths))
+		for i, path := range paths {
+			names[i] = filepath.Base(path)
+		}
+	}
+	if len(names) != len(paths) {
+		return fmt.Errorf("npz: got %d names for %d files", len(names), len(paths))
+	}
+
+	seen := make(map[string]string, len(paths))
+	for i, path := range paths {
+		name := memberName(names[i])
+		if prev, dup := seen[name]; dup {
+			return fmt.Errorf("npz: %q and %q would both be stored as member %q", prev, path, name)
+		}
+		seen[name] = path
+	}
+
+	wz := NewWriter(w, opts...)
+	defer wz.Close()
+
+	for i, path := range paths {
+		err := wz.pack(memberName(names[i]), path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return wz.Close()
+}
+
+// pack copies the NumPy data file path to the archive, as the named member.
+func (w *Writer) pack(name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("npz: could not open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	_, err = npy.ReadHeader(f)
+	if err != nil {
+		return fmt.Errorf("npz: could not read header of %q: %w", path, err)
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("npz: could not rewind %q: %w", path, err)
+	}
+
+	fh := &zip.FileHeader{
+		Name:   name,
+		Method: w.method,
+	}
+	if w.stamp {
+		fh.Modified = time.Now()
+	}
+	ww, err := w.wz.CreateHeader(fh)
+	if err != nil {
+		return fmt.Errorf("npz: could not create npz entry %q: %w", name, err)
+	}
+
+	_, err = io.Copy(ww, f)
+	if err != nil {
+		return fmt.Errorf("npz: could not copy %q to npz entry %q: %w", path, name, err)
+	}
+	return nil
+}
+
+// UnpackZip extracts the members of the npz archive r, which is assumed to
+// have the given size in bytes, as standalone NumPy data files in the
+// directory dir.
+//
+// Each member is written to the file with its name, with the ".npy"
+// suffix appended when missing. Sub-directories are created as needed.
+// UnpackZip returns an error, before writing any file, if a member name is
+// not a local path (e.g. "../x.npy") or if two members map to the same
+// file. Existing files are not overwritten.
+func UnpackZip(dir string, r io.ReaderAt, size int64) error {
+	rz, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("npz: could not create zip reader: %w", err)
+	}
+
+	seen := make(map[string]string, len(rz.File))
+	for _, f := range rz.File {
+		name := memberName(f.Name)
+		if !filepath.IsLocal(name) || strings.Contains(name, `\`) {
+			return fmt.Errorf("npz: member %q does not name a local file", f.Name)
+		}
+		if prev, dup := seen[name]; dup {
+			return fmt.Errorf("npz: members %q and %q would both be extracted to %q", prev, f.Name, name)
+		}
+		seen[name] = f.Name
+	}
+
+	for _, f := range rz.File {
+		err := unpack(filepath.Join(dir, memberName(f.Name)), f)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unpack writes the archive member f to the new file path.
+func unpack(path string, f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("npz: could not open npz entry %q: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("npz: could not create directory for %q: %w", f.Name, err)
+	}
+	o, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("npz: could not create file for %q: %w", f.Name, err)
+	}
+	defer o.Close()
+
+	_, err = io.Copy(o, rc)
+	if err != nil {
+		return fmt.Errorf("npz: could not extract %q: %w", f.Name, err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("npz: could not close file of %q: %w", f.Name, err)
+	}
+	return nil
+}
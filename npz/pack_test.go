@@ -0,0 +1,161 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npz
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sbinet/npyio/npy"
+)
+
+func TestPackUnpack(t *testing.T) {
+	dir := t.TempDir()
+	vals := map[string]interface{}{
+		"a.npy": []float64{1, 2, 3},
+		"b.npy": []int16{4, 5},
+	}
+	var paths []string
+	for _, name := range []string{"a.npy", "b.npy"} {
+		path := filepath.Join(dir, name)
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("could not create %q: %+v", path, err)
+		}
+		err = npy.Write(f, vals[name])
+		if err != nil {
+			t.Fatalf("could not write %q: %+v", path, err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close %q: %+v", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		names []string
+		keys  []string
+	}{
+		{"base-names", nil, []string{"a.npy", "b.npy"}},
+		{"names", []string{"x", "y/z.npy"}, []string{"x.npy", "y/z.npy"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := PackDir(buf, paths, tc.names)
+			if err != nil {
+				t.Fatalf("could not pack files: %+v", err)
+			}
+
+			rz, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("could not open archive: %+v", err)
+			}
+			if got, want := rz.Keys(), tc.keys; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid keys: got=%v, want=%v", got, want)
+			}
+
+			out := t.TempDir()
+			err = UnpackZip(out, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("could not unpack archive: %+v", err)
+			}
+			for i, key := range tc.keys {
+				got, err := os.ReadFile(filepath.Join(out, key))
+				if err != nil {
+					t.Fatalf("could not read unpacked file: %+v", err)
+				}
+				want, err := os.ReadFile(paths[i])
+				if err != nil {
+					t.Fatalf("could not read original file: %+v", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Fatalf("%s: unpacked file differs from %q", key, paths[i])
+				}
+			}
+
+			// existing files are not overwritten.
+			err = UnpackZip(out, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err == nil || !strings.Contains(err.Error(), tc.keys[0]) {
+				t.Fatalf("invalid error: %+v", err)
+			}
+		})
+	}
+
+	notnpy := filepath.Join(dir, "c.txt")
+	err := os.WriteFile(notnpy, []byte("hello"), 0644)
+	if err != nil {
+		t.Fatalf("could not create %q: %+v", notnpy, err)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		paths []string
+		names []string
+		msg   string
+	}{
+		{"names-len", paths, []string{"x"}, "got 1 names for 2 files"},
+		{"collision", paths, []string{"x", "x.npy"}, `would both be stored as member "x.npy"`},
+		{"not-npy", []string{paths[0], notnpy}, nil, notnpy},
+		{"missing", []string{filepath.Join(dir, "missing.npy")}, nil, "missing.npy"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := PackDir(new(bytes.Buffer), tc.paths, tc.names)
+			if err == nil || !strings.Contains(err.Error(), tc.msg) {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.msg)
+			}
+		})
+	}
+}
+
+func TestUnpackZipInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		members []string
+		msg     string
+	}{
+		{"parent", []string{"../evil.npy"}, "does not name a local file"},
+		{"absolute", []string{"/tmp/evil.npy"}, "does not name a local file"},
+		{"collision", []string{"a", "a.npy"}, `would both be extracted to "a.npy"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			zw := zip.NewWriter(buf)
+			for _, name := range tc.members {
+				w, err := zw.Create(name)
+				if err != nil {
+					t.Fatalf("could not create member: %+v", err)
+				}
+				err = npy.Write(w, 1.0)
+				if err != nil {
+					t.Fatalf("could not write member: %+v", err)
+				}
+			}
+			err := zw.Close()
+			if err != nil {
+				t.Fatalf("could not close zip writer: %+v", err)
+			}
+
+			dir := t.TempDir()
+			err = UnpackZip(dir, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err == nil || !strings.Contains(err.Error(), tc.msg) {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.msg)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("could not read directory: %+v", err)
+			}
+			if len(entries) != 0 {
+				t.Fatalf("files were extracted: %v", entries)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npz
+
+import (
+	"fmt"
+	"io"
+)
+
+// Names of the members of the npz archives holding ragged arrays.
+const (
+	raggedOffsets = "offsets.npy"
+	raggedValues  = "values.npy"
+)
+
+// WriteRagged writes rows, a ragged array of variable-length rows, to w as
+// an npz archive holding two plain 1-dimensional arrays, in a CSR-like
+// layout:
+//
+//	offsets.npy  '<i8' array of the len(rows)+1 offsets of the rows
+//	values.npy   '<f8' array of the elements of all the rows, concatenated
+//
+// Row i is held by values[offsets[i]:offsets[i+1]], and offsets[0] is 0.
+// Unlike NumPy object arrays of arrays, such archives need no pickling and
+// can be read by NumPy as two plain arrays, e.g. with:
+//
+//	f = np.load("ragged.npz")
+//	rows = np.split(f["values"], f["offsets"][1:-1])
+//
+// The underlying writer w is not closed.
+func WriteRagged(w io.Writer, rows [][]float64, opts ...WriteOption) error {
+	var (
+		offsets = make([]int64, len(rows)+1)
+		n       = 0
+	)
+	for i, row := range rows {
+		n += len(row)
+		offsets[i+1] = int64(n)
+	}
+	values := make([]float64, 0, n)
+	for _, row := range rows {
+		values = append(values, row...)
+	}
+
+	wz := NewWriter(w, opts...)
+	defer wz.Close()
+
+	err := wz.Write(raggedOffsets, offsets)
+	if err != nil {
+		return err
+	}
+	err = wz.Write(raggedValues, values)
+	if err != nil {
+		return err
+	}
+
+	return wz.Close()
+}
+
+// ReadRagged reads the ragged array held in the npz archive r, which is
+// assumed to have the given size in bytes, as written by WriteRagged.
+//
+// The returned rows share the same backing array.
+// ReadRagged returns an error if the offsets are not increasing from 0 to
+// the number of values.
+func ReadRagged(r io.ReaderAt, size int64) ([][]float64, error) {
+	rz, err := NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{raggedOffsets, raggedValues} {
+		hdr := rz.Header(name)
+		if hdr == nil {
+			return nil, fmt.Errorf("npz: ragged array has no valid %q member", name)
+		}
+		if len(hdr.Descr.Shape) != 1 {
+			return nil, fmt.Errorf(
+				"npz: ragged array member %q has shape %v, want a 1-dimensional array",
+				name, hdr.Descr.Shape,
+			)
+		}
+	}
+
+	var (
+		offsets []int64
+		values  []float64
+	)
+	err = rz.Read(raggedOffsets, &offsets)
+	if err != nil {
+		return nil, err
+	}
+	err = rz.Read(raggedValues, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(offsets) == 0 || offsets[0] != 0 || offsets[len(offsets)-1] != int64(len(values)) {
+		return nil, fmt.Errorf(
+			"npz: ragged array offsets must go from 0 to the number of values (%d)",
+			len(values),
+		)
+	}
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < offsets[i-1] {
+			return nil, fmt.Errorf("npz: ragged array offsets #%d and #%d are decreasing", i-1, i)
+		}
+		if offsets[i] > int64(len(values)) {
+			return nil, fmt.Errorf(
+				"npz: ragged array offset #%d (%d) is past the number of values (%d)",
+				i, offsets[i], len(values),
+			)
+		}
+	}
+
+	rows := make([][]float64, len(offsets)-1)
+	for i := range rows {
+		beg, end := offsets[i], offsets[i+1]
+		rows[i] = values[beg:end:end]
+	}
+	return rows, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The npyio Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package npz
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestRagged(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		rows [][]float64
+		want [][]float64
+	}{
+		{
+			name: "rows",
+			rows: [][]float64{{1, 2, 3}, {4}, {}, {5, 6}},
+			want: [][]float64{{1, 2, 3}, {4}, {}, {5, 6}},
+		},
+		{
+			name: "nil-row",
+			rows: [][]float64{nil, {1}},
+			want: [][]float64{{}, {1}},
+		},
+		{
+			name: "empty",
+			rows: [][]float64{},
+			want: [][]float64{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := WriteRagged(buf, tc.rows)
+			if err != nil {
+				t.Fatalf("could not write ragged array: %+v", err)
+			}
+
+			rz, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("could not open archive: %+v", err)
+			}
+			if got, want := rz.Keys(), []string{"offsets.npy", "values.npy"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid members: got=%q, want=%q", got, want)
+			}
+
+			got, err := ReadRagged(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("could not read ragged array: %+v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid rows:\ngot= %v\nwant=%v", got, tc.want)
+			}
+			if len(got) > 1 && cap(got[0]) != len(got[0]) {
+				t.Fatalf("appending to a row would overwrite the next one")
+			}
+		})
+	}
+}
+
+func TestReadRaggedInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		vals map[string]interface{}
+	}{
+		{
+			name: "missing-values",
+			vals: map[string]interface{}{"offsets": []int64{0}},
+		},
+		{
+			name: "2d-offsets",
+			vals: map[string]interface{}{"offsets": [][]int64{{0, 1}}, "values": []float64{1}},
+		},
+		{
+			name: "decreasing",
+			vals: map[string]interface{}{"offsets": []int64{0, 2, 1, 3}, "values": []float64{1, 2, 3}},
+		},
+		{
+			name: "past-values-then-decreasing",
+			vals: map[string]interface{}{"offsets": []int64{0, 10, 3}, "values": []float64{1, 2, 3}},
+		},
+		{
+			name: "not-from-zero",
+			vals: map[string]interface{}{"offsets": []int64{1, 3}, "values": []float64{1, 2, 3}},
+		},
+		{
+			name: "past-values",
+			vals: map[string]interface{}{"offsets": []int64{0, 4}, "values": []float64{1, 2, 3}},
+		},
+		{
+			name: "no-offsets",
+			vals: map[string]interface{}{"offsets": []int64{}, "values": []float64{}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			wz := NewWriter(buf)
+			for _, k := range []string{"offsets", "values"} {
+				v, ok := tc.vals[k]
+				if !ok {
+					continue
+				}
+				err := wz.Write(k, v)
+				if err != nil {
+					t.Fatalf("could not write %q: %+v", k, err)
+				}
+			}
+			err := wz.Close()
+			if err != nil {
+				t.Fatalf("could not close archive: %+v", err)
+			}
+
+			_, err = ReadRagged(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
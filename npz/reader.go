@@ -6,9 +6,12 @@ package npz
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"strings"
 
 	"github.com/sbinet/npyio/npy"
 )
@@ -33,6 +36,179 @@ func Read(r io.ReaderAt, name string, ptr interface{}) error {
 	return nil
 }
 
+// ExtractMember copies the named member of the npz archive src, which is
+// assumed to have the given size in bytes, to dst as a standalone NumPy
+// data file.
+// The member is decompressed if needed but its array data is not decoded.
+func ExtractMember(dst io.Writer, src io.ReaderAt, size int64, name string) error {
+	rz, err := NewReader(src, size)
+	if err != nil {
+		return err
+	}
+	defer rz.Close()
+
+	rc, err := rz.Open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(dst, rc)
+	if err != nil {
+		return fmt.Errorf("npz: could not extract %q: %w", name, err)
+	}
+
+	err = rc.Close()
+	if err != nil {
+		return fmt.Errorf("npz: could not close %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// MemberInfo describes a member of an npz archive.
+type MemberInfo struct {
+	Name   string     // name of the member in the archive
+	Header npy.Header // NumPy header of the member
+
+	CompressedSize   int64 // size of the member in the archive, in bytes
+	UncompressedSize int64 // size of the NumPy data file, in bytes
+}
+
+// Summary returns the description of all the members of the npz archive r,
+// which is assumed to have the given size in bytes.
+// Only the headers of the members are decoded.
+func Summary(r io.ReaderAt, size int64) ([]MemberInfo, error) {
+	rz, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("npz: could not create zip reader: %w", err)
+	}
+
+	infos := make([]MemberInfo, len(rz.File))
+	for i, f := range rz.File {
+		hdr, err := header(f)
+		if err != nil {
+			return nil, fmt.Errorf("npz: could not read header of %q: %w", f.Name, err)
+		}
+		infos[i] = MemberInfo{
+			Name:             f.Name,
+			Header:           hdr,
+			CompressedSize:   int64(f.CompressedSize64),
+			UncompressedSize: int64(f.UncompressedSize64),
+		}
+	}
+
+	return infos, nil
+}
+
+func header(f *zip.File) (npy.Header, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return npy.Header{}, err
+	}
+	defer rc.Close()
+
+	r, err := npy.NewReader(rc)
+	if err != nil {
+		return npy.Header{}, err
+	}
+	return r.Header, nil
+}
+
+// Verify checks that r, which is assumed to have the given size in bytes,
+// holds a valid npz archive: every member must be a ".npy" file with a
+// valid NumPy header.
+// Only the headers of the members are decoded.
+//
+// Verify returns an error naming all the invalid members.
+func Verify(r io.ReaderAt, size int64) error {
+	rz, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("npz: could not create zip reader: %w", err)
+	}
+
+	var errs []error
+	for _, f := range rz.File {
+		err := verify(f)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("npz: invalid member %q: %w", f.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func verify(f *zip.File) error {
+	if !strings.HasSuffix(f.Name, ".npy") {
+		return fmt.Errorf("missing .npy suffix")
+	}
+
+	_, err := header(f)
+	return err
+}
+
+// ReadAll reads all the members of the npz archive r, which is assumed to
+// have the given size in bytes, as np.load followed by dict() would.
+//
+// The arrays are returned keyed by the names of the members, without their
+// ".npy" suffix, along with their headers, which hold their shapes.
+// Each array is decoded into a slice of the Go type of its data type
+// (e.g. []float64 for '<f8' arrays), holding its elements in memory
+// order, whatever its shape.
+//
+// Members that can not be decoded, e.g. because their data type has no Go
+// equivalent, are left out of the returned maps: ReadAll then returns an
+// error naming all of them.
+func ReadAll(r io.ReaderAt, size int64) (map[string]interface{}, map[string]npy.Header, error) {
+	rz, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, nil, fmt.Errorf("npz: could not create zip reader: %w", err)
+	}
+
+	var (
+		vals = make(map[string]interface{}, len(rz.File))
+		hdrs = make(map[string]npy.Header, len(rz.File))
+		errs []error
+	)
+	for _, f := range rz.File {
+		name := strings.TrimSuffix(f.Name, ".npy")
+		v, hdr, err := readMember(f)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("npz: could not read %q: %w", f.Name, err))
+			continue
+		}
+		vals[name] = v
+		hdrs[name] = hdr
+	}
+
+	return vals, hdrs, errors.Join(errs...)
+}
+
+// readMember decodes the array data of f into a slice of the Go type of its
+// data type.
+func readMember(f *zip.File) (interface{}, npy.Header, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, npy.Header{}, err
+	}
+	defer rc.Close()
+
+	r, err := npy.NewReader(rc)
+	if err != nil {
+		return nil, npy.Header{}, err
+	}
+	rt, err := r.Header.Dtype()
+	if err != nil {
+		return nil, r.Header, err
+	}
+	ptr := reflect.New(reflect.SliceOf(rt))
+	err = r.Read(ptr.Interface())
+	if err != nil {
+		return nil, r.Header, err
+	}
+	return ptr.Elem().Interface(), r.Header, nil
+}
+
 // Reader reads data from a compressed NumPy data file.
 type Reader struct {
 	r  io.ReaderAt
@@ -121,34 +297,51 @@ func (r *Reader) Keys() []string {
 }
 
 // Header returns the NumPy header metadata for the named array.
+// Only the header of the array is decoded.
 func (r *Reader) Header(name string) *npy.Header {
 	elm, err := r.get(name)
-	if elm == nil || err != nil {
+	if err != nil {
 		return nil
 	}
+	defer elm.Close()
 	return elm.hdr()
 }
 
 // Open opens the named npy section in the npz archive.
+// As with np.load, the ".npy" suffix of the section may be omitted.
+//
+// Open returns an error wrapping os.ErrNotExist if the archive holds no
+// such section.
 func (r *Reader) Open(name string) (io.ReadCloser, error) {
 	return r.open(name)
 }
 
 func (r *Reader) open(name string) (io.ReadCloser, error) {
+	f := r.lookup(name)
+	if f == nil && !strings.HasSuffix(name, ".npy") {
+		f = r.lookup(name + ".npy")
+	}
+	if f == nil {
+		return nil, fmt.Errorf("npz: could not find %q: %w", name, os.ErrNotExist)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"npz: could not open item %q from npz: %w",
+			name, err,
+		)
+	}
+	return rc, nil
+}
+
+func (r *Reader) lookup(name string) *zip.File {
 	for _, f := range r.rz.File {
-		if f.Name != name {
-			continue
+		if f.Name == name {
+			return f
 		}
-		rc, err := f.Open()
-		if err != nil {
-			return nil, fmt.Errorf(
-				"npz: could not open item %q from npz: %w",
-				name, err,
-			)
-		}
-		return rc, nil
 	}
-	return nil, fmt.Errorf("npz: could not find %q", name)
+	return nil
 }
 
 func (r *Reader) get(name string) (*ritem, error) {
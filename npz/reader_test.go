@@ -5,9 +5,18 @@
 package npz
 
 import (
+	"archive/zip"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/sbinet/npyio/npy"
+
 	"gonum.org/v1/gonum/mat"
 )
 
@@ -54,3 +63,311 @@ func TestReader(t *testing.T) {
 		})
 	}
 }
+
+func TestReaderLookup(t *testing.T) {
+	zr, err := Open("../testdata/data_float64_corder.npz")
+	if err != nil {
+		t.Fatalf("could not open archive: %+v", err)
+	}
+	defer zr.Close()
+
+	for _, name := range []string{"arr0", "arr0.npy"} {
+		hdr := zr.Header(name)
+		if hdr == nil {
+			t.Fatalf("could not find header of %q", name)
+		}
+		if got, want := hdr.Descr.Shape, []int{2, 3}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid shape for %q: got=%v, want=%v", name, got, want)
+		}
+
+		var got []float64
+		err = zr.Read(name, &got)
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", name, err)
+		}
+		if want := []float64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid value for %q:\ngot= %v\nwant=%v", name, got, want)
+		}
+	}
+
+	if hdr := zr.Header("missing"); hdr != nil {
+		t.Fatalf("unexpected header for missing array: %+v", hdr)
+	}
+
+	var v []float64
+	err = zr.Read("missing", &v)
+	switch {
+	case err == nil:
+		t.Fatalf("expected an error")
+	case !errors.Is(err, os.ErrNotExist):
+		t.Fatalf("invalid error: %+v", err)
+	}
+}
+
+func TestReaderZipComment(t *testing.T) {
+	const fname = "../testdata/data_comment.npz"
+
+	zr, err := Open(fname)
+	if err != nil {
+		t.Fatalf("could not open %q: %+v", fname, err)
+	}
+	defer zr.Close()
+
+	if got, want := zr.Keys(), []string{"arr0.npy", "arr1.npy"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid keys: got=%q, want=%q", got, want)
+	}
+
+	var arr0 []float64
+	err = zr.Read("arr0.npy", &arr0)
+	if err != nil {
+		t.Fatalf("could not read arr0: %+v", err)
+	}
+	if got, want := arr0, []float64{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid arr0: got=%v, want=%v", got, want)
+	}
+
+	var arr1 []int32
+	err = zr.Read("arr1.npy", &arr1)
+	if err != nil {
+		t.Fatalf("could not read arr1: %+v", err)
+	}
+	if got, want := arr1, []int32{0, 1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid arr1: got=%v, want=%v", got, want)
+	}
+
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open %q: %+v", fname, err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		t.Fatalf("could not stat %q: %+v", fname, err)
+	}
+	err = Verify(f, stat.Size())
+	if err != nil {
+		t.Fatalf("could not verify %q: %+v", fname, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	for _, fname := range []string{
+		"../testdata/data_float64_corder.npz",
+		"../testdata/data_float64_forder.npz",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			f, err := os.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open %q: %+v", fname, err)
+			}
+			defer f.Close()
+
+			stat, err := f.Stat()
+			if err != nil {
+				t.Fatalf("could not stat %q: %+v", fname, err)
+			}
+
+			err = Verify(f, stat.Size())
+			if err != nil {
+				t.Fatalf("invalid npz: %+v", err)
+			}
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	wz := zip.NewWriter(buf)
+	for _, member := range []struct {
+		name string
+		data []byte
+	}{
+		{"ok.npy", nil},
+		{"no-suffix", nil},
+		{"bad-magic.npy", []byte("not a npy file")},
+	} {
+		w, err := wz.Create(member.name)
+		if err != nil {
+			t.Fatalf("could not create member %q: %+v", member.name, err)
+		}
+		data := member.data
+		if data == nil {
+			o := new(bytes.Buffer)
+			err = npy.Write(o, []float64{1, 2, 3})
+			if err != nil {
+				t.Fatalf("could not write npy data: %+v", err)
+			}
+			data = o.Bytes()
+		}
+		_, err = w.Write(data)
+		if err != nil {
+			t.Fatalf("could not write member %q: %+v", member.name, err)
+		}
+	}
+	err := wz.Close()
+	if err != nil {
+		t.Fatalf("could not close zip: %+v", err)
+	}
+
+	err = Verify(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if !errors.Is(err, npy.ErrInvalidNumPyFormat) {
+		t.Fatalf("expected an ErrInvalidNumPyFormat error, got: %+v", err)
+	}
+	for _, name := range []string{"no-suffix", "bad-magic.npy"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("error does not name member %q: %+v", name, err)
+		}
+	}
+	if strings.Contains(err.Error(), `"ok.npy"`) {
+		t.Fatalf("error names valid member: %+v", err)
+	}
+}
+
+func TestExtractMember(t *testing.T) {
+	const fname = "../testdata/data_float64_forder.npz"
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open %q: %+v", fname, err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		t.Fatalf("could not stat %q: %+v", fname, err)
+	}
+
+	o := new(bytes.Buffer)
+	err = ExtractMember(o, f, stat.Size(), "arr0.npy")
+	if err != nil {
+		t.Fatalf("could not extract member: %+v", err)
+	}
+
+	var m mat.Dense
+	err = npy.Read(o, &m)
+	if err != nil {
+		t.Fatalf("could not read extracted member: %+v", err)
+	}
+
+	want := mat.NewDense(2, 3, []float64{0, 2, 4, 1, 3, 5})
+	if !mat.Equal(&m, want) {
+		t.Fatalf("invalid data:\ngot= %v\nwant=%v", &m, want)
+	}
+
+	err = ExtractMember(io.Discard, f, stat.Size(), "missing.npy")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	const fname = "../testdata/data_float64_forder.npz"
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open %q: %+v", fname, err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		t.Fatalf("could not stat %q: %+v", fname, err)
+	}
+
+	infos, err := Summary(f, stat.Size())
+	if err != nil {
+		t.Fatalf("could not summarize npz: %+v", err)
+	}
+
+	want := map[string][]int{
+		"arr0.npy": {2, 3},
+		"arr1.npy": {6, 1},
+	}
+	if got, want := len(infos), len(want); got != want {
+		t.Fatalf("invalid number of members: got=%d, want=%d", got, want)
+	}
+	for _, info := range infos {
+		shape, ok := want[info.Name]
+		if !ok {
+			t.Fatalf("unexpected member %q", info.Name)
+		}
+		if got, want := info.Header.Descr.Shape, shape; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: invalid shape: got=%v, want=%v", info.Name, got, want)
+		}
+		if got, want := info.Header.Descr.Type, "<f8"; got != want {
+			t.Fatalf("%s: invalid dtype: got=%q, want=%q", info.Name, got, want)
+		}
+		if !info.Header.Descr.Fortran {
+			t.Fatalf("%s: invalid order", info.Name)
+		}
+		if got, want := info.UncompressedSize, int64(128); got != want {
+			t.Fatalf("%s: invalid size: got=%d, want=%d", info.Name, got, want)
+		}
+		if info.CompressedSize <= 0 {
+			t.Fatalf("%s: invalid compressed size: %d", info.Name, info.CompressedSize)
+		}
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	type point struct {
+		X, Y float64
+	}
+
+	buf := new(bytes.Buffer)
+	w := NewWriter(buf)
+	for _, v := range []struct {
+		name string
+		val  interface{}
+	}{
+		{"ints", []int32{1, 2, 3}},
+		{"dense", mat.NewDense(2, 3, []float64{0, 1, 2, 3, 4, 5})},
+		{"scalar", uint8(42)},
+		{"points", []point{{1, 2}}},
+	} {
+		err := w.Write(v.name, v.val)
+		if err != nil {
+			t.Fatalf("could not write %q: %+v", v.name, err)
+		}
+	}
+	err := w.Close()
+	if err != nil {
+		t.Fatalf("could not close npz writer: %+v", err)
+	}
+
+	vals, hdrs, err := ReadAll(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	switch {
+	case err == nil:
+		t.Fatalf("expected an error")
+	case !errors.Is(err, npy.ErrInvalidType) || !strings.Contains(err.Error(), "points.npy"):
+		t.Fatalf("invalid error: %+v", err)
+	}
+
+	want := map[string]interface{}{
+		"ints":   []int32{1, 2, 3},
+		"dense":  []float64{0, 1, 2, 3, 4, 5},
+		"scalar": []uint8{42},
+	}
+	if !reflect.DeepEqual(vals, want) {
+		t.Fatalf("invalid arrays:\ngot= %v\nwant=%v", vals, want)
+	}
+
+	shapes := map[string][]int{
+		"ints":   {3},
+		"dense":  {2, 3},
+		"scalar": nil,
+	}
+	if got, want := len(hdrs), len(shapes); got != want {
+		t.Fatalf("invalid number of headers: got=%d, want=%d", got, want)
+	}
+	for name, shape := range shapes {
+		if got := hdrs[name].Descr.Shape; !reflect.DeepEqual(got, shape) {
+			t.Fatalf("%s: invalid shape: got=%v, want=%v", name, got, shape)
+		}
+	}
+
+	_, _, err = ReadAll(bytes.NewReader([]byte("not a zip")), 9)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
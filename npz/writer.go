@@ -10,6 +10,8 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/sbinet/npyio/npy"
 )
@@ -17,8 +19,8 @@ import (
 // Write writes the values vs to the named npz archive file.
 //
 // The data-array will always be written out in C-order (row-major).
-func Write(name string, vs map[string]interface{}) error {
-	w, err := Create(name)
+func Write(name string, vs map[string]interface{}, opts ...WriteOption) error {
+	w, err := Create(name, opts...)
 	if err != nil {
 		return err
 	}
@@ -50,32 +52,76 @@ type Writer struct {
 	w  io.Writer
 	wz *zip.Writer
 	wc io.Closer
+
+	stamp  bool   // whether to stamp members with their modification time
+	owrite bool   // whether Append may replace an existing member
+	method uint16 // zip compression method of the members
+}
+
+// WriteOption configures a Writer.
+type WriteOption func(w *Writer)
+
+// WithDeterministic configures whether a Writer produces deterministic
+// archives.
+// Members of deterministic archives carry no modification time, so that
+// identical inputs yield byte-identical archives.
+// Otherwise, members are stamped with the time they were written at,
+// as NumPy does.
+// The default is to produce deterministic archives.
+func WithDeterministic(v bool) WriteOption {
+	return func(w *Writer) {
+		w.stamp = !v
+	}
+}
+
+// WithOverwrite configures whether Append may replace an existing member
+// with the same name.
+// The default is to return an error.
+func WithOverwrite(v bool) WriteOption {
+	return func(w *Writer) {
+		w.owrite = v
+	}
+}
+
+// WithCompression configures whether the members of the archive are
+// compressed with DEFLATE, as np.savez_compressed does, or stored
+// uncompressed, as np.savez does.
+// The default is to compress members.
+func WithCompression(v bool) WriteOption {
+	return func(w *Writer) {
+		w.method = zip.Store
+		if v {
+			w.method = zip.Deflate
+		}
+	}
 }
 
 // Create creates the named compressed NumPy data file for writing.
-func Create(name string) (*Writer, error) {
+func Create(name string, opts ...WriteOption) (*Writer, error) {
 	w, err := os.Create(name)
 	if err != nil {
 		return nil, fmt.Errorf("npz: could not create %q: %w", name, err)
 	}
 
-	wz := zip.NewWriter(w)
+	wz := NewWriter(w, opts...)
+	wz.wc = w
 
-	return &Writer{
-		w:  w,
-		wz: wz,
-		wc: w,
-	}, nil
+	return wz, nil
 }
 
 // NewWriter returns a new npz writer.
 //
 // The returned npz writer won't close the underlying writer.
-func NewWriter(w io.Writer) *Writer {
-	return &Writer{
-		w:  w,
-		wz: zip.NewWriter(w),
+func NewWriter(w io.Writer, opts ...WriteOption) *Writer {
+	wz := &Writer{
+		w:      w,
+		wz:     zip.NewWriter(w),
+		method: zip.Deflate,
+	}
+	for _, opt := range opts {
+		opt(wz)
 	}
+	return wz
 }
 
 // Close closes the npz archive.
@@ -112,8 +158,19 @@ func (w *Writer) Close() error {
 }
 
 // Write writes the named NumPy array data to the npz archive.
+//
+// As with np.savez, the ".npy" suffix is appended to name when missing.
 func (w *Writer) Write(name string, v interface{}) error {
-	ww, err := w.wz.Create(name)
+	name = memberName(name)
+	fh := &zip.FileHeader{
+		Name:   name,
+		Method: w.method,
+	}
+	if w.stamp {
+		fh.Modified = time.Now()
+	}
+
+	ww, err := w.wz.CreateHeader(fh)
 	if err != nil {
 		return fmt.Errorf("npz: could not create npz entry %q: %w", name, err)
 	}
@@ -125,3 +182,11 @@ func (w *Writer) Write(name string, v interface{}) error {
 
 	return nil
 }
+
+// memberName returns the name of the archive member holding the array name.
+func memberName(name string) string {
+	if strings.HasSuffix(name, ".npy") {
+		return name
+	}
+	return name + ".npy"
+}
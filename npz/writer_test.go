@@ -5,9 +5,11 @@
 package npz
 
 import (
+	"archive/zip"
 	"bytes"
 	"reflect"
 	"testing"
+	"time"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -99,3 +101,99 @@ func TestWrite(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteDeterministic(t *testing.T) {
+	write := func(opts ...WriteOption) []byte {
+		buf := new(bytes.Buffer)
+		wz := NewWriter(buf, opts...)
+		err := wz.Write("arr0.npy", []float64{1, 2, 3})
+		if err != nil {
+			t.Fatalf("could not write value: %+v", err)
+		}
+		err = wz.Close()
+		if err != nil {
+			t.Fatalf("could not close writer: %+v", err)
+		}
+		return buf.Bytes()
+	}
+
+	modtime := func(raw []byte) time.Time {
+		rz, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+		if err != nil {
+			t.Fatalf("could not open archive: %+v", err)
+		}
+		return rz.File[0].Modified
+	}
+
+	for _, opts := range [][]WriteOption{
+		nil,
+		{WithDeterministic(true)},
+	} {
+		v1 := write(opts...)
+		v2 := write(opts...)
+		if !bytes.Equal(v1, v2) {
+			t.Fatalf("archives are not byte-identical")
+		}
+		if got := modtime(v1); got.Year() > 1980 {
+			t.Fatalf("invalid modification time: %v", got)
+		}
+	}
+
+	now := time.Now()
+	raw := write(WithDeterministic(false))
+	if got := modtime(raw); got.Sub(now).Abs() > time.Minute {
+		t.Fatalf("invalid modification time: got=%v, want=%v", got, now)
+	}
+}
+
+func TestWriteCompression(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		opts   []WriteOption
+		method uint16
+	}{
+		{"default", nil, zip.Deflate},
+		{"compressed", []WriteOption{WithCompression(true)}, zip.Deflate},
+		{"stored", []WriteOption{WithCompression(false)}, zip.Store},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			wz := NewWriter(buf, tc.opts...)
+			for _, name := range []string{"arr0", "arr1.npy"} {
+				err := wz.Write(name, []float64{1, 2, 3})
+				if err != nil {
+					t.Fatalf("could not write value: %+v", err)
+				}
+			}
+			err := wz.Close()
+			if err != nil {
+				t.Fatalf("could not close writer: %+v", err)
+			}
+
+			rz, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("could not open archive: %+v", err)
+			}
+			for i, want := range []string{"arr0.npy", "arr1.npy"} {
+				f := rz.File[i]
+				if f.Name != want {
+					t.Fatalf("invalid member name: got=%q, want=%q", f.Name, want)
+				}
+				if f.Method != tc.method {
+					t.Fatalf("invalid compression method: got=%d, want=%d", f.Method, tc.method)
+				}
+			}
+
+			for _, name := range []string{"arr0", "arr0.npy", "arr1"} {
+				var got []float64
+				err = Read(bytes.NewReader(buf.Bytes()), name, &got)
+				if err != nil {
+					t.Fatalf("could not read %q: %+v", name, err)
+				}
+				if want := []float64{1, 2, 3}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid value for %q:\ngot= %v\nwant=%v", name, got, want)
+				}
+			}
+		})
+	}
+}